@@ -0,0 +1,186 @@
+// Package anim plays animated GIF and APNG images on a demos.Displayer. An
+// animation is decoded once into fully composed frames, which are then scaled
+// to the display size the first time they are shown and cached for the
+// following loops.
+package anim
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"image"
+	imagedraw "image/draw"
+	"image/gif"
+	"io"
+	"time"
+
+	"github.com/kettek/apng"
+)
+
+var (
+	errUnknownFormat = errors.New("anim: unknown image format")
+	errNoFrames      = errors.New("anim: image has no frames")
+)
+
+// Browsers show GIF frames with a delay of 10ms or less (0 or 1 centiseconds)
+// for 100ms, because many old GIFs have a delay of 0 and expect to be played at
+// this speed. APNG delays are used as stored.
+const (
+	minGIFDelay  = 2 // centiseconds
+	defaultDelay = 100 * time.Millisecond
+)
+
+// Frame is a single, fully composed frame of an animation.
+type Frame struct {
+	Image *image.RGBA
+	Delay time.Duration // how long this frame is shown
+}
+
+// Animation is a decoded animated image. All frames have the same size.
+type Animation struct {
+	Width, Height int
+	Frames        []Frame
+
+	// LoopCount is the number of times the animation is played, or 0 to loop
+	// forever.
+	LoopCount int
+}
+
+// Duration returns the duration of a single loop of the animation.
+func (a *Animation) Duration() time.Duration {
+	var d time.Duration
+	for _, f := range a.Frames {
+		d += f.Delay
+	}
+	return d
+}
+
+// Decode reads an animated GIF or APNG image. The format is detected from the
+// file header. Still images are read as an animation of a single frame.
+func Decode(r io.Reader) (*Animation, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(8)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(header, []byte("GIF8")):
+		return DecodeGIF(br)
+	case bytes.HasPrefix(header, []byte("\x89PNG\r\n\x1a\n")):
+		return DecodeAPNG(br)
+	default:
+		return nil, errUnknownFormat
+	}
+}
+
+// DecodeGIF reads an animated GIF image.
+func DecodeGIF(r io.Reader) (*Animation, error) {
+	g, err := gif.DecodeAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(g.Image) == 0 {
+		return nil, errNoFrames
+	}
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	if bounds.Empty() {
+		bounds = g.Image[0].Bounds()
+	}
+	a := &Animation{
+		Width:  bounds.Dx(),
+		Height: bounds.Dy(),
+	}
+	// GIF stores the number of repetitions after the first play, with -1
+	// meaning the animation is played only once.
+	switch {
+	case g.LoopCount < 0:
+		a.LoopCount = 1
+	case g.LoopCount > 0:
+		a.LoopCount = g.LoopCount + 1
+	}
+	canvas := image.NewRGBA(bounds)
+	for i, img := range g.Image {
+		var previous *image.RGBA
+		disposal := byte(gif.DisposalNone)
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+		if disposal == gif.DisposalPrevious {
+			previous = cloneRGBA(canvas)
+		}
+		imagedraw.Draw(canvas, img.Bounds(), img, img.Bounds().Min, imagedraw.Over)
+		delay := defaultDelay
+		if i < len(g.Delay) && g.Delay[i] >= minGIFDelay {
+			delay = time.Duration(g.Delay[i]) * 10 * time.Millisecond
+		}
+		a.Frames = append(a.Frames, Frame{Image: cloneRGBA(canvas), Delay: delay})
+		switch disposal {
+		case gif.DisposalBackground:
+			// Browsers clear to transparent instead of the background color.
+			imagedraw.Draw(canvas, img.Bounds(), image.Transparent, image.Point{}, imagedraw.Src)
+		case gif.DisposalPrevious:
+			canvas = previous
+		}
+	}
+	return a, nil
+}
+
+// DecodeAPNG reads an animated PNG image. Regular PNG images are read as an
+// animation with a single frame.
+func DecodeAPNG(r io.Reader) (*Animation, error) {
+	p, err := apng.DecodeAll(r)
+	if err != nil {
+		return nil, err
+	}
+	frames := p.Frames
+	if len(frames) > 1 && frames[0].IsDefault {
+		// The default image is only shown by decoders without APNG support.
+		frames = frames[1:]
+	}
+	if len(frames) == 0 || frames[0].Image == nil {
+		return nil, errNoFrames
+	}
+	bounds := frames[0].Image.Bounds()
+	a := &Animation{
+		Width:     bounds.Dx(),
+		Height:    bounds.Dy(),
+		LoopCount: int(p.LoopCount),
+	}
+	canvas := image.NewRGBA(image.Rect(0, 0, a.Width, a.Height))
+	for i, f := range frames {
+		if f.Image == nil {
+			return nil, errNoFrames
+		}
+		rect := f.Image.Bounds().Sub(f.Image.Bounds().Min).Add(image.Pt(f.XOffset, f.YOffset))
+		var previous *image.RGBA
+		disposal := f.DisposeOp
+		if disposal == apng.DISPOSE_OP_PREVIOUS && i == 0 {
+			// There is no previous frame, so the spec says to treat this as
+			// clearing to transparent.
+			disposal = apng.DISPOSE_OP_BACKGROUND
+		}
+		if disposal == apng.DISPOSE_OP_PREVIOUS {
+			previous = cloneRGBA(canvas)
+		}
+		op := imagedraw.Over
+		if f.BlendOp == apng.BLEND_OP_SOURCE {
+			op = imagedraw.Src
+		}
+		imagedraw.Draw(canvas, rect, f.Image, f.Image.Bounds().Min, op)
+		delay := time.Duration(f.GetDelay() * float64(time.Second))
+		a.Frames = append(a.Frames, Frame{Image: cloneRGBA(canvas), Delay: delay})
+		switch disposal {
+		case apng.DISPOSE_OP_BACKGROUND:
+			imagedraw.Draw(canvas, rect, image.Transparent, image.Point{}, imagedraw.Src)
+		case apng.DISPOSE_OP_PREVIOUS:
+			canvas = previous
+		}
+	}
+	return a, nil
+}
+
+func cloneRGBA(img *image.RGBA) *image.RGBA {
+	clone := image.NewRGBA(img.Bounds())
+	copy(clone.Pix, img.Pix)
+	return clone
+}
@@ -0,0 +1,245 @@
+package anim
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+	"time"
+
+	"github.com/aykevl/ledsgo/draw"
+	"github.com/aykevl/ledsgo/sprite"
+	"github.com/kettek/apng"
+)
+
+var (
+	red   = color.RGBA{255, 0, 0, 255}
+	green = color.RGBA{0, 255, 0, 255}
+	blue  = color.RGBA{0, 0, 255, 255}
+	clear = color.RGBA{}
+)
+
+// Check all pixels of the image, given as rows of colors.
+func checkImage(t *testing.T, name string, img *image.RGBA, rows [][]color.RGBA) {
+	t.Helper()
+	for y, row := range rows {
+		for x, c := range row {
+			if got := img.RGBAAt(x, y); got != c {
+				t.Errorf("%s: pixel (%d, %d): expected %v, got %v", name, x, y, c, got)
+			}
+		}
+	}
+}
+
+func TestDecodeGIF(t *testing.T) {
+	palette := color.Palette{clear, red, green, blue}
+	frame := func(r image.Rectangle, index uint8) *image.Paletted {
+		img := image.NewPaletted(r, palette)
+		for i := range img.Pix {
+			img.Pix[i] = index
+		}
+		return img
+	}
+	g := &gif.GIF{
+		Image: []*image.Paletted{
+			frame(image.Rect(0, 0, 4, 2), 1),
+			frame(image.Rect(2, 0, 4, 2), 2),
+			frame(image.Rect(0, 0, 1, 1), 3),
+		},
+		Delay:     []int{2, 1, 0},
+		Disposal:  []byte{gif.DisposalNone, gif.DisposalBackground, gif.DisposalNone},
+		LoopCount: -1,
+		Config:    image.Config{ColorModel: palette, Width: 4, Height: 2},
+	}
+	buf := &bytes.Buffer{}
+	if err := gif.EncodeAll(buf, g); err != nil {
+		t.Fatal("could not encode GIF:", err)
+	}
+	a, err := Decode(buf)
+	if err != nil {
+		t.Fatal("could not decode GIF:", err)
+	}
+	if a.Width != 4 || a.Height != 2 || len(a.Frames) != 3 {
+		t.Fatalf("unexpected animation: %dx%d, %d frames", a.Width, a.Height, len(a.Frames))
+	}
+	if a.LoopCount != 1 {
+		t.Errorf("expected to play once, got loop count %d", a.LoopCount)
+	}
+	// Delays of 0 and 1 centiseconds are shown for 100ms, like browsers do.
+	for i, delay := range []time.Duration{20 * time.Millisecond, 100 * time.Millisecond, 100 * time.Millisecond} {
+		if a.Frames[i].Delay != delay {
+			t.Errorf("frame %d: expected delay %v, got %v", i, delay, a.Frames[i].Delay)
+		}
+	}
+	checkImage(t, "frame 0", a.Frames[0].Image, [][]color.RGBA{
+		{red, red, red, red},
+		{red, red, red, red},
+	})
+	checkImage(t, "frame 1", a.Frames[1].Image, [][]color.RGBA{
+		{red, red, green, green},
+		{red, red, green, green},
+	})
+	// The green part was cleared by frame 1.
+	checkImage(t, "frame 2", a.Frames[2].Image, [][]color.RGBA{
+		{blue, red, clear, clear},
+		{red, red, clear, clear},
+	})
+}
+
+func TestDecodeAPNG(t *testing.T) {
+	solid := func(w, h int, c color.RGBA) image.Image {
+		img := image.NewRGBA(image.Rect(0, 0, w, h))
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				img.SetRGBA(x, y, c)
+			}
+		}
+		return img
+	}
+	p := apng.APNG{
+		LoopCount: 2,
+		Frames: []apng.Frame{
+			{Image: solid(2, 2, red), DelayNumerator: 1, DelayDenominator: 10},
+			{Image: solid(1, 1, green), XOffset: 1, YOffset: 1, DelayNumerator: 5, DisposeOp: apng.DISPOSE_OP_PREVIOUS, BlendOp: apng.BLEND_OP_OVER},
+			{Image: solid(1, 2, blue), DelayNumerator: 1, DelayDenominator: 4},
+			{Image: solid(1, 2, blue), DelayNumerator: 1, DelayDenominator: 60},
+		},
+	}
+	buf := &bytes.Buffer{}
+	if err := apng.Encode(buf, p); err != nil {
+		t.Fatal("could not encode APNG:", err)
+	}
+	a, err := Decode(buf)
+	if err != nil {
+		t.Fatal("could not decode APNG:", err)
+	}
+	if len(a.Frames) != 4 || a.LoopCount != 2 {
+		t.Fatalf("unexpected animation: %d frames, loop count %d", len(a.Frames), a.LoopCount)
+	}
+	// Short APNG delays are not changed, unlike short GIF delays.
+	for i, delay := range []time.Duration{100 * time.Millisecond, 50 * time.Millisecond, 250 * time.Millisecond, time.Second / 60} {
+		if a.Frames[i].Delay != delay {
+			t.Errorf("frame %d: expected delay %v, got %v", i, delay, a.Frames[i].Delay)
+		}
+	}
+	checkImage(t, "frame 1", a.Frames[1].Image, [][]color.RGBA{
+		{red, red},
+		{red, green},
+	})
+	// Frame 1 is disposed by restoring frame 0.
+	checkImage(t, "frame 2", a.Frames[2].Image, [][]color.RGBA{
+		{blue, red},
+		{blue, red},
+	})
+
+	if _, err := Decode(bytes.NewReader([]byte("not an image"))); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestPlayerIndex(t *testing.T) {
+	a := &Animation{
+		Width:  1,
+		Height: 1,
+		Frames: []Frame{
+			{Delay: 100 * time.Millisecond},
+			{Delay: 200 * time.Millisecond},
+			{Delay: 100 * time.Millisecond},
+		},
+		LoopCount: 2,
+	}
+	p := NewPlayer(a)
+	for _, tc := range []struct {
+		ms      int
+		index   int
+		playing bool
+	}{
+		{0, 0, true},
+		{99, 0, true},
+		{100, 1, true},
+		{299, 1, true},
+		{300, 2, true},
+		{400, 0, true},
+		{799, 2, true},
+		{800, 2, false},
+		{5000, 2, false},
+	} {
+		index, playing := p.Index(time.Duration(tc.ms) * time.Millisecond)
+		if index != tc.index || playing != tc.playing {
+			t.Errorf("at %dms: expected frame %d (playing: %v), got %d (playing: %v)", tc.ms, tc.index, tc.playing, index, playing)
+		}
+	}
+	p.Loops = Forever
+	if index, playing := p.Index(5050 * time.Millisecond); index != 1 || !playing {
+		t.Errorf("looping forever: expected frame 1, got %d (playing: %v)", index, playing)
+	}
+}
+
+func TestPlayerDraw(t *testing.T) {
+	// A 2x1 animation with a red and a green pixel.
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.SetRGBA(0, 0, red)
+	img.SetRGBA(1, 0, green)
+	second := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	a := &Animation{
+		Width:  2,
+		Height: 1,
+		Frames: []Frame{
+			{Image: img, Delay: time.Second},
+			{Image: second, Delay: time.Second},
+		},
+		LoopCount: 1,
+	}
+	white := color.RGBA{255, 255, 255, 255}
+	for _, tc := range []struct {
+		scale Scale
+		rows  [][]color.RGBA
+	}{
+		{Fit, [][]color.RGBA{
+			{white, white, white, white},
+			{red, red, green, green},
+			{red, red, green, green},
+			{white, white, white, white},
+		}},
+		// Fill crops the left and right side of the animation.
+		{Fill, [][]color.RGBA{
+			{red, red, green, green},
+			{red, red, green, green},
+			{red, red, green, green},
+			{red, red, green, green},
+		}},
+		{Stretch, [][]color.RGBA{
+			{red, red, green, green},
+			{red, red, green, green},
+			{red, red, green, green},
+			{red, red, green, green},
+		}},
+	} {
+		display := draw.NewBuffer(4, 4)
+		p := NewPlayer(a)
+		p.Scale = tc.scale
+		p.Background = white
+		p.Options = sprite.Options{Filter: sprite.Nearest, Linear: true}
+		start := time.Unix(100, 0)
+		if !p.Draw(display, start) {
+			t.Errorf("scale %d: animation finished too early", tc.scale)
+		}
+		for y, row := range tc.rows {
+			for x, c := range row {
+				if got := display.GetPixel(int16(x), int16(y)); got != c {
+					t.Errorf("scale %d: pixel (%d, %d): expected %v, got %v", tc.scale, x, y, c, got)
+				}
+			}
+		}
+		if p.Draw(display, start.Add(2*time.Second)) {
+			t.Errorf("scale %d: animation didn't finish", tc.scale)
+		}
+		if c := display.GetPixel(3, 1); c != white {
+			t.Errorf("scale %d: expected the background in the last frame, got %v", tc.scale, c)
+		}
+		if len(p.cache) != 2 || p.cache[0] == nil || p.cache[1] == nil {
+			t.Errorf("scale %d: frames were not cached", tc.scale)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package anim
+
+import (
+	"image"
+	"image/color"
+	"time"
+
+	"github.com/aykevl/ledsgo/demos"
+	"github.com/aykevl/ledsgo/draw"
+	"github.com/aykevl/ledsgo/sprite"
+)
+
+// Scale selects how an animation is scaled to the display size.
+type Scale uint8
+
+const (
+	// Fit scales the animation to fit in the display while keeping the aspect
+	// ratio. The remaining area is filled with the background color.
+	Fit Scale = iota
+
+	// Fill scales the animation to cover the whole display while keeping the
+	// aspect ratio. Parts of the animation may be cut off.
+	Fill
+
+	// Stretch scales the animation to the display size, ignoring the aspect
+	// ratio.
+	Stretch
+)
+
+// Forever can be used as Player.Loops to ignore the loop count of the
+// animation and loop forever.
+const Forever = -1
+
+// Player plays an animation on a display. Scaled frames are cached, so that
+// every frame is only scaled once. The cache is cleared when the display size
+// changes or Reset is called.
+type Player struct {
+	Animation  *Animation
+	Scale      Scale
+	Background color.RGBA     // shown behind transparent pixels (alpha is ignored)
+	Options    sprite.Options // filter and color conversion
+
+	// Loops is the number of times the animation is played. It is 0 to use
+	// the loop count stored in the animation, or Forever to loop forever.
+	Loops int
+
+	start         time.Time
+	started       bool
+	width, height int16
+	cache         []*draw.Buffer
+}
+
+// NewPlayer returns a new player for the given animation.
+func NewPlayer(a *Animation) *Player {
+	return &Player{Animation: a}
+}
+
+// Reset restarts the animation on the next call to Draw, and clears the frame
+// cache. It must be called after changing the Scale, Background or Options
+// field.
+func (p *Player) Reset() {
+	p.started = false
+	p.cache = nil
+}
+
+// Index returns the index of the frame to show after the given time since the
+// start of the animation, and whether the animation is still playing. When the
+// last loop has finished, the last frame stays visible.
+func (p *Player) Index(elapsed time.Duration) (int, bool) {
+	frames := p.Animation.Frames
+	loops := p.Loops
+	if loops == 0 {
+		loops = p.Animation.LoopCount
+	}
+	duration := p.Animation.Duration()
+	if duration <= 0 {
+		return 0, false
+	}
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	if loops > 0 && elapsed >= duration*time.Duration(loops) {
+		return len(frames) - 1, false
+	}
+	elapsed %= duration
+	for i, f := range frames {
+		if elapsed < f.Delay {
+			return i, true
+		}
+		elapsed -= f.Delay
+	}
+	return len(frames) - 1, true
+}
+
+// Draw draws the frame for the given time on the display. The animation starts
+// at the time of the first call to Draw (or the first call after Reset). It
+// returns false when the animation has finished.
+func (p *Player) Draw(display demos.Displayer, now time.Time) bool {
+	if !p.started {
+		p.start = now
+		p.started = true
+	}
+	width, height := display.Size()
+	if width != p.width || height != p.height || p.cache == nil {
+		p.width = width
+		p.height = height
+		p.cache = make([]*draw.Buffer, len(p.Animation.Frames))
+	}
+	index, playing := p.Index(now.Sub(p.start))
+	if p.cache[index] == nil {
+		p.cache[index] = p.render(p.Animation.Frames[index].Image)
+	}
+	p.cache[index].DrawTo(display)
+	return playing
+}
+
+// Scale the image to the display size.
+func (p *Player) render(img image.Image) *draw.Buffer {
+	buf := draw.NewBuffer(p.width, p.height)
+	background := p.Background
+	background.A = 0xff
+	buf.Clear(background)
+	sprite.Draw(buf, p.rect(), img, &p.Options)
+	return buf
+}
+
+// Return the rectangle of the display to draw the animation in.
+func (p *Player) rect() image.Rectangle {
+	dw, dh := int(p.width), int(p.height)
+	if p.Scale == Stretch {
+		return image.Rect(0, 0, dw, dh)
+	}
+	aw, ah := p.Animation.Width, p.Animation.Height
+	w, h := dw, dh
+	// The animation is relatively wider than the display if aw/ah > dw/dh.
+	if (aw*dh > ah*dw) == (p.Scale == Fit) {
+		h = (ah*dw + aw/2) / aw
+	} else {
+		w = (aw*dh + ah/2) / ah
+	}
+	x := (dw - w) / 2
+	y := (dh - h) / 2
+	return image.Rect(x, y, x+w, y+h)
+}
@@ -0,0 +1,269 @@
+package artnet
+
+import (
+	"bytes"
+	"image/color"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/aykevl/ledsgo"
+	"github.com/aykevl/ledsgo/dmx"
+)
+
+func TestDmxPacket(t *testing.T) {
+	p := &DmxPacket{Sequence: 5, Physical: 1, Universe: 0x1234, Data: []byte{1, 2, 3}}
+	buf := p.Append(nil)
+	expected := []byte{
+		'A', 'r', 't', '-', 'N', 'e', 't', 0,
+		0x00, 0x50, // OpCode (little endian)
+		0, 14, // protocol version
+		5, 1, // sequence, physical
+		0x34, 0x12, // SubUni, Net
+		0, 4, // length (padded to an even number)
+		1, 2, 3, 0,
+	}
+	if !bytes.Equal(buf, expected) {
+		t.Errorf("unexpected packet\nexpected: % x\nactual:   % x", expected, buf)
+	}
+
+	packet, err := Parse(buf)
+	if err != nil {
+		t.Fatal("could not parse packet:", err)
+	}
+	p2, ok := packet.(*DmxPacket)
+	if !ok || p2.Sequence != 5 || p2.Physical != 1 || p2.Universe != 0x1234 || !bytes.Equal(p2.Data, []byte{1, 2, 3, 0}) {
+		t.Errorf("packet changed after round trip: %+v", packet)
+	}
+}
+
+func TestOtherPackets(t *testing.T) {
+	buf := (&SyncPacket{}).Append(nil)
+	if !bytes.Equal(buf, []byte{'A', 'r', 't', '-', 'N', 'e', 't', 0, 0x00, 0x52, 0, 14, 0, 0}) {
+		t.Errorf("unexpected ArtSync packet: % x", buf)
+	}
+	if packet, err := Parse(buf); err != nil {
+		t.Error("could not parse ArtSync:", err)
+	} else if _, ok := packet.(*SyncPacket); !ok {
+		t.Errorf("expected ArtSync, got %T", packet)
+	}
+
+	buf = (&PollPacket{Flags: 2, DiagPriority: 0x10}).Append(nil)
+	if !bytes.Equal(buf, []byte{'A', 'r', 't', '-', 'N', 'e', 't', 0, 0x00, 0x20, 0, 14, 2, 0x10}) {
+		t.Errorf("unexpected ArtPoll packet: % x", buf)
+	}
+	if packet, err := Parse(buf); err != nil {
+		t.Error("could not parse ArtPoll:", err)
+	} else if p, ok := packet.(*PollPacket); !ok || *p != (PollPacket{Flags: 2, DiagPriority: 0x10}) {
+		t.Errorf("unexpected ArtPoll: %+v", packet)
+	}
+
+	reply := &PollReplyPacket{
+		IP:         [4]byte{10, 0, 0, 5},
+		NetSwitch:  1,
+		SubSwitch:  2,
+		ShortName:  "short",
+		LongName:   "long name",
+		NumPorts:   2,
+		PortTypes:  [4]uint8{0x85, 0x85},
+		GoodOutput: [4]uint8{0x80, 0x80},
+		SwOut:      [4]uint8{3, 4},
+		MAC:        [6]byte{1, 2, 3, 4, 5, 6},
+		BindIndex:  1,
+		Status2:    Status2Port15,
+	}
+	buf = reply.Append(nil)
+	if len(buf) != 239 {
+		t.Errorf("unexpected ArtPollReply length: %d", len(buf))
+	}
+	for offset, data := range map[int][]byte{
+		8:   {0x00, 0x21, 10, 0, 0, 5, 0x36, 0x19}, // OpCode, IP, port
+		18:  {1, 2},                                // NetSwitch, SubSwitch
+		26:  {'s', 'h', 'o', 'r', 't', 0},
+		172: {0, 2, 0x85, 0x85, 0, 0},
+		190: {3, 4, 0, 0},
+	} {
+		if !bytes.Equal(buf[offset:offset+len(data)], data) {
+			t.Errorf("ArtPollReply offset %d: expected % x, got % x", offset, data, buf[offset:offset+len(data)])
+		}
+	}
+	packet, err := Parse(buf)
+	if err != nil {
+		t.Fatal("could not parse ArtPollReply:", err)
+	}
+	if p, ok := packet.(*PollReplyPacket); !ok || *p != *reply {
+		t.Errorf("ArtPollReply changed after round trip: %+v", packet)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	valid := (&DmxPacket{Universe: 1, Data: []byte{1, 2}}).Append(nil)
+	modify := func(offset int, value byte) []byte {
+		buf := append([]byte{}, valid...)
+		buf[offset] = value
+		return buf
+	}
+	for name, buf := range map[string][]byte{
+		"empty":     nil,
+		"short":     valid[:15],
+		"truncated": valid[:len(valid)-1],
+		"ID":        modify(0, 'a'),
+		"OpCode":    modify(9, 0x99),
+		"version":   modify(11, 13),
+		"odd":       modify(17, 3),
+	} {
+		if _, err := Parse(buf); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestNode(t *testing.T) {
+	strip := make(ledsgo.Strip, 2)
+	frames := 0
+	n := NewNode(nil, dmx.Map{
+		{Universe: 0, Channel: 1, Strip: strip, Start: 0, Length: 1},
+		{Universe: 1, Channel: 1, Strip: strip, Start: 1, Length: 1},
+	})
+	n.OnFrame = func() {
+		frames++
+	}
+	now := time.Now()
+	send := func(p Packet) {
+		if err := n.HandlePacket(p.Append(nil), nil, now); err != nil {
+			t.Error("could not handle packet:", err)
+		}
+	}
+
+	send(&DmxPacket{Sequence: 1, Universe: 0, Data: []byte{1, 2, 3, 0}})
+	if frames != 0 {
+		t.Error("frame complete after one universe")
+	}
+	send(&DmxPacket{Sequence: 1, Universe: 1, Data: []byte{4, 5, 6, 0}})
+	if frames != 1 {
+		t.Error("frame not complete after all universes")
+	}
+	if strip[0] != (color.RGBA{1, 2, 3, 0xff}) || strip[1] != (color.RGBA{4, 5, 6, 0xff}) {
+		t.Errorf("unexpected strip: %v", strip)
+	}
+
+	// Old packets are dropped.
+	send(&DmxPacket{Sequence: 3, Universe: 0, Data: []byte{7, 7, 7, 0}})
+	send(&DmxPacket{Sequence: 2, Universe: 0, Data: []byte{8, 8, 8, 0}})
+	if strip[0] != (color.RGBA{7, 7, 7, 0xff}) {
+		t.Errorf("old packet was not dropped: %v", strip[0])
+	}
+
+	// After an ArtSync, data is only output on the next ArtSync.
+	frames = 0
+	send(&SyncPacket{})
+	send(&DmxPacket{Sequence: 4, Universe: 0, Data: []byte{9, 9, 9, 0}})
+	send(&DmxPacket{Sequence: 4, Universe: 1, Data: []byte{10, 10, 10, 0}})
+	if strip[0] != (color.RGBA{7, 7, 7, 0xff}) || frames != 0 {
+		t.Errorf("data was output before ArtSync: %v", strip)
+	}
+	send(&SyncPacket{})
+	if strip[0] != (color.RGBA{9, 9, 9, 0xff}) || strip[1] != (color.RGBA{10, 10, 10, 0xff}) || frames != 1 {
+		t.Errorf("data was not output after ArtSync: %v", strip)
+	}
+
+	// Without ArtSync packets, the node goes back to normal mode.
+	now = now.Add(5 * time.Second)
+	send(&DmxPacket{Sequence: 5, Universe: 0, Data: []byte{11, 11, 11, 0}})
+	if strip[0] != (color.RGBA{11, 11, 11, 0xff}) {
+		t.Errorf("node did not leave synchronous mode: %v", strip)
+	}
+}
+
+func TestPollReplies(t *testing.T) {
+	strip := make(ledsgo.Strip, 170*6)
+	n := NewNode(nil, dmx.MapStrip(strip, 0x10e, ledsgo.RGB))
+	n.IP = net.IPv4(192, 168, 1, 2)
+	replies := n.PollReplies()
+	// Universes 0x10e and 0x10f share a sub-net, 0x110-0x113 too.
+	if len(replies) != 2 {
+		t.Fatalf("expected 2 replies, got %d", len(replies))
+	}
+	r := replies[0]
+	if r.NumPorts != 2 || r.NetSwitch != 1 || r.SubSwitch != 0 || r.SwOut[0] != 0xe || r.SwOut[1] != 0xf || r.BindIndex != 1 || r.IP != [4]byte{192, 168, 1, 2} {
+		t.Errorf("unexpected first reply: %+v", r)
+	}
+	r = replies[1]
+	if r.NumPorts != 4 || r.NetSwitch != 1 || r.SubSwitch != 1 || r.SwOut != [4]uint8{0, 1, 2, 3} || r.BindIndex != 2 {
+		t.Errorf("unexpected second reply: %+v", r)
+	}
+}
+
+func TestLoopback(t *testing.T) {
+	// Send a strip from a sender to a node over UDP, and discover the node
+	// using ArtPoll.
+	received := make(ledsgo.Strip, 300)
+	node, err := Listen("127.0.0.1:0", dmx.MapStrip(received, 0, ledsgo.GRB))
+	if err != nil {
+		t.Fatal("could not listen:", err)
+	}
+	frames := make(chan ledsgo.Strip, 1)
+	node.OnFrame = func() {
+		frames <- append(ledsgo.Strip(nil), received...)
+	}
+	done := make(chan error)
+	go func() {
+		done <- node.Serve()
+	}()
+
+	strip := make(ledsgo.Strip, 300)
+	for i := range strip {
+		strip[i] = color.RGBA{uint8(i), uint8(i >> 8), 0x80, 0xff}
+	}
+	sender, err := Dial(node.Addr().String(), dmx.MapStrip(strip, 0, ledsgo.GRB))
+	if err != nil {
+		t.Fatal("could not dial:", err)
+	}
+	defer sender.Close()
+	sender.Sync = true
+	if err := sender.Send(); err != nil {
+		t.Fatal("could not send:", err)
+	}
+	select {
+	case frame := <-frames:
+		for i := range frame {
+			if frame[i] != strip[i] {
+				t.Errorf("LED %d: expected %v, got %v", i, strip[i], frame[i])
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("timeout waiting for frame")
+	}
+
+	// Discovery.
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("could not listen:", err)
+	}
+	defer conn.Close()
+	if _, err := conn.WriteTo((&PollPacket{}).Append(nil), node.Addr()); err != nil {
+		t.Fatal("could not send ArtPoll:", err)
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 1500)
+	size, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal("no ArtPollReply received:", err)
+	}
+	packet, err := Parse(buf[:size])
+	if err != nil {
+		t.Fatal("could not parse ArtPollReply:", err)
+	}
+	reply, ok := packet.(*PollReplyPacket)
+	if !ok {
+		t.Fatalf("expected ArtPollReply, got %T", packet)
+	}
+	if reply.NumPorts != 2 || reply.SwOut[0] != 0 || reply.SwOut[1] != 1 || reply.IP != [4]byte{127, 0, 0, 1} || reply.ShortName != "ledsgo" {
+		t.Errorf("unexpected ArtPollReply: %+v", reply)
+	}
+
+	node.Close()
+	if err := <-done; err != nil {
+		t.Error("Serve returned an error:", err)
+	}
+}
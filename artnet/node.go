@@ -0,0 +1,228 @@
+package artnet
+
+import (
+	"errors"
+	"net"
+	"time"
+
+	"github.com/aykevl/ledsgo/dmx"
+)
+
+// After receiving an ArtSync packet, a node stays in synchronous mode for this
+// long. If no ArtSync packet arrives in this time, it outputs data directly.
+const syncTimeout = 4 * time.Second
+
+// Node receives Art-Net data and copies it into the strips of a DMX map. It
+// also responds to ArtPoll packets, so that consoles can discover it.
+//
+// Packets that arrive out of order are dropped. Merging data from multiple
+// controllers is not supported: the most recent packet wins.
+//
+// A frame is complete when all universes in the map have been received since
+// the previous frame. If the controller sends ArtSync packets, a frame is
+// instead complete on every ArtSync packet. In both cases, the OnFrame
+// callback is called.
+type Node struct {
+	// OnFrame is called when a frame is complete, with all strips updated. It
+	// is called from the goroutine running Serve, so the strips are not
+	// modified while it runs.
+	OnFrame func()
+
+	// Names reported in ArtPollReply packets.
+	ShortName string
+	LongName  string
+
+	// IP address reported in ArtPollReply packets. If it is nil, the local
+	// address of the connection is used.
+	IP net.IP
+
+	conn      net.PacketConn
+	mapping   dmx.Map
+	universes []uint16
+	sequences map[uint16]uint8  // last sequence number per universe
+	received  map[uint16]bool   // universes received since the last frame
+	pending   map[uint16][]byte // data waiting for an ArtSync packet
+	lastSync  time.Time
+	buf       []byte
+}
+
+// NewNode creates a new node that reads packets from the given connection.
+func NewNode(conn net.PacketConn, mapping dmx.Map) *Node {
+	return &Node{
+		ShortName: "ledsgo",
+		LongName:  "ledsgo Art-Net node",
+		conn:      conn,
+		mapping:   mapping,
+		universes: mapping.Universes(),
+		sequences: make(map[uint16]uint8),
+		received:  make(map[uint16]bool),
+		pending:   make(map[uint16][]byte),
+	}
+}
+
+// Listen creates a new node listening on the given UDP address, for example
+// ":6454" to listen on all interfaces.
+func Listen(address string, mapping dmx.Map) (*Node, error) {
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenPacket("udp", address)
+	if err != nil {
+		return nil, err
+	}
+	return NewNode(conn, mapping), nil
+}
+
+// Addr returns the local address of the node.
+func (n *Node) Addr() net.Addr {
+	return n.conn.LocalAddr()
+}
+
+// Serve receives packets until the node is closed. Invalid packets are
+// ignored. It returns nil after Close is called.
+func (n *Node) Serve() error {
+	buf := make([]byte, 1500)
+	for {
+		size, addr, err := n.conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		n.HandlePacket(buf[:size], addr, time.Now())
+	}
+}
+
+// Close stops the node and closes the underlying connection.
+func (n *Node) Close() error {
+	return n.conn.Close()
+}
+
+// HandlePacket processes a single Art-Net packet received at the given time
+// from the given address. This is normally done by Serve, but can be used
+// directly if the packets are received in another way. It must not be called
+// concurrently with Serve.
+func (n *Node) HandlePacket(buf []byte, from net.Addr, now time.Time) error {
+	packet, err := Parse(buf)
+	if err != nil {
+		return err
+	}
+	switch packet := packet.(type) {
+	case *DmxPacket:
+		n.handleDmx(packet, now)
+	case *SyncPacket:
+		n.handleSync(now)
+	case *PollPacket:
+		return n.handlePoll(from)
+	}
+	return nil
+}
+
+func (n *Node) handleDmx(packet *DmxPacket, now time.Time) {
+	if !n.mapping.Contains(packet.Universe) {
+		return // not interested in this universe
+	}
+	if packet.Sequence != 0 {
+		// Drop packets that are older than the last received packet. Note
+		// that sequence numbers wrap around from 255 to 1.
+		if last, ok := n.sequences[packet.Universe]; ok && last != 0 {
+			diff := int8(packet.Sequence - last)
+			if diff <= 0 && diff > -20 {
+				return
+			}
+		}
+		n.sequences[packet.Universe] = packet.Sequence
+	}
+
+	if now.Sub(n.lastSync) < syncTimeout {
+		// Synchronous mode: keep the data until the next ArtSync packet.
+		n.pending[packet.Universe] = append(n.pending[packet.Universe][:0], packet.Data...)
+		return
+	}
+
+	n.mapping.Apply(packet.Universe, packet.Data)
+	n.received[packet.Universe] = true
+	for _, universe := range n.universes {
+		if !n.received[universe] {
+			return // frame is not yet complete
+		}
+	}
+	n.frameDone()
+}
+
+func (n *Node) handleSync(now time.Time) {
+	n.lastSync = now
+	if len(n.pending) == 0 {
+		return
+	}
+	for universe, data := range n.pending {
+		n.mapping.Apply(universe, data)
+		delete(n.pending, universe)
+	}
+	n.frameDone()
+}
+
+// Called when a complete frame has been received.
+func (n *Node) frameDone() {
+	for universe := range n.received {
+		delete(n.received, universe)
+	}
+	if n.OnFrame != nil {
+		n.OnFrame()
+	}
+}
+
+func (n *Node) handlePoll(from net.Addr) error {
+	if from == nil {
+		return nil // nowhere to send the reply to
+	}
+	for _, reply := range n.PollReplies() {
+		n.buf = reply.Append(n.buf[:0])
+		if _, err := n.conn.WriteTo(n.buf, from); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PollReplies returns the ArtPollReply packets that describe this node. Every
+// packet describes up to four output ports (universes) that share the same
+// net and sub-net, so a node with many universes sends multiple replies.
+func (n *Node) PollReplies() []*PollReplyPacket {
+	ip := n.IP
+	if ip == nil {
+		if addr, ok := n.conn.LocalAddr().(*net.UDPAddr); ok {
+			ip = addr.IP
+		}
+	}
+
+	var replies []*PollReplyPacket
+	var reply *PollReplyPacket
+	for _, universe := range n.universes {
+		netSwitch, subSwitch := uint8(universe>>8)&0x7f, uint8(universe>>4)&0x0f
+		if reply == nil || reply.NumPorts == maxPortsPerReply || reply.NetSwitch != netSwitch || reply.SubSwitch != subSwitch {
+			reply = &PollReplyPacket{
+				NetSwitch:  netSwitch,
+				SubSwitch:  subSwitch,
+				Status1:    Status1Indicate,
+				ShortName:  n.ShortName,
+				LongName:   n.LongName,
+				NodeReport: "#0001 [0000] OK",
+				Style:      StyleNode,
+				BindIndex:  uint8(len(replies) + 1),
+				Status2:    Status2Port15,
+			}
+			if ip4 := ip.To4(); ip4 != nil {
+				copy(reply.IP[:], ip4)
+			}
+			replies = append(replies, reply)
+		}
+		port := reply.NumPorts
+		reply.PortTypes[port] = PortTypeOutput | PortTypeArtNet
+		reply.GoodOutput[port] = GoodOutputData
+		reply.SwOut[port] = uint8(universe) & 0x0f
+		reply.NumPorts++
+	}
+	return replies
+}
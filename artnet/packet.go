@@ -0,0 +1,282 @@
+// Package artnet implements the Art-Net 4 protocol, which is used by lighting
+// consoles and other software to send DMX512 data over UDP. It contains a node
+// that receives ArtDmx data into LED strips and responds to discovery
+// (ArtPoll) requests, and a sender that sends LED strips as ArtDmx packets.
+//
+// Universes are 15-bit Art-Net port addresses: a 7-bit net, 4-bit sub-net and
+// 4-bit universe. Unlike E1.31, the first universe is 0.
+//
+// See https://art-net.org.uk/ for the specification.
+package artnet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+// Port is the UDP port used by Art-Net.
+const Port = 6454
+
+// Protocol version implemented by this package.
+const protocolVersion = 14
+
+// OpCodes of the supported packets.
+const (
+	OpPoll      = 0x2000
+	OpPollReply = 0x2100
+	OpDmx       = 0x5000
+	OpSync      = 0x5200
+)
+
+// Sizes of the packet formats.
+const (
+	headerSize       = 10 // ID and OpCode
+	pollSize         = 14 // minimum size, newer versions add more fields
+	pollReplySize    = 239
+	dmxHeaderSize    = 18
+	syncSize         = 14
+	maxPortsPerReply = 4
+	shortNameSize    = 18
+	longNameSize     = 64
+	nodeReportSize   = 64
+)
+
+var packetID = [8]byte{'A', 'r', 't', '-', 'N', 'e', 't', 0}
+
+var (
+	errShortPacket   = errors.New("artnet: packet too short")
+	errInvalidID     = errors.New("artnet: not an Art-Net packet")
+	errUnknownOpCode = errors.New("artnet: unsupported OpCode")
+	errInvalidLength = errors.New("artnet: invalid DMX data length")
+	errOldVersion    = errors.New("artnet: unsupported protocol version")
+)
+
+// Packet is one of *DmxPacket, *SyncPacket, *PollPacket or *PollReplyPacket.
+type Packet interface {
+	// Append appends the binary encoding of the packet to buf and returns the
+	// extended buffer.
+	Append(buf []byte) []byte
+}
+
+// DmxPacket (ArtDmx) contains the DMX data of a single universe.
+type DmxPacket struct {
+	Sequence uint8  // 1-255, or 0 to disable sequence checking
+	Physical uint8  // physical input port, for information only
+	Universe uint16 // 15-bit port address
+	Data     []byte // 2-512 DMX slots, must be an even number
+}
+
+// SyncPacket (ArtSync) tells nodes to output all DMX data received since the
+// previous ArtSync packet.
+type SyncPacket struct{}
+
+// PollPacket (ArtPoll) is sent by controllers to discover nodes.
+type PollPacket struct {
+	Flags        uint8
+	DiagPriority uint8
+}
+
+// PollReplyPacket (ArtPollReply) is sent by nodes in response to an ArtPoll
+// packet. It describes up to four ports of the node. Only the most commonly
+// used fields are included.
+type PollReplyPacket struct {
+	IP         [4]byte
+	Version    uint16 // firmware version of the node
+	NetSwitch  uint8  // bits 14-8 of the port addresses
+	SubSwitch  uint8  // bits 7-4 of the port addresses
+	Oem        uint16
+	Status1    uint8
+	EstaMan    uint16
+	ShortName  string // max 17 bytes
+	LongName   string // max 63 bytes
+	NodeReport string // max 63 bytes
+	NumPorts   uint16
+	PortTypes  [4]uint8
+	GoodInput  [4]uint8
+	GoodOutput [4]uint8
+	SwIn       [4]uint8 // bits 3-0 of the input port addresses
+	SwOut      [4]uint8 // bits 3-0 of the output port addresses
+	Style      uint8
+	MAC        [6]byte
+	BindIndex  uint8
+	Status2    uint8
+}
+
+// Values for PollReplyPacket fields.
+const (
+	PortTypeOutput  = 0x80 // the port can output data from the network
+	PortTypeArtNet  = 0x05 // the port uses the Art-Net protocol
+	GoodOutputData  = 0x80 // data is being output
+	StyleNode       = 0x00 // a DMX to/from Art-Net device
+	Status2Port15   = 0x08 // the node supports 15-bit port addresses
+	Status1Indicate = 0xc0 // indicators in normal mode
+)
+
+// Parse parses an Art-Net packet. The data of a DmxPacket refers to buf, so buf
+// must not be modified while the packet is in use.
+func Parse(buf []byte) (Packet, error) {
+	if len(buf) < headerSize {
+		return nil, errShortPacket
+	}
+	if !bytes.Equal(buf[:8], packetID[:]) {
+		return nil, errInvalidID
+	}
+	opcode := binary.LittleEndian.Uint16(buf[8:])
+	if opcode != OpPollReply {
+		// All other packets start with the protocol version.
+		if len(buf) < 12 {
+			return nil, errShortPacket
+		}
+		if binary.BigEndian.Uint16(buf[10:]) < protocolVersion {
+			return nil, errOldVersion
+		}
+	}
+	switch opcode {
+	case OpDmx:
+		if len(buf) < dmxHeaderSize {
+			return nil, errShortPacket
+		}
+		length := int(binary.BigEndian.Uint16(buf[16:]))
+		if length < 2 || length > 512 || length%2 != 0 || dmxHeaderSize+length > len(buf) {
+			return nil, errInvalidLength
+		}
+		return &DmxPacket{
+			Sequence: buf[12],
+			Physical: buf[13],
+			Universe: uint16(buf[15]&0x7f)<<8 | uint16(buf[14]),
+			Data:     buf[dmxHeaderSize : dmxHeaderSize+length],
+		}, nil
+	case OpSync:
+		if len(buf) < syncSize {
+			return nil, errShortPacket
+		}
+		return &SyncPacket{}, nil
+	case OpPoll:
+		if len(buf) < pollSize {
+			return nil, errShortPacket
+		}
+		return &PollPacket{
+			Flags:        buf[12],
+			DiagPriority: buf[13],
+		}, nil
+	case OpPollReply:
+		if len(buf) < 207 {
+			// Older nodes send a shorter packet, only accept the fields that
+			// are present in all versions.
+			return nil, errShortPacket
+		}
+		p := &PollReplyPacket{
+			Version:    binary.BigEndian.Uint16(buf[16:]),
+			NetSwitch:  buf[18],
+			SubSwitch:  buf[19],
+			Oem:        binary.BigEndian.Uint16(buf[20:]),
+			Status1:    buf[23],
+			EstaMan:    binary.LittleEndian.Uint16(buf[24:]),
+			ShortName:  cString(buf[26 : 26+shortNameSize]),
+			LongName:   cString(buf[44 : 44+longNameSize]),
+			NodeReport: cString(buf[108 : 108+nodeReportSize]),
+			NumPorts:   binary.BigEndian.Uint16(buf[172:]),
+			Style:      buf[200],
+		}
+		copy(p.IP[:], buf[10:14])
+		copy(p.PortTypes[:], buf[174:178])
+		copy(p.GoodInput[:], buf[178:182])
+		copy(p.GoodOutput[:], buf[182:186])
+		copy(p.SwIn[:], buf[186:190])
+		copy(p.SwOut[:], buf[190:194])
+		copy(p.MAC[:], buf[201:207])
+		if len(buf) >= 213 {
+			p.BindIndex = buf[211]
+			p.Status2 = buf[212]
+		}
+		return p, nil
+	default:
+		return nil, errUnknownOpCode
+	}
+}
+
+// Return the string up to the first zero byte.
+func cString(b []byte) string {
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		b = b[:i]
+	}
+	return string(b)
+}
+
+// Append the packet ID, OpCode and (optionally) protocol version, followed by
+// size-headerSize zero bytes. It returns the extended buffer and the new
+// packet within it.
+func appendHeader(buf []byte, opcode uint16, size int) ([]byte, []byte) {
+	start := len(buf)
+	buf = append(buf, make([]byte, size)...)
+	b := buf[start:]
+	copy(b, packetID[:])
+	binary.LittleEndian.PutUint16(b[8:], opcode)
+	if opcode != OpPollReply {
+		binary.BigEndian.PutUint16(b[10:], protocolVersion)
+	}
+	return buf, b
+}
+
+// Append appends the binary encoding of the ArtDmx packet to buf and returns
+// the extended buffer. If the data has an odd length, a zero byte is added.
+func (p *DmxPacket) Append(buf []byte) []byte {
+	length := len(p.Data) + len(p.Data)%2
+	if length < 2 {
+		length = 2
+	}
+	buf, b := appendHeader(buf, OpDmx, dmxHeaderSize+length)
+	b[12] = p.Sequence
+	b[13] = p.Physical
+	b[14] = uint8(p.Universe)
+	b[15] = uint8(p.Universe>>8) & 0x7f
+	binary.BigEndian.PutUint16(b[16:], uint16(length))
+	copy(b[dmxHeaderSize:], p.Data)
+	return buf
+}
+
+// Append appends the binary encoding of the ArtSync packet to buf and returns
+// the extended buffer.
+func (p *SyncPacket) Append(buf []byte) []byte {
+	buf, _ = appendHeader(buf, OpSync, syncSize)
+	return buf
+}
+
+// Append appends the binary encoding of the ArtPoll packet to buf and returns
+// the extended buffer.
+func (p *PollPacket) Append(buf []byte) []byte {
+	buf, b := appendHeader(buf, OpPoll, pollSize)
+	b[12] = p.Flags
+	b[13] = p.DiagPriority
+	return buf
+}
+
+// Append appends the binary encoding of the ArtPollReply packet to buf and
+// returns the extended buffer.
+func (p *PollReplyPacket) Append(buf []byte) []byte {
+	buf, b := appendHeader(buf, OpPollReply, pollReplySize)
+	copy(b[10:14], p.IP[:])
+	binary.LittleEndian.PutUint16(b[14:], Port)
+	binary.BigEndian.PutUint16(b[16:], p.Version)
+	b[18] = p.NetSwitch
+	b[19] = p.SubSwitch
+	binary.BigEndian.PutUint16(b[20:], p.Oem)
+	b[23] = p.Status1
+	binary.LittleEndian.PutUint16(b[24:], p.EstaMan)
+	copy(b[26:26+shortNameSize-1], p.ShortName)
+	copy(b[44:44+longNameSize-1], p.LongName)
+	copy(b[108:108+nodeReportSize-1], p.NodeReport)
+	binary.BigEndian.PutUint16(b[172:], p.NumPorts)
+	copy(b[174:178], p.PortTypes[:])
+	copy(b[178:182], p.GoodInput[:])
+	copy(b[182:186], p.GoodOutput[:])
+	copy(b[186:190], p.SwIn[:])
+	copy(b[190:194], p.SwOut[:])
+	b[200] = p.Style
+	copy(b[201:207], p.MAC[:])
+	copy(b[207:211], p.IP[:]) // BindIp
+	b[211] = p.BindIndex
+	b[212] = p.Status2
+	return buf
+}
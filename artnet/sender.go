@@ -0,0 +1,83 @@
+package artnet
+
+import (
+	"net"
+
+	"github.com/aykevl/ledsgo/dmx"
+)
+
+// Sender sends the strips of a DMX map as ArtDmx packets, one packet per
+// universe. Use dmx.MapStrip to split a long strip over multiple universes.
+type Sender struct {
+	// Sync sends an ArtSync packet after every frame, so that all universes
+	// are output at the same time.
+	Sync bool
+
+	conn      net.Conn
+	mapping   dmx.Map
+	universes []uint16
+	sequence  uint8
+	data      [dmx.UniverseSize]byte
+	buf       []byte
+}
+
+// NewSender creates a new sender that sends packets over the given
+// connection.
+func NewSender(conn net.Conn, mapping dmx.Map) *Sender {
+	return &Sender{
+		conn:      conn,
+		mapping:   mapping,
+		universes: mapping.Universes(),
+	}
+}
+
+// Dial creates a new sender that sends packets to the given UDP address, for
+// example "192.168.1.10:6454". Art-Net 4 recommends sending data to nodes
+// directly instead of broadcasting it.
+func Dial(address string, mapping dmx.Map) (*Sender, error) {
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, err
+	}
+	return NewSender(conn, mapping), nil
+}
+
+// Send sends the current contents of all mapped strips, followed by an
+// ArtSync packet if Sync is set.
+func (s *Sender) Send() error {
+	// Sequence numbers run from 1 to 255, 0 disables sequence checking.
+	s.sequence++
+	if s.sequence == 0 {
+		s.sequence = 1
+	}
+	for _, universe := range s.universes {
+		for i := range s.data {
+			s.data[i] = 0
+		}
+		length := s.mapping.Fill(universe, s.data[:])
+		packet := DmxPacket{
+			Sequence: s.sequence,
+			Universe: universe,
+			Data:     s.data[:length],
+		}
+		s.buf = packet.Append(s.buf[:0])
+		if _, err := s.conn.Write(s.buf); err != nil {
+			return err
+		}
+	}
+	if s.Sync {
+		s.buf = (&SyncPacket{}).Append(s.buf[:0])
+		if _, err := s.conn.Write(s.buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the underlying connection.
+func (s *Sender) Close() error {
+	return s.conn.Close()
+}
@@ -0,0 +1,341 @@
+package audio
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"testing"
+	"time"
+)
+
+// Synthesize a sine wave with the given frequency and amplitude.
+func sine(n, sampleRate int, freq, amplitude float64) []int16 {
+	samples := make([]int16, n)
+	for i := range samples {
+		samples[i] = int16(math.Round(amplitude * math.Sin(2*math.Pi*freq*float64(i)/float64(sampleRate))))
+	}
+	return samples
+}
+
+func TestSinTable(t *testing.T) {
+	for i := 0; i < MaxFFTSize; i++ {
+		want := math.Sin(2 * math.Pi * float64(i) / MaxFFTSize)
+		if got := float64(sin(i)) / 32768; math.Abs(got-want) > 1.0/32768 {
+			t.Errorf("sin(%d): expected %f, got %f", i, want, got)
+		}
+		want = math.Cos(2 * math.Pi * float64(i) / MaxFFTSize)
+		if got := float64(cos(i)) / 32768; math.Abs(got-want) > 1.0/32768 {
+			t.Errorf("cos(%d): expected %f, got %f", i, want, got)
+		}
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	for _, x := range []uint32{0, 1, 2, 3, 4, 15, 16, 17, 1000, 65535, 65536, 1 << 30, 0xffffffff} {
+		want := uint32(math.Sqrt(float64(x)))
+		if got := sqrt(x); got != want {
+			t.Errorf("sqrt(%d): expected %d, got %d", x, want, got)
+		}
+	}
+}
+
+func TestLog2(t *testing.T) {
+	for _, x := range []uint32{1, 2, 3, 10, 100, 1000, 44100 * 16, 0xffffffff} {
+		want := math.Log2(float64(x))
+		if got := float64(log2(x)) / 65536; math.Abs(got-want) > 2.0/65536 {
+			t.Errorf("log2(%d): expected %f, got %f", x, want, got)
+		}
+	}
+}
+
+func TestFFT(t *testing.T) {
+	const sampleRate = 8000
+	for _, size := range []int{64, 128, 256, 512} {
+		fft := NewFFT(size)
+		out := make([]uint16, size/2)
+		for _, bin := range []int{3, size / 8, size/2 - 4} {
+			freq := float64(bin) * sampleRate / float64(size)
+			for _, amplitude := range []float64{32767, 1000} {
+				fft.Transform(sine(size, sampleRate, freq, amplitude), out)
+				peak := 0
+				for k := range out {
+					if out[k] > out[peak] {
+						peak = k
+					}
+				}
+				if peak != bin {
+					t.Errorf("size %d, bin %d: peak in bin %d", size, bin, peak)
+				}
+				if got := float64(out[bin]); math.Abs(got-amplitude) > amplitude*0.05+16 {
+					t.Errorf("size %d, bin %d: expected magnitude %.0f, got %.0f", size, bin, amplitude, got)
+				}
+				// Bins far from the tone should be close to zero.
+				for k := range out {
+					if (k < bin-3 || k > bin+3) && out[k] > 48 {
+						t.Errorf("size %d, bin %d: leakage of %d in bin %d", size, bin, out[k], k)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestFFTSilence(t *testing.T) {
+	fft := NewFFT(256)
+	out := make([]uint16, 128)
+	fft.Transform(make([]int16, 256), out)
+	for k, magnitude := range out {
+		if magnitude != 0 {
+			t.Errorf("bin %d: expected 0, got %d", k, magnitude)
+		}
+	}
+}
+
+func TestFFTInvalidSize(t *testing.T) {
+	for _, size := range []int{0, 32, 100, 1024} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewFFT(%d) did not panic", size)
+				}
+			}()
+			NewFFT(size)
+		}()
+	}
+}
+
+func TestBands(t *testing.T) {
+	const sampleRate = 16000
+	for _, numBands := range []int{8, 16, 32} {
+		bands := NewBands(numBands, 512, sampleRate, 40, 8000)
+		if bands.Len() != numBands {
+			t.Fatalf("expected %d bands, got %d", numBands, bands.Len())
+		}
+		lastFirst := 0
+		for band := 0; band < numBands; band++ {
+			first, last := bands.Range(band)
+			if first < 1 || last >= 256 || first > last {
+				t.Errorf("%d bands: invalid range %d-%d for band %d", numBands, first, last, band)
+			}
+			if first < lastFirst {
+				t.Errorf("%d bands: band %d starts before band %d", numBands, band, band-1)
+			}
+			lastFirst = first
+		}
+		// The bands cover all bins above the lowest band.
+		first, _ := bands.Range(0)
+		_, last := bands.Range(numBands - 1)
+		if last != 255 {
+			t.Errorf("%d bands: expected last bin 255, got %d", numBands, last)
+		}
+		for bin := first + 1; bin <= last; bin++ {
+			found := false
+			for band := 0; band < numBands; band++ {
+				f, l := bands.Range(band)
+				found = found || (bin >= f && bin <= l)
+			}
+			if !found {
+				t.Errorf("%d bands: bin %d is not in any band", numBands, bin)
+			}
+		}
+	}
+
+	// A low tone ends up in a low band, a high tone in a high band.
+	fft := NewFFT(512)
+	bands := NewBands(16, 512, sampleRate, 40, 8000)
+	magnitudes := make([]uint16, 256)
+	levels := make([]uint16, 16)
+	for _, tc := range []struct {
+		freq float64
+		band int
+	}{
+		{100, 2},
+		{1000, 8},
+		{5000, 14},
+	} {
+		fft.Transform(sine(512, sampleRate, tc.freq, 16000), magnitudes)
+		bands.Apply(magnitudes, levels)
+		loudest := 0
+		for band := range levels {
+			if levels[band] > levels[loudest] {
+				loudest = band
+			}
+		}
+		if loudest < tc.band-1 || loudest > tc.band+1 {
+			t.Errorf("%.0fHz: expected loudest band near %d, got %d (%v)", tc.freq, tc.band, loudest, levels)
+		}
+	}
+}
+
+func TestEnvelope(t *testing.T) {
+	// 100 updates per second: 10ms attack is a single update, 1s release is
+	// 100 updates.
+	e := NewEnvelope(10*time.Millisecond, time.Second, 100)
+	if level := e.Update(30000); level != 30000 {
+		t.Errorf("expected immediate attack to 30000, got %d", level)
+	}
+	for i := 0; i < 100; i++ {
+		e.Update(0)
+	}
+	// After one time constant, about 37% remains.
+	if level := e.Level(); level < 10500 || level > 11500 {
+		t.Errorf("expected level of about 11000 after release time, got %d", level)
+	}
+	for i := 0; i < 2000; i++ {
+		e.Update(0)
+	}
+	if level := e.Level(); level != 0 {
+		t.Errorf("expected level to reach 0, got %d", level)
+	}
+
+	// Slow attack.
+	e = NewEnvelope(100*time.Millisecond, 100*time.Millisecond, 100)
+	for i := 0; i < 10; i++ {
+		e.Update(60000)
+	}
+	// Slightly more than 63%, because the envelope moves in discrete steps.
+	if level := e.Level(); level < 37500 || level > 39500 {
+		t.Errorf("expected level of about 39000 after attack time, got %d", level)
+	}
+}
+
+func TestLevels(t *testing.T) {
+	samples := sine(8000, 8000, 100, 20000)
+	if peak := Peak(samples); peak != 20000 {
+		t.Errorf("expected peak 20000, got %d", peak)
+	}
+	if rms := RMS(samples); rms < 14100 || rms > 14180 {
+		t.Errorf("expected RMS of about 14142, got %d", rms)
+	}
+	if peak := Peak([]int16{-32768, 100}); peak != 32768 {
+		t.Errorf("expected peak 32768, got %d", peak)
+	}
+	if rms := RMS([]int16{-32768, -32768}); rms != 32768 {
+		t.Errorf("expected RMS 32768, got %d", rms)
+	}
+
+	// Followers process blocks of 80 samples at 8kHz.
+	peak := NewPeakFollower(0, 500*time.Millisecond, 100)
+	rms := NewRMSFollower(0, 500*time.Millisecond, 100)
+	if level := peak.Process(samples[:80]); level < 19900 {
+		t.Errorf("expected peak follower to attack to 20000, got %d", level)
+	}
+	if level := rms.Process(samples[:80]); level < 14000 || level > 14300 {
+		t.Errorf("expected RMS follower to attack to 14142, got %d", level)
+	}
+	silence := make([]int16, 80)
+	for i := 0; i < 50; i++ {
+		peak.Process(silence)
+		rms.Process(silence)
+	}
+	if level := peak.Level(); level < 7000 || level > 7700 {
+		t.Errorf("expected peak follower to release to about 7400, got %d", level)
+	}
+}
+
+// Detect onsets in the samples and return their times.
+func detectOnsets(samples []int16, sampleRate, blockSize int) []time.Duration {
+	detector := NewBeatDetector(sampleRate/blockSize, 250*time.Millisecond)
+	var onsets []time.Duration
+	for i := 0; i+blockSize <= len(samples); i += blockSize {
+		if detector.Process(samples[i : i+blockSize]) {
+			onsets = append(onsets, time.Duration(i)*time.Second/time.Duration(sampleRate))
+		}
+	}
+	return onsets
+}
+
+// Check that onsets are found at the expected times, within a block.
+func checkOnsets(t *testing.T, onsets, expected []time.Duration, blockTime time.Duration) {
+	t.Helper()
+	if len(onsets) != len(expected) {
+		t.Fatalf("expected %d onsets, got %v", len(expected), onsets)
+	}
+	for i, onset := range onsets {
+		if onset < expected[i]-blockTime || onset > expected[i]+blockTime {
+			t.Errorf("onset %d: expected at %v, got %v", i, expected[i], onset)
+		}
+	}
+}
+
+func TestBeatDetectorClicks(t *testing.T) {
+	// Clicks every 300ms over a quiet tone.
+	const sampleRate = 16000
+	samples := sine(sampleRate*2, sampleRate, 500, 1000)
+	var expected []time.Duration
+	for start := sampleRate / 10; start < len(samples)-sampleRate/20; start += sampleRate * 3 / 10 {
+		click := sine(sampleRate/20, sampleRate, 80, 20000)
+		for i, s := range click {
+			samples[start+i] += s
+		}
+		expected = append(expected, time.Duration(start)*time.Second/sampleRate)
+	}
+	onsets := detectOnsets(samples, sampleRate, 256)
+	checkOnsets(t, onsets, expected, 256*time.Second/sampleRate)
+
+	// A steady tone has no onsets after the start.
+	onsets = detectOnsets(sine(sampleRate*2, sampleRate, 500, 10000), sampleRate, 256)
+	if len(onsets) > 1 {
+		t.Errorf("expected at most one onset for a steady tone, got %v", onsets)
+	}
+}
+
+func TestWAV(t *testing.T) {
+	// 3 seconds at 8kHz with a kick drum at 120 beats per minute, starting at
+	// 250ms, over a quiet tone and some noise.
+	data, err := os.ReadFile("testdata/kick120.wav")
+	if err != nil {
+		t.Fatal(err)
+	}
+	samples, sampleRate, err := DecodeWAV(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sampleRate != 8000 || len(samples) != 3*8000 {
+		t.Fatalf("expected 3s at 8000Hz, got %d samples at %dHz", len(samples), sampleRate)
+	}
+
+	var expected []time.Duration
+	for onset := 250 * time.Millisecond; onset < 3*time.Second; onset += 500 * time.Millisecond {
+		expected = append(expected, onset)
+	}
+	const blockSize = 128
+	onsets := detectOnsets(samples, sampleRate, blockSize)
+	checkOnsets(t, onsets, expected, blockSize*time.Second/8000)
+
+	// The kick drum is in the lowest bands.
+	fft := NewFFT(blockSize)
+	bands := NewBands(8, blockSize, sampleRate, 60, 4000)
+	magnitudes := make([]uint16, blockSize/2)
+	levels := make([]uint16, 8)
+	fft.Transform(samples[2000:2000+blockSize], magnitudes) // at 250ms
+	bands.Apply(magnitudes, levels)
+	if levels[0] < 4*levels[7] || levels[0] < 5000 {
+		t.Errorf("expected kick drum in the lowest band, got %v", levels)
+	}
+
+	if _, _, err := DecodeWAV(bytes.NewReader(data[:20])); err == nil {
+		t.Error("expected error for truncated WAV file")
+	}
+	if _, _, err := DecodeWAV(bytes.NewReader([]byte("RIFF\x00\x00\x00\x00AVI LIST"))); err != errNotWAV {
+		t.Errorf("expected errNotWAV, got %v", err)
+	}
+}
+
+func TestWAVStereo(t *testing.T) {
+	buf := &bytes.Buffer{}
+	buf.WriteString("RIFF\x00\x00\x00\x00WAVE")
+	buf.WriteString("fmt \x10\x00\x00\x00\x01\x00\x02\x00\x44\xac\x00\x00\x10\xb1\x02\x00\x04\x00\x10\x00")
+	buf.WriteString("LIST\x03\x00\x00\x00abc\x00") // odd chunk with padding
+	buf.WriteString("data\x08\x00\x00\x00\x10\x00\x30\x00\xf0\xff\xf0\xff")
+	samples, sampleRate, err := DecodeWAV(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sampleRate != 44100 {
+		t.Errorf("expected 44100Hz, got %d", sampleRate)
+	}
+	if len(samples) != 2 || samples[0] != 0x20 || samples[1] != -16 {
+		t.Errorf("unexpected samples: %v", samples)
+	}
+}
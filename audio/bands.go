@@ -0,0 +1,134 @@
+package audio
+
+import (
+	"math/bits"
+)
+
+// Bands groups the bins of an FFT into logarithmically spaced frequency
+// bands, which is how people perceive pitch. This is what is usually shown on
+// a spectrum analyzer: with linear bins, almost all bands would be in the
+// treble.
+type Bands struct {
+	first, last []uint16 // range of FFT bins for every band (inclusive)
+}
+
+// NewBands creates a band binner with the given number of bands (usually
+// between 8 and 32) for an FFT of the given size at the given sample rate.
+// The bands are spread over the range minFreq to maxFreq (in Hz), which is
+// clamped to the frequencies that the FFT can detect. Low bands may be
+// narrower than a single FFT bin: these bands use the nearest bin, so that
+// neighbouring bands may show the same value.
+func NewBands(bands, fftSize, sampleRate, minFreq, maxFreq int) *Bands {
+	b := &Bands{
+		first: make([]uint16, bands),
+		last:  make([]uint16, bands),
+	}
+	numBins := fftSize / 2
+
+	// Frequencies are stored as log2(frequency) in 16.16 format, with the
+	// frequency in 1/16 Hz to get some precision for the lowest bins. The DC
+	// bin (bin 0) is never used.
+	binFreq := func(bin int) int32 {
+		return log2(uint32(uint64(bin) * uint64(sampleRate) * 16 / uint64(fftSize)))
+	}
+	if lowest := sampleRate / fftSize; minFreq < lowest {
+		minFreq = lowest
+	}
+	if highest := sampleRate / 2; maxFreq > highest || maxFreq <= minFreq {
+		maxFreq = highest
+	}
+	low := log2(uint32(minFreq) * 16)
+	high := log2(uint32(maxFreq) * 16)
+	span := int64(high - low)
+	if span <= 0 {
+		span = 1
+	}
+
+	// Assign every bin to a band.
+	used := make([]bool, bands)
+	for bin := 1; bin < numBins; bin++ {
+		freq := binFreq(bin)
+		if freq < low || freq > high {
+			continue
+		}
+		band := int(int64(freq-low) * int64(bands) / span)
+		if band >= bands {
+			band = bands - 1
+		}
+		if !used[band] {
+			b.first[band] = uint16(bin)
+			used[band] = true
+		}
+		b.last[band] = uint16(bin)
+	}
+
+	// Bands without bins use the bin that is closest to their center.
+	for band := range used {
+		if used[band] {
+			continue
+		}
+		center := low + int32((int64(band)*2+1)*span/int64(bands*2))
+		closest := 1
+		closestDistance := int32(0x7fffffff)
+		for bin := 1; bin < numBins; bin++ {
+			distance := binFreq(bin) - center
+			if distance < 0 {
+				distance = -distance
+			}
+			if distance < closestDistance {
+				closest = bin
+				closestDistance = distance
+			}
+		}
+		b.first[band] = uint16(closest)
+		b.last[band] = uint16(closest)
+	}
+	return b
+}
+
+// Len returns the number of bands.
+func (b *Bands) Len() int {
+	return len(b.first)
+}
+
+// Range returns the first and last FFT bin (inclusive) of the given band.
+func (b *Bands) Range(band int) (first, last int) {
+	return int(b.first[band]), int(b.last[band])
+}
+
+// Apply calculates the bands from the FFT magnitudes and stores them in out,
+// which must have room for Len() bands. The value of a band is the largest
+// magnitude of its bins, so that a tone has the same level in every band.
+func (b *Bands) Apply(magnitudes []uint16, out []uint16) {
+	for band := range b.first {
+		var value uint16
+		for _, magnitude := range magnitudes[b.first[band] : b.last[band]+1] {
+			if magnitude > value {
+				value = magnitude
+			}
+		}
+		out[band] = value
+	}
+}
+
+// Return log2(x) in 16.16 fixed-point format. The result for x=0 is the same
+// as for x=1 (zero).
+func log2(x uint32) int32 {
+	if x == 0 {
+		return 0
+	}
+	exponent := bits.Len32(x) - 1
+	result := int32(exponent) << 16
+
+	// Calculate the fraction bit by bit, by repeatedly squaring the mantissa
+	// (which is a 1.31 number between 1 and 2).
+	mantissa := uint64(x) << uint(31-exponent)
+	for bit := int32(1 << 15); bit != 0; bit >>= 1 {
+		mantissa = mantissa * mantissa >> 31
+		if mantissa >= 1<<32 {
+			mantissa >>= 1
+			result |= bit
+		}
+	}
+	return result
+}
@@ -0,0 +1,90 @@
+package audio
+
+import (
+	"time"
+)
+
+// BeatDetector detects onsets (such as beats of a kick drum) by comparing the
+// energy of a block of samples to the average energy of the last second. This
+// simple method works well for music with a clear beat.
+type BeatDetector struct {
+	// Sensitivity is the ratio between the energy of a block and the average
+	// energy that counts as an onset, as an 8.8 fixed-point number. The
+	// default is 0x0280 (2.5): lower values detect more onsets.
+	Sensitivity uint16
+
+	// MinLevel is the RMS level below which no onsets are detected, so that
+	// noise is ignored during silence.
+	MinLevel uint16
+
+	history     []uint32 // energy of the last blocks (a ring buffer)
+	index       int      // next entry of history to overwrite
+	filled      int      // number of entries in history that are used
+	sum         uint64   // sum of all entries in history
+	previous    uint32   // energy of the previous block
+	minInterval int      // minimum number of blocks between onsets
+	holdoff     int      // number of blocks until the next onset is allowed
+}
+
+// NewBeatDetector creates a new beat detector for blocks of samples that are
+// processed updateRate times per second. Onsets are at least minInterval
+// apart: for example, 250ms allows up to 240 beats per minute.
+func NewBeatDetector(updateRate int, minInterval time.Duration) *BeatDetector {
+	if updateRate < 1 {
+		updateRate = 1
+	}
+	return &BeatDetector{
+		Sensitivity: 0x0280,
+		MinLevel:    256,
+		history:     make([]uint32, updateRate),
+		minInterval: int(int64(minInterval) * int64(updateRate) / int64(time.Second)),
+	}
+}
+
+// Process calculates the energy of the samples and returns whether there is an
+// onset in this block.
+func (d *BeatDetector) Process(samples []int16) bool {
+	return d.Update(Energy(samples))
+}
+
+// Update returns whether the given energy (see Energy) of the next block is
+// an onset, and adds it to the history. This can be used to detect onsets in
+// part of the spectrum, for example using the energy of the lowest bands to
+// only detect bass drums.
+func (d *BeatDetector) Update(energy uint32) bool {
+	onset := false
+	if d.holdoff > 0 {
+		d.holdoff--
+	} else if d.filled > 0 && energy > d.previous {
+		average := d.sum / uint64(d.filled)
+		minEnergy := uint64(d.MinLevel) * uint64(d.MinLevel)
+		if uint64(energy)<<8 > average*uint64(d.Sensitivity) && uint64(energy) > minEnergy {
+			onset = true
+			d.holdoff = d.minInterval
+		}
+	}
+	d.previous = energy
+
+	// Add the energy to the history.
+	if d.filled == len(d.history) {
+		d.sum -= uint64(d.history[d.index])
+	} else {
+		d.filled++
+	}
+	d.history[d.index] = energy
+	d.sum += uint64(energy)
+	d.index++
+	if d.index == len(d.history) {
+		d.index = 0
+	}
+	return onset
+}
+
+// Reset clears the history of the beat detector.
+func (d *BeatDetector) Reset() {
+	d.index = 0
+	d.filled = 0
+	d.sum = 0
+	d.previous = 0
+	d.holdoff = 0
+}
@@ -0,0 +1,144 @@
+package audio
+
+import (
+	"time"
+)
+
+// Envelope smooths a level with separate attack and release times. This is
+// what makes a VU meter rise quickly and fall back slowly.
+//
+// The times are the time constants of the envelope: the time it takes to
+// close about 63% of the distance to a new level.
+type Envelope struct {
+	attack  uint32 // fraction of the distance that is closed per update, .16
+	release uint32 // same, for falling levels
+	value   uint32 // current level, 16.16
+}
+
+// NewEnvelope creates a new envelope with the given attack and release times,
+// that is updated updateRate times per second (for example, the sample rate
+// divided by the block size).
+func NewEnvelope(attack, release time.Duration, updateRate int) *Envelope {
+	e := &Envelope{}
+	e.SetTimes(attack, release, updateRate)
+	return e
+}
+
+// SetTimes changes the attack and release times of the envelope, without
+// changing the current level.
+func (e *Envelope) SetTimes(attack, release time.Duration, updateRate int) {
+	e.attack = coefficient(attack, updateRate)
+	e.release = coefficient(release, updateRate)
+}
+
+// Calculate the fraction of the distance that is closed in a single update,
+// as a .16 number. A time shorter than a single update means the new level is
+// used immediately.
+func coefficient(t time.Duration, updateRate int) uint32 {
+	updates := int64(t) * int64(updateRate) / int64(time.Second)
+	if updates <= 1 {
+		return 1 << 16
+	}
+	return uint32((1<<16 + updates/2) / updates)
+}
+
+// Update moves the envelope towards the given level and returns the new
+// level.
+func (e *Envelope) Update(level uint16) uint16 {
+	target := uint32(level) << 16
+	if target > e.value {
+		e.value += uint32(uint64(target-e.value) * uint64(e.attack) >> 16)
+	} else {
+		e.value -= uint32(uint64(e.value-target) * uint64(e.release) >> 16)
+	}
+	return uint16(e.value >> 16)
+}
+
+// Level returns the current level of the envelope.
+func (e *Envelope) Level() uint16 {
+	return uint16(e.value >> 16)
+}
+
+// Reset sets the level of the envelope to the given level.
+func (e *Envelope) Reset(level uint16) {
+	e.value = uint32(level) << 16
+}
+
+// PeakFollower follows the peak level of blocks of samples, as used in a peak
+// meter.
+type PeakFollower struct {
+	Envelope
+}
+
+// NewPeakFollower creates a new peak follower for blocks of samples that are
+// processed updateRate times per second.
+func NewPeakFollower(attack, release time.Duration, updateRate int) *PeakFollower {
+	f := &PeakFollower{}
+	f.SetTimes(attack, release, updateRate)
+	return f
+}
+
+// Process updates the envelope with the peak level of the samples and returns
+// the new level.
+func (f *PeakFollower) Process(samples []int16) uint16 {
+	return f.Update(Peak(samples))
+}
+
+// RMSFollower follows the RMS level of blocks of samples. This follows the
+// loudness of a sound more closely than the peak level.
+type RMSFollower struct {
+	Envelope
+}
+
+// NewRMSFollower creates a new RMS follower for blocks of samples that are
+// processed updateRate times per second.
+func NewRMSFollower(attack, release time.Duration, updateRate int) *RMSFollower {
+	f := &RMSFollower{}
+	f.SetTimes(attack, release, updateRate)
+	return f
+}
+
+// Process updates the envelope with the RMS level of the samples and returns
+// the new level.
+func (f *RMSFollower) Process(samples []int16) uint16 {
+	return f.Update(RMS(samples))
+}
+
+// Peak returns the largest absolute sample value. The result is 32768 for
+// a sample of -32768.
+func Peak(samples []int16) uint16 {
+	var peak uint16
+	for _, s := range samples {
+		level := uint16(s)
+		if s < 0 {
+			level = uint16(-int32(s))
+		}
+		if level > peak {
+			peak = level
+		}
+	}
+	return peak
+}
+
+// Energy returns the mean of the squared samples, which is the power of the
+// signal. It is the square of the RMS level.
+func Energy(samples []int16) uint32 {
+	if len(samples) == 0 {
+		return 0
+	}
+	var sum uint64
+	for _, s := range samples {
+		sum += uint64(int32(s) * int32(s))
+	}
+	return uint32(sum / uint64(len(samples)))
+}
+
+// RMS returns the root mean square level of the samples. A sine wave has an
+// RMS level of about 0.707 times its amplitude.
+func RMS(samples []int16) uint16 {
+	rms := sqrt(Energy(samples))
+	if rms > 0xffff {
+		rms = 0xffff
+	}
+	return uint16(rms)
+}
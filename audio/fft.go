@@ -0,0 +1,145 @@
+// Package audio contains building blocks for sound-reactive LED animations:
+// a fixed-point FFT with a logarithmic band binner, peak and RMS envelope
+// followers and a simple beat detector. All of them work on 16-bit PCM
+// samples and only use integer math, so that they are fast enough on
+// microcontrollers without FPU such as the Cortex-M0.
+package audio
+
+//go:generate go run gensin.go
+
+import (
+	"math/bits"
+)
+
+// Supported FFT sizes. The size must also be a power of two.
+const (
+	MinFFTSize = 64
+	MaxFFTSize = 512
+)
+
+// FFT calculates the magnitude spectrum of a block of samples. It keeps its
+// own buffers, so it doesn't allocate after it has been created.
+type FFT struct {
+	size   int
+	shift  uint    // log2(MaxFFTSize / size)
+	window []int16 // Hann window, Q15
+	re, im []int32
+}
+
+// NewFFT creates a new FFT for blocks of the given size, which must be a power
+// of two between MinFFTSize and MaxFFTSize. It panics for other sizes.
+func NewFFT(size int) *FFT {
+	if size < MinFFTSize || size > MaxFFTSize || size&(size-1) != 0 {
+		panic("audio: invalid FFT size")
+	}
+	f := &FFT{
+		size:   size,
+		shift:  uint(bits.TrailingZeros(MaxFFTSize / uint(size))),
+		window: make([]int16, size),
+		re:     make([]int32, size),
+		im:     make([]int32, size),
+	}
+	for i := range f.window {
+		// (1 - cos(2π·i/size)) / 2
+		f.window[i] = int16((32768 - int32(cos(i<<f.shift))) / 2)
+	}
+	return f
+}
+
+// Size returns the number of samples in a block.
+func (f *FFT) Size() int {
+	return f.size
+}
+
+// Transform calculates the magnitude spectrum of the given samples, after
+// applying a Hann window. There must be exactly Size() samples. The Size()/2
+// magnitudes are stored in out, where bin k contains the frequency
+// k*sampleRate/Size(). The magnitudes are scaled so that a sine wave that is
+// exactly in the center of a bin results in a magnitude close to its amplitude.
+// The first bin is the DC offset.
+func (f *FFT) Transform(samples []int16, out []uint16) {
+	n := f.size
+	if len(samples) != n || len(out) < n/2 {
+		panic("audio: invalid FFT buffer size")
+	}
+
+	// Apply the window and store the samples in bit-reversed order.
+	reverseShift := uint(32 - bits.TrailingZeros(uint(n)))
+	for i, s := range samples {
+		j := bits.Reverse32(uint32(i)) >> reverseShift
+		f.re[j] = int32(s) * int32(f.window[i]) >> 15
+		f.im[j] = 0
+	}
+
+	// Radix-2 decimation in time. Every stage is scaled down by half, so that
+	// the values (and the products below) never overflow.
+	re, im := f.re, f.im
+	for size := 2; size <= n; size *= 2 {
+		half := size / 2
+		step := MaxFFTSize / size
+		for start := 0; start < n; start += size {
+			for k := 0; k < half; k++ {
+				// Twiddle factor: e^(-2πi·k/size)
+				wr := int32(cos(k * step))
+				wi := -int32(sin(k * step))
+				a := start + k
+				b := a + half
+				tr := (re[b]*wr - im[b]*wi) >> 15
+				ti := (re[b]*wi + im[b]*wr) >> 15
+				re[b] = (re[a] - tr) >> 1
+				im[b] = (im[a] - ti) >> 1
+				re[a] = (re[a] + tr) >> 1
+				im[a] = (im[a] + ti) >> 1
+			}
+		}
+	}
+
+	// The result has been divided by n. Multiply by 2 for the energy in the
+	// negative frequencies and by 2 for the gain of the Hann window.
+	for k := 0; k < n/2; k++ {
+		magnitude := sqrt(uint32(re[k]*re[k])+uint32(im[k]*im[k])) * 4
+		if magnitude > 0xffff {
+			magnitude = 0xffff
+		}
+		out[k] = uint16(magnitude)
+	}
+}
+
+// Return sin(2π·i/MaxFFTSize) as a Q15 number.
+func sin(i int) int16 {
+	i &= MaxFFTSize - 1
+	switch {
+	case i <= MaxFFTSize/4:
+		return sinTable[i]
+	case i <= MaxFFTSize/2:
+		return sinTable[MaxFFTSize/2-i]
+	case i <= MaxFFTSize*3/4:
+		return -sinTable[i-MaxFFTSize/2]
+	default:
+		return -sinTable[MaxFFTSize-i]
+	}
+}
+
+// Return cos(2π·i/MaxFFTSize) as a Q15 number.
+func cos(i int) int16 {
+	return sin(i + MaxFFTSize/4)
+}
+
+// Integer square root, rounded down.
+func sqrt(x uint32) uint32 {
+	var result uint32
+	bit := uint32(1) << 30
+	for bit > x {
+		bit >>= 2
+	}
+	for bit != 0 {
+		if x >= result+bit {
+			x -= result + bit
+			result = result>>1 + bit
+		} else {
+			result >>= 1
+		}
+		bit >>= 2
+	}
+	return result
+}
@@ -0,0 +1,47 @@
+// +build none
+
+// This file is used in `go generate` to update sintable.go. It stores a
+// quarter of a sine wave as Q15 fixed-point numbers, which is all that is
+// needed for the twiddle factors and window of the largest FFT.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"math"
+	"os"
+)
+
+// Must be kept in sync with MaxFFTSize.
+const tableSize = 512
+
+func main() {
+	buf := &bytes.Buffer{}
+	buf.WriteString("// Code generated by gensin.go; DO NOT EDIT.\n\npackage audio\n\n")
+	buf.WriteString("// sin(2π·i/MaxFFTSize) as a Q15 number, for the first quarter of the wave.\n")
+	buf.WriteString("var sinTable = [MaxFFTSize/4 + 1]int16{")
+	for i := 0; i <= tableSize/4; i++ {
+		if i%8 == 0 {
+			buf.WriteString("\n")
+		}
+		v := math.Round(math.Sin(2*math.Pi*float64(i)/tableSize) * 32768)
+		if v > 32767 {
+			v = 32767
+		}
+		fmt.Fprintf(buf, "%d, ", int(v))
+	}
+	buf.WriteString("\n}\n")
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to format source:", err)
+		os.Exit(1)
+	}
+	err = ioutil.WriteFile("sintable.go", src, 0666)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to write sintable.go:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,96 @@
+package audio
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+)
+
+var (
+	errNotWAV          = errors.New("audio: not a WAV file")
+	errUnsupportedWAV  = errors.New("audio: only 16-bit PCM WAV files are supported")
+	errMissingWAVChunk = errors.New("audio: WAV file has no fmt or data chunk")
+)
+
+// WAV format tags.
+const (
+	wavFormatPCM        = 1
+	wavFormatExtensible = 0xfffe
+)
+
+// DecodeWAV reads a 16-bit PCM WAV file and returns its samples and sample
+// rate. Files with more than one channel are mixed down to mono. This is
+// mostly useful to test sound-reactive animations with recorded audio.
+func DecodeWAV(r io.Reader) (samples []int16, sampleRate int, err error) {
+	var header [12]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			err = errNotWAV
+		}
+		return nil, 0, err
+	}
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return nil, 0, errNotWAV
+	}
+
+	channels := 0
+	for {
+		var chunkHeader [8]byte
+		if _, err := io.ReadFull(r, chunkHeader[:]); err != nil {
+			if err == io.EOF {
+				err = errMissingWAVChunk
+			}
+			return nil, 0, err
+		}
+		size := binary.LittleEndian.Uint32(chunkHeader[4:])
+		switch string(chunkHeader[:4]) {
+		case "fmt ":
+			if size < 16 {
+				return nil, 0, errUnsupportedWAV
+			}
+			buf := make([]byte, size)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return nil, 0, err
+			}
+			format := binary.LittleEndian.Uint16(buf[0:])
+			channels = int(binary.LittleEndian.Uint16(buf[2:]))
+			sampleRate = int(binary.LittleEndian.Uint32(buf[4:]))
+			bitsPerSample := binary.LittleEndian.Uint16(buf[14:])
+			if format == wavFormatExtensible && size >= 26 {
+				format = binary.LittleEndian.Uint16(buf[24:]) // sub format
+			}
+			if format != wavFormatPCM || bitsPerSample != 16 || channels == 0 {
+				return nil, 0, errUnsupportedWAV
+			}
+		case "data":
+			if channels == 0 {
+				return nil, 0, errMissingWAVChunk
+			}
+			data, err := ioutil.ReadAll(io.LimitReader(r, int64(size)))
+			if err != nil {
+				return nil, 0, err
+			}
+			frameSize := channels * 2
+			samples = make([]int16, len(data)/frameSize)
+			for i := range samples {
+				var sum int32
+				for c := 0; c < channels; c++ {
+					sum += int32(int16(binary.LittleEndian.Uint16(data[i*frameSize+c*2:])))
+				}
+				samples[i] = int16(sum / int32(channels))
+			}
+			return samples, sampleRate, nil
+		default:
+			// Skip unknown chunks, which are padded to an even size.
+			if _, err := io.CopyN(ioutil.Discard, r, int64(size)+int64(size&1)); err != nil {
+				return nil, 0, err
+			}
+		}
+		if size&1 != 0 && string(chunkHeader[:4]) == "fmt " {
+			if _, err := io.CopyN(ioutil.Discard, r, 1); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Command ledsgo-png2go converts PNG images to Go source code, so that they
+// can be embedded in firmware without a filesystem. Every image is stored as a
+// sprite.Bitmap in the most compact format that doesn't lose information.
+//
+// It is intended to be used with go generate, for example:
+//
+//	//go:generate go run github.com/aykevl/ledsgo/cmd/ledsgo-png2go -o images.go logo.png walk.png
+//
+// This creates the variables Logo and Walk in images.go. The package name is
+// taken from the $GOPACKAGE environment variable (set by go generate) unless
+// the -pkg flag is used.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/aykevl/ledsgo/sprite"
+)
+
+func main() {
+	output := flag.String("o", "images.go", "output file")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package name of the output file")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: ledsgo-png2go [-o images.go] [-pkg name] image.png...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 || *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "// Code generated by ledsgo-png2go; DO NOT EDIT.\n\npackage %s\n\n", *pkg)
+	buf.WriteString("import \"github.com/aykevl/ledsgo/sprite\"\n\n")
+	for _, path := range flag.Args() {
+		if err := writeImage(buf, path); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to convert %s: %v\n", path, err)
+			os.Exit(1)
+		}
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to format source:", err)
+		os.Exit(1)
+	}
+	err = ioutil.WriteFile(*output, src, 0666)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write %s: %v\n", *output, err)
+		os.Exit(1)
+	}
+}
+
+// writeImage loads the PNG image and writes it to buf as a Go variable.
+func writeImage(buf *bytes.Buffer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		return err
+	}
+	b := sprite.NewBitmap(img)
+	name := varName(path)
+	fmt.Fprintf(buf, "// %s was converted from %s (%dx%d pixels).\n", name, filepath.Base(path), b.Width, b.Height)
+	fmt.Fprintf(buf, "var %s = &sprite.Bitmap{\nWidth: %d,\nHeight: %d,\n", name, b.Width, b.Height)
+	switch b.Format {
+	case sprite.FormatRGB:
+		buf.WriteString("Format: sprite.FormatRGB,\n")
+	case sprite.FormatRGBA:
+		buf.WriteString("Format: sprite.FormatRGBA,\n")
+	case sprite.FormatPaletted:
+		buf.WriteString("Format: sprite.FormatPaletted,\nPalette: ")
+		writeString(buf, b.Palette, 16)
+	}
+	buf.WriteString("Data: ")
+	writeString(buf, b.Data, 32)
+	buf.WriteString("}\n\n")
+	return nil
+}
+
+// writeString writes the data as a string literal, with the given number of
+// bytes per line. Strings (unlike byte slices) can be stored in flash by
+// TinyGo, so that large images don't use any RAM.
+func writeString(buf *bytes.Buffer, data string, perLine int) {
+	if len(data) == 0 {
+		buf.WriteString("\"\",\n")
+		return
+	}
+	for i := 0; i < len(data); i += perLine {
+		end := i + perLine
+		if end > len(data) {
+			end = len(data)
+		}
+		if i > 0 {
+			buf.WriteString(" +\n")
+		}
+		buf.WriteString("\"")
+		for j := i; j < end; j++ {
+			fmt.Fprintf(buf, "\\x%02x", data[j])
+		}
+		buf.WriteString("\"")
+	}
+	buf.WriteString(",\n")
+}
+
+// varName converts a file name such as "walk-cycle.png" to an exported Go
+// identifier such as "WalkCycle".
+func varName(path string) string {
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	var name []rune
+	upper := true
+	for _, r := range base {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		name = append(name, r)
+	}
+	if len(name) == 0 || unicode.IsDigit(name[0]) {
+		name = append([]rune("Image"), name...)
+	}
+	return string(name)
+}
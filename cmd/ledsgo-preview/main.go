@@ -0,0 +1,70 @@
+// Command ledsgo-preview shows an animation from the demos package live in a
+// truecolor terminal. Press Ctrl-C to stop. For example:
+//
+//	ledsgo-preview -width 64 -height 32 warp
+//
+// With the -strip flag, the animation is drawn on a LED strip of the given
+// length, shown as a single line of blocks.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/aykevl/ledsgo"
+	"github.com/aykevl/ledsgo/demos"
+	"github.com/aykevl/ledsgo/terminal"
+)
+
+func main() {
+	width := flag.Int("width", 32, "display width in LEDs")
+	height := flag.Int("height", 32, "display height in LEDs")
+	strip := flag.Int("strip", 0, "length of the LED strip, instead of a display of width×height")
+	frameRate := flag.Int("fps", 30, "frames per second")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: ledsgo-preview [flags] animation")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	animation, err := demos.Animation(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if *width <= 0 || *height <= 0 || *strip < 0 || *frameRate <= 0 {
+		fmt.Fprintln(os.Stderr, "width, height, strip and fps must be positive")
+		os.Exit(2)
+	}
+
+	var display interface {
+		terminal.Displayer
+		Close() error
+	}
+	if *strip != 0 {
+		display = terminal.NewStripDisplay(os.Stdout, make(ledsgo.Strip, *strip))
+	} else {
+		display = terminal.NewDisplay(os.Stdout, int16(*width), int16(*height))
+	}
+
+	// Stop on Ctrl-C, so that the cursor is restored.
+	done := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	go func() {
+		<-signals
+		close(done)
+	}()
+
+	err = terminal.Loop(display, animation, *frameRate, done)
+	display.Close()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to draw:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,200 @@
+// Command ledsgo-render renders an animation from the demos package to an
+// image, for documentation or to compare the output of animations between
+// versions.
+//
+// The output format is selected by the file name: a .gif file is an animated
+// GIF, a name with a %d verb (such as frame-%03d.png) is a sequence of PNG
+// files and any other name is an animated PNG. For example:
+//
+//	ledsgo-render -width 64 -height 32 -duration 5s -o fire.gif fire
+//
+// With the -waterfall flag, the animation is drawn on a strip of -width LEDs
+// and the output is a single PNG image where every row is a frame, so that
+// time goes from top to bottom.
+//
+// With the -recording flag, a recording made with the record package is
+// converted instead of rendering an animation. The size, duration and frame
+// times are then taken from the recording:
+//
+//	ledsgo-render -recording show.ledrec -o show.png
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aykevl/ledsgo/demos"
+	"github.com/kettek/apng"
+)
+
+func main() {
+	output := flag.String("o", "", "output file (.png, .gif or a PNG sequence such as frame-%03d.png)")
+	width := flag.Int("width", 32, "display width in LEDs")
+	height := flag.Int("height", 32, "display height in LEDs")
+	duration := flag.Duration("duration", 3*time.Second, "duration of the animation")
+	frameRate := flag.Int("fps", 30, "frames per second")
+	scale := flag.Int("scale", 4, "number of image pixels per LED, horizontally and vertically")
+	gamma := flag.Float64("gamma", 2.2, "gamma used to convert LED colors to image colors (1 to disable)")
+	start := flag.String("start", "2000-01-01T00:00:00Z", "time of the first frame (RFC 3339)")
+	waterfall := flag.Bool("waterfall", false, "render a strip as a single image with one row per frame")
+	recordingPath := flag.String("recording", "", "convert this recording instead of rendering an animation")
+	list := flag.Bool("list", false, "list the available animations")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: ledsgo-render [flags] -o output animation")
+		fmt.Fprintln(os.Stderr, "       ledsgo-render [flags] -o output -recording file")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *list {
+		for _, name := range demos.AnimationNames() {
+			fmt.Println(name)
+		}
+		return
+	}
+	if *width <= 0 || *height <= 0 || *scale <= 0 || *frameRate <= 0 || *gamma <= 0 {
+		fmt.Fprintln(os.Stderr, "width, height, scale, fps and gamma must be positive")
+		os.Exit(2)
+	}
+	r := &renderer{
+		width:     *width,
+		height:    *height,
+		scale:     *scale,
+		frameRate: *frameRate,
+		duration:  *duration,
+	}
+	r.setGamma(*gamma)
+
+	var err error
+	if *recordingPath != "" {
+		if flag.NArg() != 0 || *output == "" {
+			flag.Usage()
+			os.Exit(2)
+		}
+		err = convertRecording(r, *recordingPath, *output, *waterfall)
+	} else {
+		if flag.NArg() != 1 || *output == "" {
+			flag.Usage()
+			os.Exit(2)
+		}
+		var animation func(display demos.Displayer, now time.Time)
+		animation, err = demos.Animation(flag.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		r.start, err = time.Parse(time.RFC3339Nano, *start)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "invalid start time:", err)
+			os.Exit(2)
+		}
+		if r.frames() == 0 {
+			fmt.Fprintln(os.Stderr, "duration is shorter than a single frame")
+			os.Exit(2)
+		}
+		switch {
+		case *waterfall:
+			err = writePNG(*output, r.waterfall(animation))
+		default:
+			err = writeFrames(*output, r.render(animation), r.delays())
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to render:", err)
+		os.Exit(1)
+	}
+}
+
+// convertRecording converts the recording at path to an image, using the size
+// and frame times of the recording.
+func convertRecording(r *renderer, path, output string, waterfall bool) error {
+	rec, err := readRecording(path)
+	if err != nil {
+		return err
+	}
+	if len(rec.frames) == 0 {
+		return errors.New("recording has no frames")
+	}
+	if waterfall {
+		r.width = rec.header.LEDs()
+		return writePNG(output, r.drawWaterfall(len(rec.frames), rec.drawStrip))
+	}
+	r.width = rec.header.Width
+	r.height = rec.header.Height
+	return writeFrames(output, r.drawFrames(len(rec.frames), rec.draw), rec.delays())
+}
+
+// writeFrames writes the frames in the format selected by the output file
+// name: a sequence of PNG files, an animated GIF or an animated PNG.
+func writeFrames(output string, images []*image.RGBA, delays []time.Duration) error {
+	switch {
+	case strings.Contains(output, "%"):
+		for i, img := range images {
+			if err := writePNG(fmt.Sprintf(output, i), img); err != nil {
+				return err
+			}
+		}
+		return nil
+	case strings.EqualFold(filepath.Ext(output), ".gif"):
+		return writeGIF(output, images, gifDelays(delays))
+	default:
+		return writeAPNG(output, images, delays)
+	}
+}
+
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeAPNG(path string, images []*image.RGBA, delays []time.Duration) error {
+	var a apng.APNG
+	for i, img := range images {
+		numerator, denominator := apngDelay(delays[i])
+		a.Frames = append(a.Frames, apng.Frame{
+			Image:            img,
+			DelayNumerator:   numerator,
+			DelayDenominator: denominator,
+		})
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := apng.Encode(f, a); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeGIF(path string, images []*image.RGBA, delays []int) error {
+	g := &gif.GIF{Delay: delays}
+	for _, img := range images {
+		g.Image = append(g.Image, toPaletted(img))
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := gif.EncodeAll(f, g); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/aykevl/ledsgo"
+	"github.com/aykevl/ledsgo/demos"
+	"github.com/aykevl/ledsgo/record"
+)
+
+// recording is a recording from the record package, read into memory.
+type recording struct {
+	header record.Header
+	frames []ledsgo.Strip
+	times  []time.Duration
+}
+
+// readRecording reads all frames of the recording at path. A recording that
+// was cut off in the middle of a frame is read up to the last complete frame.
+func readRecording(path string) (*recording, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r, err := record.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	rec := &recording{header: r.Header()}
+	for {
+		strip := make(ledsgo.Strip, rec.header.LEDs())
+		t, err := r.ReadFrame(strip)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		rec.frames = append(rec.frames, strip)
+		rec.times = append(rec.times, t)
+	}
+	return rec, nil
+}
+
+// delays returns how long every frame is shown: until the next frame, and for
+// the last frame one frame at the frame rate of the recording.
+func (rec *recording) delays() []time.Duration {
+	last := 100 * time.Millisecond
+	if rec.header.FrameRate != 0 {
+		last = time.Second / time.Duration(rec.header.FrameRate)
+	}
+	delays := make([]time.Duration, len(rec.frames))
+	for i := range delays {
+		if i+1 < len(rec.times) {
+			delays[i] = rec.times[i+1] - rec.times[i]
+		} else {
+			delays[i] = last
+		}
+	}
+	return delays
+}
+
+// draw draws the frame with the LEDs at their position in the layout.
+func (rec *recording) draw(frame int, display demos.Displayer) {
+	for i, c := range rec.frames[frame] {
+		x, y := rec.header.Position(i)
+		display.SetPixel(int16(x), int16(y), c)
+	}
+}
+
+// drawStrip draws the frame as a single line of LEDs, in strip order.
+func (rec *recording) drawStrip(frame int, display demos.Displayer) {
+	for i, c := range rec.frames[frame] {
+		display.SetPixel(int16(i), 0, c)
+	}
+}
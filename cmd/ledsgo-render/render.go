@@ -0,0 +1,196 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/color/palette"
+	imagedraw "image/draw"
+	"time"
+
+	"github.com/aykevl/ledsgo/demos"
+	"github.com/aykevl/ledsgo/internal/gamma"
+)
+
+// renderer renders an animation to a sequence of images.
+type renderer struct {
+	width, height int
+	scale         int
+	frameRate     int
+	duration      time.Duration
+	start         time.Time
+	gamma         [256]uint8 // converts linear LED values to sRGB
+}
+
+// setGamma sets the gamma used to convert the linear LED colors to the sRGB
+// colors of an image. A gamma of 1 leaves colors unmodified.
+func (r *renderer) setGamma(value float64) {
+	r.gamma = gamma.Table(value)
+}
+
+// frames returns the number of frames in the animation.
+func (r *renderer) frames() int {
+	return int(r.duration * time.Duration(r.frameRate) / time.Second)
+}
+
+// frameTime returns the time of the given frame.
+func (r *renderer) frameTime(frame int) time.Time {
+	return r.start.Add(time.Duration(frame) * time.Second / time.Duration(r.frameRate))
+}
+
+// render draws all frames of the animation.
+func (r *renderer) render(animation func(demos.Displayer, time.Time)) []*image.RGBA {
+	return r.drawFrames(r.frames(), func(i int, display demos.Displayer) {
+		animation(display, r.frameTime(i))
+	})
+}
+
+// waterfall draws the animation on a strip of r.width LEDs, with every frame
+// as a row of the image. Time goes from top to bottom.
+func (r *renderer) waterfall(animation func(demos.Displayer, time.Time)) *image.RGBA {
+	return r.drawWaterfall(r.frames(), func(i int, display demos.Displayer) {
+		animation(display, r.frameTime(i))
+	})
+}
+
+// delays returns how long every frame of the animation is shown.
+func (r *renderer) delays() []time.Duration {
+	delays := make([]time.Duration, r.frames())
+	for i := range delays {
+		delays[i] = r.frameTime(i + 1).Sub(r.frameTime(i))
+	}
+	return delays
+}
+
+// drawFrames draws the given number of frames, each on a new image.
+func (r *renderer) drawFrames(frames int, draw func(frame int, display demos.Displayer)) []*image.RGBA {
+	var images []*image.RGBA
+	for i := 0; i < frames; i++ {
+		display := &imageDisplay{
+			img:    image.NewRGBA(image.Rect(0, 0, r.width*r.scale, r.height*r.scale)),
+			width:  int16(r.width),
+			height: int16(r.height),
+			scale:  r.scale,
+			gamma:  &r.gamma,
+		}
+		draw(i, display)
+		images = append(images, display.img)
+	}
+	return images
+}
+
+// drawWaterfall draws the given number of frames on a strip of r.width LEDs,
+// with every frame as a row of a single image.
+func (r *renderer) drawWaterfall(frames int, draw func(frame int, display demos.Displayer)) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, r.width*r.scale, frames*r.scale))
+	for i := 0; i < frames; i++ {
+		display := &imageDisplay{
+			img:     img,
+			width:   int16(r.width),
+			height:  1,
+			scale:   r.scale,
+			offsetY: i * r.scale,
+			gamma:   &r.gamma,
+		}
+		draw(i, display)
+	}
+	return img
+}
+
+// gifDelays converts frame delays to hundredths of a second, which is the
+// unit used by GIF. Rounding errors are spread over the frames, so that the
+// total duration is correct.
+func gifDelays(delays []time.Duration) []int {
+	result := make([]int, len(delays))
+	var total time.Duration
+	for i, delay := range delays {
+		start := total
+		total += delay
+		result[i] = int(total.Round(10*time.Millisecond)/(10*time.Millisecond)) - int(start.Round(10*time.Millisecond)/(10*time.Millisecond))
+	}
+	return result
+}
+
+// apngDelay converts a frame delay to the fraction used by APNG, which
+// consists of two 16-bit numbers. Delays of 1s/fps (within the nanosecond
+// rounding of frame times) are stored as 1/fps, and other delays as an exact
+// fraction if possible, so that the animation doesn't drift. Only delays that
+// can't be stored exactly are rounded.
+func apngDelay(delay time.Duration) (numerator, denominator uint16) {
+	if delay <= 0 {
+		return 0, 1
+	}
+	if fps := (time.Second + delay/2) / delay; fps >= 1 && fps <= 0xffff {
+		if diff := time.Second/fps - delay; diff >= -1 && diff <= 1 {
+			return 1, uint16(fps)
+		}
+	}
+	n, d := int64(delay), int64(time.Second)
+	for a, b := n, d; b != 0; {
+		a, b = b, a%b
+		if b == 0 {
+			n, d = n/a, d/a
+		}
+	}
+	if n <= 0xffff && d <= 0xffff {
+		return uint16(n), uint16(d)
+	}
+	for _, denominator := range []uint16{10000, 1000, 100, 1} {
+		n := delay.Round(time.Second/time.Duration(denominator)) / (time.Second / time.Duration(denominator))
+		if n <= 0xffff {
+			return uint16(n), denominator
+		}
+	}
+	return 0xffff, 1
+}
+
+// toPaletted converts an image to a paletted image for GIF. Images with at
+// most 256 colors are converted without loss, other images are reduced to
+// the Plan 9 palette.
+func toPaletted(img *image.RGBA) *image.Paletted {
+	var pal color.Palette
+	seen := make(map[color.RGBA]bool)
+	for i := 0; i < len(img.Pix); i += 4 {
+		c := color.RGBA{img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3]}
+		if !seen[c] {
+			seen[c] = true
+			pal = append(pal, c)
+			if len(pal) > 256 {
+				pal = palette.Plan9
+				break
+			}
+		}
+	}
+	paletted := image.NewPaletted(img.Bounds(), pal)
+	imagedraw.Draw(paletted, img.Bounds(), img, image.Point{}, imagedraw.Src)
+	return paletted
+}
+
+// imageDisplay is a demos.Displayer that draws into an image, with every LED
+// as a square of scale×scale pixels.
+type imageDisplay struct {
+	img           *image.RGBA
+	width, height int16
+	scale         int
+	offsetY       int
+	gamma         *[256]uint8
+}
+
+func (d *imageDisplay) Size() (int16, int16) {
+	return d.width, d.height
+}
+
+func (d *imageDisplay) SetPixel(x, y int16, c color.RGBA) {
+	if x < 0 || y < 0 || x >= d.width || y >= d.height {
+		return
+	}
+	c = color.RGBA{d.gamma[c.R], d.gamma[c.G], d.gamma[c.B], 0xff}
+	for iy := int(y) * d.scale; iy < int(y+1)*d.scale; iy++ {
+		for ix := int(x) * d.scale; ix < int(x+1)*d.scale; ix++ {
+			d.img.SetRGBA(ix, d.offsetY+iy, c)
+		}
+	}
+}
+
+func (d *imageDisplay) Display() error {
+	return nil
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aykevl/ledsgo"
+	"github.com/aykevl/ledsgo/demos"
+	"github.com/aykevl/ledsgo/record"
+)
+
+func TestRender(t *testing.T) {
+	r := &renderer{
+		width:     3,
+		height:    2,
+		scale:     2,
+		frameRate: 30,
+		duration:  time.Second,
+		start:     time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	r.setGamma(1)
+
+	// Draw the frame number in the red channel of pixel (x, y) = (1, 1).
+	var times []time.Time
+	animation := func(display demos.Displayer, now time.Time) {
+		width, height := display.Size()
+		display.SetPixel(width-2, height-1, color.RGBA{uint8(len(times)), 0, 0, 255})
+		times = append(times, now)
+	}
+
+	images := r.render(animation)
+	if len(images) != 30 {
+		t.Fatalf("expected 30 frames, got %d", len(images))
+	}
+	if d := times[29].Sub(times[0]); d != 29*time.Second/30 {
+		t.Errorf("unexpected time between the first and last frame: %v", d)
+	}
+	img := images[5]
+	if size := img.Bounds().Size(); size.X != 6 || size.Y != 4 {
+		t.Errorf("unexpected image size: %v", size)
+	}
+	for _, p := range [][2]int{{2, 2}, {3, 3}} {
+		if c := img.RGBAAt(p[0], p[1]); c != (color.RGBA{5, 0, 0, 255}) {
+			t.Errorf("pixel %v: unexpected color %v", p, c)
+		}
+	}
+	if c := img.RGBAAt(1, 1); c.A != 0 {
+		t.Errorf("pixel that was not drawn has color %v", c)
+	}
+
+	// In a waterfall, every frame is a row of one LED high.
+	times = nil
+	img = r.waterfall(animation)
+	if size := img.Bounds().Size(); size.X != 6 || size.Y != 60 {
+		t.Errorf("unexpected waterfall size: %v", size)
+	}
+	if c := img.RGBAAt(2, 7*2+1); c != (color.RGBA{7, 0, 0, 255}) {
+		t.Errorf("unexpected color in waterfall: %v", c)
+	}
+
+	total := 0
+	for _, delay := range gifDelays(r.delays()) {
+		if delay != 3 && delay != 4 {
+			t.Errorf("unexpected GIF delay: %d", delay)
+		}
+		total += delay
+	}
+	if total != 100 {
+		t.Errorf("expected a total GIF delay of 100, got %d", total)
+	}
+}
+
+func TestGamma(t *testing.T) {
+	r := &renderer{}
+	r.setGamma(2.2)
+	if r.gamma[0] != 0 || r.gamma[255] != 255 || r.gamma[55] != 127 {
+		t.Errorf("unexpected gamma table: %d %d %d", r.gamma[0], r.gamma[55], r.gamma[255])
+	}
+}
+
+func TestAPNGDelay(t *testing.T) {
+	for _, tc := range []struct {
+		delay                  time.Duration
+		numerator, denominator uint16
+	}{
+		{time.Second / 30, 1, 30},
+		{time.Second/30 + 1, 1, 30}, // frame times are rounded to nanoseconds
+		{20 * time.Millisecond, 1, 50},
+		{1500 * time.Millisecond, 3, 2},
+		{10 * time.Second, 10, 1},
+		{7777777, 78, 10000}, // can't be stored exactly
+		{1000 * time.Hour, 0xffff, 1},
+	} {
+		if n, d := apngDelay(tc.delay); n != tc.numerator || d != tc.denominator {
+			t.Errorf("%v: expected %d/%d, got %d/%d", tc.delay, tc.numerator, tc.denominator, n, d)
+		}
+	}
+}
+
+func TestRecording(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.ledrec")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := record.NewWriter(f, record.Header{Layout: record.LayoutSerpentine, Width: 2, Height: 2, FrameRate: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	red := color.RGBA{255, 0, 0, 255}
+	w.WriteFrame(ledsgo.Strip{red}, 0)
+	w.WriteFrame(ledsgo.Strip{{}, {}, red}, 100*time.Millisecond)
+	f.Close()
+
+	rec, err := readRecording(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delays := rec.delays()
+	if len(delays) != 2 || delays[0] != 100*time.Millisecond || delays[1] != 20*time.Millisecond {
+		t.Errorf("unexpected delays: %v", delays)
+	}
+	r := &renderer{width: 2, height: 2, scale: 1}
+	r.setGamma(1)
+	images := r.drawFrames(2, rec.draw)
+	// LED 2 is the first LED of the second row, which runs from right to
+	// left.
+	if images[0].RGBAAt(0, 0) != red || images[1].RGBAAt(1, 1) != red || images[1].RGBAAt(0, 1) != (color.RGBA{0, 0, 0, 255}) {
+		t.Errorf("unexpected images: %v %v", images[0].Pix, images[1].Pix)
+	}
+}
@@ -0,0 +1,200 @@
+package ledsgo
+
+import (
+	"image/color"
+)
+
+// ColorOrder is the order in which color channels are sent to a LED chip.
+// Many LED chips don't use RGB order: for example, the WS2812 uses GRB order.
+// The zero value is RGB.
+//
+// The RGBW variants are for LED chips with an extra white LED, where the white
+// channel is sent after the color channels. Because colors are stored as RGB,
+// the white channel is derived from the color: the part common to all three
+// color channels is sent to the white LED instead.
+type ColorOrder uint8
+
+// Bit that is set for the RGBW variants of a color order.
+const colorOrderWhite = 8
+
+// All possible orders of the three color channels, and their RGBW variants.
+const (
+	RGB ColorOrder = iota
+	RBG
+	GRB
+	GBR
+	BRG
+	BGR
+)
+const (
+	RGBW ColorOrder = colorOrderWhite + iota
+	RBGW
+	GRBW
+	GBRW
+	BRGW
+	BGRW
+)
+
+// Index of the red, green and blue channels in the output, for each color
+// order (without the white flag).
+var colorOrderIndices = [...][3]uint8{
+	RGB: {0, 1, 2},
+	RBG: {0, 2, 1},
+	GRB: {1, 0, 2},
+	GBR: {2, 0, 1},
+	BRG: {1, 2, 0},
+	BGR: {2, 1, 0},
+}
+
+// Valid returns whether this is one of the color orders defined above. Put and
+// Get (and therefore the Strip methods that use them) panic for invalid
+// orders.
+func (o ColorOrder) Valid() bool {
+	return int(o&^colorOrderWhite) < len(colorOrderIndices) && o&^(colorOrderWhite|7) == 0
+}
+
+// WithoutWhite returns the three-channel variant of this color order, for
+// example GRB for GRBW. Three-channel orders are returned unchanged.
+func (o ColorOrder) WithoutWhite() ColorOrder {
+	return o &^ colorOrderWhite
+}
+
+// Channels returns the number of channels (and thus bytes) per LED: 3 for RGB
+// orders and 4 for RGBW orders.
+func (o ColorOrder) Channels() int {
+	if o&colorOrderWhite != 0 {
+		return 4
+	}
+	return 3
+}
+
+// String returns the name of the color order, such as "GRB" or "GRBW".
+func (o ColorOrder) String() string {
+	if !o.Valid() {
+		return "ColorOrder(?)"
+	}
+	s := [4]byte{3: 'W'}
+	indices := &colorOrderIndices[o.WithoutWhite()]
+	s[indices[0]] = 'R'
+	s[indices[1]] = 'G'
+	s[indices[2]] = 'B'
+	return string(s[:o.Channels()])
+}
+
+// Put stores the color c in the first bytes of dst in this color order. It
+// stores 3 bytes for RGB orders and 4 bytes for RGBW orders. It panics if the
+// order is not valid.
+func (o ColorOrder) Put(dst []byte, c color.RGBA) {
+	if o&colorOrderWhite != 0 {
+		var w uint8
+		c, w = ExtractWhite(c)
+		dst[3] = w
+	}
+	indices := &colorOrderIndices[o.WithoutWhite()]
+	dst[indices[0]] = c.R
+	dst[indices[1]] = c.G
+	dst[indices[2]] = c.B
+}
+
+// Get is the inverse of Put: it reads a color in this color order from the
+// first bytes of src. For RGBW orders the white channel is added to the color
+// channels. It panics if the order is not valid.
+func (o ColorOrder) Get(src []byte) color.RGBA {
+	indices := &colorOrderIndices[o.WithoutWhite()]
+	c := color.RGBA{
+		R: src[indices[0]],
+		G: src[indices[1]],
+		B: src[indices[2]],
+		A: 0xff,
+	}
+	if o&colorOrderWhite != 0 {
+		w := src[3]
+		c.R = addSaturate(c.R, w)
+		c.G = addSaturate(c.G, w)
+		c.B = addSaturate(c.B, w)
+	}
+	return c
+}
+
+// Add two 8-bit values, saturating at 0xff.
+func addSaturate(a, b uint8) uint8 {
+	if uint16(a)+uint16(b) > 0xff {
+		return 0xff
+	}
+	return a + b
+}
+
+// ExtractWhite splits the color c in a color part and a white part, using the
+// part common to all three color channels as white. This is useful for RGBW
+// LEDs, where the white LED is usually more efficient and gives a better white
+// than mixing red, green and blue.
+func ExtractWhite(c color.RGBA) (color.RGBA, uint8) {
+	w := c.R
+	if c.G < w {
+		w = c.G
+	}
+	if c.B < w {
+		w = c.B
+	}
+	c.R -= w
+	c.G -= w
+	c.B -= w
+	return c, w
+}
+
+// Reorder changes the order of the color channels of all LEDs in place, so
+// that the memory layout of the strip matches the byte order of the LED chip:
+// the R, G and B fields contain the first, second and third channel in this
+// order. For RGBW orders, the A field contains the white channel. Because the
+// result is not a valid strip anymore, this is usually done just before
+// sending the strip to the LEDs (and the strip is redrawn afterwards).
+func (s Strip) Reorder(order ColorOrder) {
+	if order == RGB {
+		return // nothing to do
+	}
+	var buf [4]byte
+	for i, c := range s {
+		buf[3] = c.A
+		order.Put(buf[:], c)
+		s[i] = color.RGBA{buf[0], buf[1], buf[2], buf[3]}
+	}
+}
+
+// AppendBytes appends the colors of the strip to buf in the given color order
+// and returns the extended buffer. Every LED takes order.Channels() bytes.
+func (s Strip) AppendBytes(buf []byte, order ColorOrder) []byte {
+	channels := order.Channels()
+	start := len(buf)
+	n := len(s) * channels
+	if cap(buf)-start < n {
+		newBuf := make([]byte, start, start+n)
+		copy(newBuf, buf)
+		buf = newBuf
+	}
+	buf = buf[:start+n]
+	data := buf[start:]
+	for i, c := range s {
+		order.Put(data[i*channels:], c)
+	}
+	return buf
+}
+
+// Segment is a part of a strip with its own color order, for installations
+// that use different LED chips on a single logical strip (for example, a GRB
+// strip followed by a BRG strip on the same data line).
+type Segment struct {
+	Start  int // index of the first LED
+	Length int // number of LEDs
+	Order  ColorOrder
+}
+
+// AppendSegments appends the given segments of the strip to buf, each in its
+// own color order, and returns the extended buffer. The segments are appended
+// in the order they are listed. LEDs that are not part of a segment are left
+// out.
+func (s Strip) AppendSegments(buf []byte, segments []Segment) []byte {
+	for _, segment := range segments {
+		buf = s[segment.Start:segment.Start+segment.Length].AppendBytes(buf, segment.Order)
+	}
+	return buf
+}
@@ -0,0 +1,116 @@
+package ledsgo
+
+import (
+	"bytes"
+	"image/color"
+	"testing"
+)
+
+func TestColorOrder(t *testing.T) {
+	for order, name := range map[ColorOrder]string{
+		RGB: "RGB", RBG: "RBG", GRB: "GRB", GBR: "GBR", BRG: "BRG", BGR: "BGR",
+		RGBW: "RGBW", RBGW: "RBGW", GRBW: "GRBW", GBRW: "GBRW", BRGW: "BRGW", BGRW: "BGRW",
+	} {
+		if order.String() != name {
+			t.Errorf("expected %s, got %s", name, order.String())
+		}
+		if order.Channels() != len(name) {
+			t.Errorf("%s: expected %d channels, got %d", name, len(name), order.Channels())
+		}
+		// Check that the name matches the actual order. The white channel is
+		// the part common to all three color channels, so it's easiest to
+		// check with a color that has no white in it.
+		var buf [4]byte
+		order.Put(buf[:], color.RGBA{R: 'R', G: 'G', B: 'B'})
+		expected := []byte(name)
+		if len(name) == 4 {
+			w := byte('B') // lowest of R, G and B
+			for i, c := range expected {
+				if c == 'W' {
+					expected[i] = w
+				} else {
+					expected[i] -= w
+				}
+			}
+		}
+		if !bytes.Equal(buf[:len(name)], expected) {
+			t.Errorf("%s: Put returned %v, expected %v", name, buf[:len(name)], expected)
+		}
+		if c := order.Get(buf[:]); c != (color.RGBA{R: 'R', G: 'G', B: 'B', A: 0xff}) {
+			t.Errorf("%s: Get returned %v", name, c)
+		}
+	}
+	for _, order := range []ColorOrder{6, 7, 14, 16} {
+		if s := order.String(); s != "ColorOrder(?)" {
+			t.Errorf("invalid order %d: got String() %s", order, s)
+		}
+		if order.Valid() {
+			t.Errorf("invalid order %d: Valid() returned true", order)
+		}
+	}
+	if !BGRW.Valid() || BGRW.WithoutWhite() != BGR || GRB.WithoutWhite() != GRB {
+		t.Error("unexpected Valid or WithoutWhite result")
+	}
+}
+
+func TestStripReorder(t *testing.T) {
+	s := Strip{{R: 1, G: 2, B: 3, A: 0xff}, {R: 0x80, G: 0x40, B: 0x20, A: 0xff}}
+	s.Reorder(GRB)
+	expected := Strip{{R: 2, G: 1, B: 3, A: 0xff}, {R: 0x40, G: 0x80, B: 0x20, A: 0xff}}
+	for i := range s {
+		if s[i] != expected[i] {
+			t.Errorf("GRB: LED %d: expected %v, got %v", i, expected[i], s[i])
+		}
+	}
+
+	s = Strip{{R: 1, G: 2, B: 3, A: 0xff}, {R: 0x80, G: 0x40, B: 0x20, A: 0xff}}
+	s.Reorder(GRBW)
+	expected = Strip{{R: 1, G: 0, B: 2, A: 1}, {R: 0x20, G: 0x60, B: 0, A: 0x20}}
+	for i := range s {
+		if s[i] != expected[i] {
+			t.Errorf("GRBW: LED %d: expected %v, got %v", i, expected[i], s[i])
+		}
+	}
+}
+
+func TestStripAppendBytes(t *testing.T) {
+	s := Strip{{R: 1, G: 2, B: 3}, {R: 4, G: 5, B: 6}, {R: 7, G: 8, B: 9}}
+	tests := []struct {
+		order ColorOrder
+		out   []byte
+	}{
+		{RGB, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{GRB, []byte{2, 1, 3, 5, 4, 6, 8, 7, 9}},
+		{BGR, []byte{3, 2, 1, 6, 5, 4, 9, 8, 7}},
+		{RGBW, []byte{0, 1, 2, 1, 0, 1, 2, 4, 0, 1, 2, 7}},
+	}
+	for _, tc := range tests {
+		out := s.AppendBytes([]byte{0xaa}, tc.order)
+		if !bytes.Equal(out, append([]byte{0xaa}, tc.out...)) {
+			t.Errorf("%s: unexpected output: %v", tc.order, out)
+		}
+	}
+}
+
+func TestStripAppendSegments(t *testing.T) {
+	// A single logical strip with a GRB part followed by a BRG part and an
+	// RGBW part.
+	s := Strip{{R: 1, G: 2, B: 3}, {R: 4, G: 5, B: 6}, {R: 7, G: 8, B: 9}, {R: 10, G: 11, B: 12}}
+	segments := []Segment{
+		{Start: 0, Length: 2, Order: GRB},
+		{Start: 2, Length: 1, Order: BRG},
+		{Start: 3, Length: 1, Order: RGBW},
+	}
+	out := s.AppendSegments(nil, segments)
+	expected := []byte{2, 1, 3, 5, 4, 6, 9, 7, 8, 0, 1, 2, 10}
+	if !bytes.Equal(out, expected) {
+		t.Errorf("unexpected output\nexpected: %v\nactual:   %v", expected, out)
+	}
+}
+
+func BenchmarkStripReorder(b *testing.B) {
+	s := make(Strip, 300)
+	for i := 0; i < b.N; i++ {
+		s.Reorder(GRB)
+	}
+}
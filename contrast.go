@@ -0,0 +1,68 @@
+package ledsgo
+
+//go:generate go run gencdf.go
+
+// The output of the noise functions covers the full range of a uint16, but
+// most values are close to the center: values near 0 and 0xffff are very
+// rare. This file contains functions to remap noise values so that the full
+// range is used more evenly, which is useful when mapping noise to a palette
+// or a hue.
+
+// Number of segments in the CDF lookup tables. Every table contains one more
+// entry than this, for the upper bound of the last segment.
+const cdfTableSize = 128
+
+// Look up the value n in the cumulative distribution function (CDF) table,
+// interpolating between table entries.
+func lookupCDF(table *[cdfTableSize + 1]uint16, n uint16) uint16 {
+	index := n >> 9
+	frac := uint32(n & 0x1ff) // .9
+	bottom := uint32(table[index])
+	top := uint32(table[index+1])
+	return uint16(bottom + ((top-bottom)*frac)>>9)
+}
+
+// EqualizeNoise1 remaps the output of Noise1 so that all output values are
+// (nearly) equally likely. The mapping is monotonic, so the noise keeps its
+// shape but gets a lot more contrast.
+//
+// This is done using a precomputed lookup table of the distribution of Noise1
+// output values, which makes this function fast and deterministic.
+func EqualizeNoise1(n uint16) uint16 {
+	return lookupCDF(&noise1CDF, n)
+}
+
+// EqualizeNoise2 remaps the output of Noise2 so that all output values are
+// (nearly) equally likely. See EqualizeNoise1 for details.
+func EqualizeNoise2(n uint16) uint16 {
+	return lookupCDF(&noise2CDF, n)
+}
+
+// EqualizeNoise3 remaps the output of Noise3 so that all output values are
+// (nearly) equally likely. See EqualizeNoise1 for details.
+func EqualizeNoise3(n uint16) uint16 {
+	return lookupCDF(&noise3CDF, n)
+}
+
+// EqualizeNoise4 remaps the output of Noise4 so that all output values are
+// (nearly) equally likely. See EqualizeNoise1 for details.
+func EqualizeNoise4(n uint16) uint16 {
+	return lookupCDF(&noise4CDF, n)
+}
+
+// Contrast applies a linear contrast curve to a noise value: the distance to
+// the center (32768) is multiplied by the 8.8 fixed-point gain and the result
+// is clamped to the uint16 range. A gain of 1.0 (0x100) returns the input
+// unmodified, a gain of 2.0 (0x200) doubles the contrast. Unlike the Equalize*
+// functions this works for any noise function, but values far away from the
+// center are clipped.
+func Contrast(n uint16, gain uint16) uint16 {
+	d := int32(n) - 0x8000     // .15
+	d = (d * int32(gain)) >> 8 // .15 * .8 = .23 >> 8 = .15
+	if d > 0x7fff {
+		d = 0x7fff
+	} else if d < -0x8000 {
+		d = -0x8000
+	}
+	return uint16(d + 0x8000)
+}
@@ -0,0 +1,91 @@
+package ledsgo
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestEqualizeNoise(t *testing.T) {
+	tests := []struct {
+		name     string
+		noise    func(r *rand.Rand) uint16
+		equalize func(uint16) uint16
+	}{
+		{"Noise1", func(r *rand.Rand) uint16 {
+			return Noise1(r.Uint32())
+		}, EqualizeNoise1},
+		{"Noise2", func(r *rand.Rand) uint16 {
+			return Noise2(r.Uint32(), r.Uint32())
+		}, EqualizeNoise2},
+		{"Noise3", func(r *rand.Rand) uint16 {
+			return Noise3(r.Uint32(), r.Uint32(), r.Uint32())
+		}, EqualizeNoise3},
+		{"Noise4", func(r *rand.Rand) uint16 {
+			return Noise4(r.Uint32(), r.Uint32(), r.Uint32(), r.Uint32())
+		}, EqualizeNoise4},
+	}
+	for _, tc := range tests {
+		// Use a different seed than gencdf.go, to test with values that
+		// weren't used to build the lookup tables.
+		r := rand.New(rand.NewSource(1))
+		const numTests = 1 << 20
+		const numBuckets = 16
+		var buckets [numBuckets]int
+		for i := 0; i < numTests; i++ {
+			buckets[tc.equalize(tc.noise(r))/(0x10000/numBuckets)]++
+		}
+		for i, count := range buckets {
+			fraction := float64(count) / numTests * numBuckets // 1.0 is perfect
+			if fraction < 0.9 || fraction > 1.1 {
+				t.Errorf("%s: bucket %d has a fraction of %.3f of the expected values", tc.name, i, fraction)
+			}
+		}
+	}
+}
+
+func TestEqualizeNoiseMonotonic(t *testing.T) {
+	for _, equalize := range []func(uint16) uint16{EqualizeNoise1, EqualizeNoise2, EqualizeNoise3, EqualizeNoise4} {
+		prev := equalize(0)
+		for n := 1; n <= 0xffff; n++ {
+			v := equalize(uint16(n))
+			if v < prev {
+				t.Fatalf("not monotonic at %d: %d < %d", n, v, prev)
+			}
+			prev = v
+		}
+	}
+}
+
+func TestContrast(t *testing.T) {
+	tests := []struct {
+		n      uint16
+		gain   uint16
+		result uint16
+	}{
+		{0x8000, 0x400, 0x8000},
+		{0x1234, 0x100, 0x1234},
+		{0xfedc, 0x100, 0xfedc},
+		{0x9000, 0x200, 0xa000},
+		{0x7000, 0x200, 0x6000},
+		{0x9000, 0x080, 0x8800},
+		{0xc000, 0x300, 0xffff},
+		{0x4000, 0x300, 0x0000},
+		{0x0000, 0xffff, 0x0000},
+		{0xffff, 0xffff, 0xffff},
+		{0x1234, 0, 0x8000},
+	}
+	for _, tc := range tests {
+		result := Contrast(tc.n, tc.gain)
+		if result != tc.result {
+			t.Errorf("Contrast(0x%04x, 0x%03x): expected 0x%04x, got 0x%04x", tc.n, tc.gain, tc.result, result)
+		}
+	}
+}
+
+func BenchmarkEqualizeNoise3(b *testing.B) {
+	var r uint16
+	for i := 0; i < b.N; i++ {
+		r = EqualizeNoise3(uint16(i))
+	}
+	resultUint16 = r
+}
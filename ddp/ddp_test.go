@@ -0,0 +1,248 @@
+package ddp
+
+import (
+	"bytes"
+	"image/color"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/aykevl/ledsgo"
+)
+
+// Example packets from the specification, as they are sent on the wire: the
+// header bytes are followed by the data.
+var specPackets = []struct {
+	name   string
+	header []byte
+	data   []byte
+	packet Packet
+}{
+	// 1000 RGB pixels (3000 bytes) to the default output device, sent as
+	// three packets of at most 480 pixels. Only the last packet has the PUSH
+	// flag set, to display the complete frame.
+	{"frame 1/3", []byte{0x40, 0x01, 0x0b, 0x01, 0x00, 0x00, 0x00, 0x00, 0x05, 0xa0}, bytes.Repeat([]byte{0x10}, 1440),
+		Packet{Sequence: 1, DataType: DataTypeRGB24, ID: IDDisplay, Offset: 0}},
+	{"frame 2/3", []byte{0x40, 0x02, 0x0b, 0x01, 0x00, 0x00, 0x05, 0xa0, 0x05, 0xa0}, bytes.Repeat([]byte{0x20}, 1440),
+		Packet{Sequence: 2, DataType: DataTypeRGB24, ID: IDDisplay, Offset: 1440}},
+	{"frame 3/3", []byte{0x41, 0x03, 0x0b, 0x01, 0x00, 0x00, 0x0b, 0x40, 0x00, 0x78}, bytes.Repeat([]byte{0x30}, 120),
+		Packet{Flags: FlagPush, Sequence: 3, DataType: DataTypeRGB24, ID: IDDisplay, Offset: 2880}},
+	// 8-bit RGBW data, with the sequence number not used.
+	{"rgbw", []byte{0x41, 0x00, 0x1b, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x08}, []byte{0xff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xff},
+		Packet{Flags: FlagPush, DataType: DataTypeRGBW32, ID: IDDisplay}},
+	// A timecode (in 1/65536 seconds) follows the offset and length.
+	{"timecode", []byte{0x51, 0x05, 0x0b, 0xff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0x00, 0x01, 0x80, 0x00}, []byte{0x01, 0x02, 0x03},
+		Packet{Flags: FlagTimecode | FlagPush, Sequence: 5, DataType: DataTypeRGB24, ID: IDAll, Timecode: 0x00018000}},
+	// Status query, without data, and the JSON reply.
+	{"status query", []byte{0x42, 0x00, 0x00, 0xfb, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, []byte{},
+		Packet{Flags: FlagQuery, ID: IDStatus}},
+	{"status reply", []byte{0x44, 0x00, 0x00, 0xfb, 0x00, 0x00, 0x00, 0x00, 0x00, 0x25}, []byte(`{"status":{"man":"ledsgo","ver":"1"}}`),
+		Packet{Flags: FlagReply, ID: IDStatus}},
+}
+
+func TestPacket(t *testing.T) {
+	for _, tc := range specPackets {
+		buf := append(append([]byte(nil), tc.header...), tc.data...)
+		p, err := Parse(buf)
+		if err != nil {
+			t.Errorf("%s: could not parse packet: %v", tc.name, err)
+			continue
+		}
+		expected := tc.packet
+		expected.Data = tc.data
+		if p.Flags != expected.Flags || p.Sequence != expected.Sequence || p.DataType != expected.DataType || p.ID != expected.ID || p.Offset != expected.Offset || p.Timecode != expected.Timecode || !bytes.Equal(p.Data, expected.Data) {
+			t.Errorf("%s: unexpected packet: %+v", tc.name, p)
+		}
+		if encoded := expected.Append(nil); !bytes.Equal(encoded, buf) {
+			t.Errorf("%s: unexpected encoding\nexpected: % x\nactual:   % x", tc.name, tc.header, encoded[:len(tc.header)])
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, buf := range [][]byte{
+		{0x41, 0x01, 0x0b, 0x01, 0, 0, 0, 0, 0x00},                   // short header
+		{0x81, 0x01, 0x0b, 0x01, 0, 0, 0, 0, 0x00, 0x00},             // version 2
+		{0x41, 0x01, 0x0b, 0x01, 0, 0, 0, 0, 0x00, 0x03, 1, 2},       // data too short
+		{0x51, 0x01, 0x0b, 0x01, 0, 0, 0, 0, 0x00, 0x00, 0x00, 0x00}, // timecode too short
+	} {
+		if _, err := Parse(buf); err == nil {
+			t.Errorf("expected error for packet % x", buf)
+		}
+	}
+}
+
+func TestDataType(t *testing.T) {
+	if DataTypeRGB24.Kind() != KindRGB || DataTypeRGB24.BitsPerElement() != 8 || DataTypeRGB24.Custom() {
+		t.Error("unexpected fields for DataTypeRGB24")
+	}
+	if DataTypeRGBW32.Kind() != KindRGBW || DataTypeRGBW32.BitsPerElement() != 8 {
+		t.Error("unexpected fields for DataTypeRGBW32")
+	}
+	if DataType(0x8b).Custom() != true || DataType(0x0c).BitsPerElement() != 16 {
+		t.Error("unexpected fields for other data types")
+	}
+}
+
+// Connection that records written packets.
+type recordConn struct {
+	net.Conn
+	packets [][]byte
+}
+
+func (c *recordConn) Write(buf []byte) (int, error) {
+	c.packets = append(c.packets, append([]byte(nil), buf...))
+	return len(buf), nil
+}
+
+func TestSenderFragmentation(t *testing.T) {
+	strip := make(ledsgo.Strip, 1000)
+	for i := range strip {
+		strip[i] = color.RGBA{uint8(i), uint8(i >> 8), 0x55, 0xff}
+	}
+	conn := &recordConn{}
+	s := NewSender(conn)
+	if err := s.Send(strip); err != nil {
+		t.Fatal("could not send:", err)
+	}
+	// 3000 bytes: 1440 + 1440 + 120.
+	if len(conn.packets) != 3 {
+		t.Fatalf("expected 3 packets, got %d", len(conn.packets))
+	}
+	r := NewReceiver(nil, make(ledsgo.Strip, len(strip)))
+	frames := 0
+	r.OnFrame = func() {
+		frames++
+	}
+	for i, buf := range conn.packets {
+		p, err := Parse(buf)
+		if err != nil {
+			t.Fatal("could not parse packet:", err)
+		}
+		if header := specPackets[i].header; !bytes.Equal(buf[:len(header)], header) {
+			t.Errorf("packet %d differs from the specification\nexpected: % x\nactual:   % x", i, header, buf[:len(header)])
+		}
+		push := i == len(conn.packets)-1
+		if (p.Flags&FlagPush != 0) != push || p.Offset != uint32(i*MaxDataSize) || p.Sequence != uint8(i+1) {
+			t.Errorf("unexpected packet %d: flags=%#x offset=%d sequence=%d", i, p.Flags, p.Offset, p.Sequence)
+		}
+		if err := r.HandlePacket(buf); err != nil {
+			t.Error("could not handle packet:", err)
+		}
+	}
+	if frames != 1 {
+		t.Errorf("expected 1 frame, got %d", frames)
+	}
+	for i, c := range r.strip {
+		if c != strip[i] {
+			t.Fatalf("LED %d: expected %v, got %v", i, strip[i], c)
+		}
+	}
+
+	// Sequence numbers wrap from 15 to 1.
+	for i := 0; i < 4; i++ {
+		s.Send(strip)
+	}
+	p, _ := Parse(conn.packets[len(conn.packets)-1])
+	if p.Sequence != 15 {
+		t.Errorf("expected sequence 15, got %d", p.Sequence)
+	}
+	s.Send(strip)
+	p, _ = Parse(conn.packets[len(conn.packets)-3])
+	if p.Sequence != 1 {
+		t.Errorf("expected sequence 1 after wrap, got %d", p.Sequence)
+	}
+}
+
+func TestReceiver(t *testing.T) {
+	strip := make(ledsgo.Strip, 4)
+	r := NewReceiver(nil, strip)
+	frames := 0
+	r.OnFrame = func() {
+		frames++
+	}
+
+	// Without push flag, the strip isn't updated yet.
+	r.HandlePacket((&Packet{DataType: DataTypeRGB24, ID: IDDisplay, Offset: 3, Data: []byte{1, 2, 3}}).Append(nil))
+	if frames != 0 || strip[1] != (color.RGBA{}) {
+		t.Errorf("strip updated without push: %v", strip)
+	}
+	// Data that doesn't fit is ignored. Undefined data types are RGB.
+	r.HandlePacket((&Packet{Flags: FlagPush, ID: IDDisplay, Offset: 9, Data: []byte{4, 5, 6, 7, 8, 9}}).Append(nil))
+	expected := ledsgo.Strip{{0, 0, 0, 0xff}, {1, 2, 3, 0xff}, {0, 0, 0, 0xff}, {4, 5, 6, 0xff}}
+	if frames != 1 || !stripEqual(strip, expected) {
+		t.Errorf("unexpected strip: %v", strip)
+	}
+
+	// RGBW data.
+	r.HandlePacket((&Packet{Flags: FlagPush, DataType: DataTypeRGBW32, ID: IDAll, Data: []byte{10, 20, 30, 5}}).Append(nil))
+	if frames != 2 || strip[0] != (color.RGBA{15, 25, 35, 0xff}) {
+		t.Errorf("unexpected strip after RGBW data: %v", strip)
+	}
+
+	// Other destinations, queries and unsupported data types are ignored.
+	r.HandlePacket((&Packet{Flags: FlagPush, DataType: DataTypeRGB24, ID: IDControl, Data: []byte("{}")}).Append(nil))
+	r.HandlePacket((&Packet{Flags: FlagPush | FlagQuery, ID: IDDisplay}).Append(nil))
+	if err := r.HandlePacket((&Packet{Flags: FlagPush, DataType: KindHSL<<3 | 3, ID: IDDisplay, Data: []byte{1, 2, 3}}).Append(nil)); err == nil {
+		t.Error("expected error for HSL data")
+	}
+	if frames != 2 {
+		t.Errorf("unexpected frame count: %d", frames)
+	}
+}
+
+func stripEqual(a, b ledsgo.Strip) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLoopback(t *testing.T) {
+	strip := make(ledsgo.Strip, 600)
+	r, err := Listen("127.0.0.1:0", strip)
+	if err != nil {
+		t.Fatal("could not listen:", err)
+	}
+	frames := make(chan ledsgo.Strip, 1)
+	r.OnFrame = func() {
+		frames <- append(ledsgo.Strip(nil), strip...)
+	}
+	done := make(chan error)
+	go func() {
+		done <- r.Serve()
+	}()
+
+	s, err := Dial(r.Addr().String())
+	if err != nil {
+		t.Fatal("could not dial:", err)
+	}
+	defer s.Close()
+	s.RGBW = true
+	sent := make(ledsgo.Strip, len(strip))
+	for i := range sent {
+		sent[i] = color.RGBA{uint8(i), 0x40, uint8(i >> 2), 0xff}
+	}
+	if err := s.Send(sent); err != nil {
+		t.Fatal("could not send:", err)
+	}
+
+	select {
+	case frame := <-frames:
+		if !stripEqual(frame, sent) {
+			t.Errorf("received frame differs from sent frame")
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("timeout waiting for frame")
+	}
+
+	r.Close()
+	if err := <-done; err != nil {
+		t.Error("Serve returned an error:", err)
+	}
+}
@@ -0,0 +1,174 @@
+// Package ddp implements the Distributed Display Protocol (DDP), which is
+// used by WLED, xLights and others to send pixel data over UDP. Unlike DMX
+// based protocols, DDP is not limited to 512 channels per universe: a frame is
+// a single block of pixel data that is split over as many packets as needed.
+// It contains a packet encoder and decoder, a sender that streams a strip and
+// a receiver that reassembles packets into a strip.
+//
+// See http://www.3waylabs.com/ddp/ for the specification.
+package ddp
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Port is the UDP port used by DDP.
+const Port = 4048
+
+// MaxDataSize is the maximum amount of pixel data in a single packet, as
+// recommended by the specification. It is a multiple of both 3 and 4, so that
+// RGB and RGBW pixels are never split over two packets.
+const MaxDataSize = 1440
+
+// Flags in the first header byte. The version bits are handled by Parse and
+// Append and are not part of Packet.Flags.
+const (
+	FlagPush     = 0x01 // the last packet of a frame: display the data
+	FlagQuery    = 0x02 // request data from the destination
+	FlagReply    = 0x04 // a reply to a query
+	FlagStorage  = 0x08 // select or save storage (not supported)
+	FlagTimecode = 0x10 // the header contains a timecode
+)
+
+// Version bits in the first header byte.
+const (
+	versionMask = 0xc0
+	version1    = 0x40
+)
+
+// Sizes of the header, without and with a timecode.
+const (
+	headerSize         = 10
+	headerSizeTimecode = 14
+)
+
+// Destination (or source) IDs.
+const (
+	IDDisplay = 1   // the default output device
+	IDControl = 246 // JSON control
+	IDConfig  = 250 // JSON configuration
+	IDStatus  = 251 // JSON status
+	IDDMX     = 254 // DMX transit
+	IDAll     = 255 // all devices
+)
+
+// DataType describes the pixel format of the data in a packet. It consists of
+// a kind (RGB, HSL, etc) and the number of bits per pixel element.
+type DataType uint8
+
+// Kinds of data, see DataType.Kind.
+const (
+	KindUndefined = 0
+	KindRGB       = 1
+	KindHSL       = 2
+	KindRGBW      = 3
+	KindGrayscale = 4
+)
+
+// Commonly used data types.
+const (
+	DataTypeUndefined DataType = 0x00
+	DataTypeRGB24     DataType = KindRGB<<3 | 3  // 8-bit RGB
+	DataTypeRGBW32    DataType = KindRGBW<<3 | 3 // 8-bit RGBW
+)
+
+// Custom returns whether this is a customer defined data type, in which case
+// the other fields have no defined meaning.
+func (t DataType) Custom() bool {
+	return t&0x80 != 0
+}
+
+// Kind returns the kind of data, such as KindRGB.
+func (t DataType) Kind() uint8 {
+	return uint8(t>>3) & 7
+}
+
+// BitsPerElement returns the number of bits per pixel element (for example,
+// per color channel), or 0 if it is undefined.
+func (t DataType) BitsPerElement() int {
+	switch t & 7 {
+	case 1:
+		return 1
+	case 2:
+		return 4
+	case 3:
+		return 8
+	case 4:
+		return 16
+	case 5:
+		return 24
+	case 6:
+		return 32
+	default:
+		return 0
+	}
+}
+
+var (
+	errShortPacket   = errors.New("ddp: packet too short")
+	errInvalidVer    = errors.New("ddp: unsupported protocol version")
+	errInvalidLength = errors.New("ddp: invalid data length")
+)
+
+// Packet is a single DDP packet.
+type Packet struct {
+	Flags    uint8 // FlagPush etc, without the version bits
+	Sequence uint8 // 1-15, or 0 if not used
+	DataType DataType
+	ID       uint8  // destination or source ID, such as IDDisplay
+	Offset   uint32 // offset of the data in bytes
+	Timecode uint32 // only sent when FlagTimecode is set
+	Data     []byte
+}
+
+// Parse parses a DDP packet. The data of the packet refers to buf, so buf must
+// not be modified while the packet is in use.
+func Parse(buf []byte) (*Packet, error) {
+	if len(buf) < headerSize {
+		return nil, errShortPacket
+	}
+	if buf[0]&versionMask != version1 {
+		return nil, errInvalidVer
+	}
+	p := &Packet{
+		Flags:    buf[0] &^ versionMask,
+		Sequence: buf[1] & 0x0f,
+		DataType: DataType(buf[2]),
+		ID:       buf[3],
+		Offset:   binary.BigEndian.Uint32(buf[4:]),
+	}
+	length := int(binary.BigEndian.Uint16(buf[8:]))
+	size := headerSize
+	if p.Flags&FlagTimecode != 0 {
+		if len(buf) < headerSizeTimecode {
+			return nil, errShortPacket
+		}
+		p.Timecode = binary.BigEndian.Uint32(buf[10:])
+		size = headerSizeTimecode
+	}
+	if size+length > len(buf) {
+		return nil, errInvalidLength
+	}
+	p.Data = buf[size : size+length]
+	return p, nil
+}
+
+// Append appends the binary encoding of the packet to buf and returns the
+// extended buffer. The data must not be longer than 65535 bytes.
+func (p *Packet) Append(buf []byte) []byte {
+	var header [headerSizeTimecode]byte
+	header[0] = version1 | p.Flags&^versionMask
+	header[1] = p.Sequence & 0x0f
+	header[2] = uint8(p.DataType)
+	header[3] = p.ID
+	binary.BigEndian.PutUint32(header[4:], p.Offset)
+	binary.BigEndian.PutUint16(header[8:], uint16(len(p.Data)))
+	size := headerSize
+	if p.Flags&FlagTimecode != 0 {
+		binary.BigEndian.PutUint32(header[10:], p.Timecode)
+		size = headerSizeTimecode
+	}
+	buf = append(buf, header[:size]...)
+	return append(buf, p.Data...)
+}
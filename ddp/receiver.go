@@ -0,0 +1,114 @@
+package ddp
+
+import (
+	"errors"
+	"net"
+
+	"github.com/aykevl/ledsgo"
+)
+
+var errUnsupportedType = errors.New("ddp: unsupported data type")
+
+// Receiver receives DDP frames into a strip. The data of all packets of a
+// frame is collected, and copied into the strip when a packet with the push
+// flag arrives. LEDs that are not part of a frame keep their previous value,
+// and data that doesn't fit in the strip is ignored.
+//
+// Only 8-bit RGB and RGBW data is supported. Packets with an undefined data
+// type (as sent by some older software) are treated as RGB.
+type Receiver struct {
+	// OnFrame is called when a frame is complete, with the strip updated. It
+	// is called from the goroutine running Serve, so the strip is not
+	// modified while it runs.
+	OnFrame func()
+
+	conn  net.PacketConn
+	strip ledsgo.Strip
+	frame []byte // data received since the last push, 4 bytes per LED
+}
+
+// NewReceiver creates a new receiver that reads packets from the given
+// connection.
+func NewReceiver(conn net.PacketConn, strip ledsgo.Strip) *Receiver {
+	return &Receiver{
+		conn:  conn,
+		strip: strip,
+		frame: make([]byte, len(strip)*4),
+	}
+}
+
+// Listen creates a new receiver listening on the given UDP address, for
+// example ":4048" to listen on all interfaces.
+func Listen(address string, strip ledsgo.Strip) (*Receiver, error) {
+	conn, err := net.ListenPacket("udp", address)
+	if err != nil {
+		return nil, err
+	}
+	return NewReceiver(conn, strip), nil
+}
+
+// Addr returns the local address of the receiver.
+func (r *Receiver) Addr() net.Addr {
+	return r.conn.LocalAddr()
+}
+
+// Serve receives packets until the receiver is closed. Invalid packets are
+// ignored. It returns nil after Close is called.
+func (r *Receiver) Serve() error {
+	buf := make([]byte, 1500)
+	for {
+		n, _, err := r.conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		r.HandlePacket(buf[:n])
+	}
+}
+
+// Close stops the receiver and closes the underlying connection.
+func (r *Receiver) Close() error {
+	return r.conn.Close()
+}
+
+// HandlePacket processes a single DDP packet. This is normally done by Serve,
+// but can be used directly if the packets are received in another way. It
+// must not be called concurrently with Serve.
+func (r *Receiver) HandlePacket(buf []byte) error {
+	packet, err := Parse(buf)
+	if err != nil {
+		return err
+	}
+	if packet.Flags&(FlagQuery|FlagReply|FlagStorage) != 0 {
+		return nil // not pixel data
+	}
+	if packet.ID != IDDisplay && packet.ID != IDAll {
+		return nil // another destination, such as JSON control
+	}
+	var order ledsgo.ColorOrder
+	switch packet.DataType {
+	case DataTypeRGB24, DataTypeUndefined:
+		order = ledsgo.RGB
+	case DataTypeRGBW32:
+		order = ledsgo.RGBW
+	default:
+		return errUnsupportedType
+	}
+	channels := order.Channels()
+
+	if offset := uint64(packet.Offset); offset < uint64(len(r.strip)*channels) {
+		copy(r.frame[offset:len(r.strip)*channels], packet.Data)
+	}
+	if packet.Flags&FlagPush == 0 {
+		return nil
+	}
+	for i := range r.strip {
+		r.strip[i] = order.Get(r.frame[i*channels:])
+	}
+	if r.OnFrame != nil {
+		r.OnFrame()
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package ddp
+
+import (
+	"net"
+
+	"github.com/aykevl/ledsgo"
+)
+
+// Sender sends strips as DDP frames. Frames that don't fit in a single packet
+// are split over multiple packets, with the push flag set on the last one.
+type Sender struct {
+	// RGBW sends 4 bytes per LED (RGBW) instead of 3 (RGB). The white channel
+	// is extracted from the color, see ledsgo.ExtractWhite.
+	RGBW bool
+
+	conn     net.Conn
+	sequence uint8
+	data     []byte
+	buf      []byte
+}
+
+// NewSender creates a new sender that sends packets over the given
+// connection.
+func NewSender(conn net.Conn) *Sender {
+	return &Sender{conn: conn}
+}
+
+// Dial creates a new sender that sends packets to the given UDP address, for
+// example "192.168.1.10:4048".
+func Dial(address string) (*Sender, error) {
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, err
+	}
+	return NewSender(conn), nil
+}
+
+// Send sends the strip as a single frame.
+func (s *Sender) Send(strip ledsgo.Strip) error {
+	order := ledsgo.RGB
+	dataType := DataTypeRGB24
+	if s.RGBW {
+		order = ledsgo.RGBW
+		dataType = DataTypeRGBW32
+	}
+	s.data = strip.AppendBytes(s.data[:0], order)
+	offset := 0
+	for {
+		chunk := s.data[offset:]
+		var flags uint8
+		if len(chunk) > MaxDataSize {
+			chunk = chunk[:MaxDataSize]
+		} else {
+			flags = FlagPush
+		}
+		// Sequence numbers run from 1 to 15, 0 means they are not used.
+		s.sequence = s.sequence%15 + 1
+		packet := Packet{
+			Flags:    flags,
+			Sequence: s.sequence,
+			DataType: dataType,
+			ID:       IDDisplay,
+			Offset:   uint32(offset),
+			Data:     chunk,
+		}
+		s.buf = packet.Append(s.buf[:0])
+		if _, err := s.conn.Write(s.buf); err != nil {
+			return err
+		}
+		offset += len(chunk)
+		if flags&FlagPush != 0 {
+			return nil
+		}
+	}
+}
+
+// Close closes the underlying connection.
+func (s *Sender) Close() error {
+	return s.conn.Close()
+}
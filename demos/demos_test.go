@@ -0,0 +1,48 @@
+package demos_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aykevl/ledsgo/demos"
+	"github.com/aykevl/ledsgo/demos/demotest"
+)
+
+// Sizes to render every demo at. They are not square, to catch mixups between
+// the width and the height.
+var goldenSizes = [][2]int16{
+	{12, 8},
+	{8, 12},
+}
+
+func TestGolden(t *testing.T) {
+	times := demotest.Times(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), 250*time.Millisecond, 4)
+	for _, name := range demos.AnimationNames() {
+		for _, size := range goldenSizes {
+			name, size := name, size
+			t.Run(fmt.Sprintf("%s-%dx%d", name, size[0], size[1]), func(t *testing.T) {
+				r := demotest.Render(demos.Animations[name], size[0], size[1], times)
+				if r.OutOfBounds != 0 {
+					t.Errorf("%d pixels were drawn outside of the display", r.OutOfBounds)
+				}
+				path := filepath.Join("testdata", fmt.Sprintf("%s-%dx%d.png", name, size[0], size[1]))
+				demotest.CheckGolden(t, path, r.Frames, 0)
+			})
+		}
+	}
+}
+
+func TestAnimation(t *testing.T) {
+	if names := demos.AnimationNames(); fmt.Sprint(names) != "[fire noise warp]" {
+		t.Errorf("unexpected animation names: %v", names)
+	}
+	if animation, err := demos.Animation("warp"); err != nil || animation == nil {
+		t.Errorf("could not find the warp animation: %v", err)
+	}
+	_, err := demos.Animation("nope")
+	if err == nil || err.Error() != `demos: unknown animation "nope", choose one of: fire, noise, warp` {
+		t.Errorf("unexpected error for an unknown animation: %v", err)
+	}
+}
@@ -0,0 +1,209 @@
+// Package demotest contains helpers for golden image tests of animations. An
+// animation is rendered at fixed times on a Recorder, and the frames are
+// compared against a golden PNG image in the testdata directory.
+//
+// Run the tests with the -update flag to write new golden images, after
+// checking that a change in the output is intended:
+//
+//	go test ./demos -update
+package demotest
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aykevl/ledsgo/demos"
+)
+
+var update = flag.Bool("update", false, "update golden images")
+
+// Recorder is a demos.Displayer that records every frame. A frame is recorded
+// on every call to Display. It also counts the pixels that are drawn outside
+// of the display, which usually indicates a bug in the animation.
+type Recorder struct {
+	Frames      []*image.RGBA
+	OutOfBounds int
+
+	frame *image.RGBA
+}
+
+// NewRecorder returns a new recorder for a display of the given size.
+func NewRecorder(width, height int16) *Recorder {
+	return &Recorder{
+		frame: image.NewRGBA(image.Rect(0, 0, int(width), int(height))),
+	}
+}
+
+// Size returns the display size.
+func (r *Recorder) Size() (width, height int16) {
+	size := r.frame.Bounds().Size()
+	return int16(size.X), int16(size.Y)
+}
+
+// SetPixel sets a pixel in the current frame. The alpha channel is ignored,
+// like on a real display.
+func (r *Recorder) SetPixel(x, y int16, c color.RGBA) {
+	if !(image.Point{int(x), int(y)}.In(r.frame.Bounds())) {
+		r.OutOfBounds++
+		return
+	}
+	c.A = 0xff
+	r.frame.SetRGBA(int(x), int(y), c)
+}
+
+// Display records the current frame. The next frame starts with the content
+// of the current frame, like on a real display.
+func (r *Recorder) Display() error {
+	frame := image.NewRGBA(r.frame.Bounds())
+	copy(frame.Pix, r.frame.Pix)
+	r.Frames = append(r.Frames, frame)
+	return nil
+}
+
+// Times returns n times, starting at start and separated by interval.
+func Times(start time.Time, interval time.Duration, n int) []time.Time {
+	times := make([]time.Time, n)
+	for i := range times {
+		times[i] = start.Add(interval * time.Duration(i))
+	}
+	return times
+}
+
+// Render draws the animation at each of the given times on a recorder of the
+// given size, and returns the recorder with one frame per time.
+func Render(animation func(demos.Displayer, time.Time), width, height int16, times []time.Time) *Recorder {
+	r := NewRecorder(width, height)
+	for _, t := range times {
+		animation(r, t)
+		r.Display()
+	}
+	return r
+}
+
+// CheckGolden compares the frames against the golden image at path, which
+// contains all frames below each other. Every color channel may differ by at
+// most tolerance. On a mismatch, the test fails and an image that marks the
+// differences in red is written next to the golden image, with the extension
+// .diff.png. With the -update flag, the golden image is written instead.
+func CheckGolden(t testing.TB, path string, frames []*image.RGBA, tolerance uint8) {
+	t.Helper()
+	if len(frames) == 0 {
+		t.Fatal("no frames to compare")
+	}
+	got := stack(frames)
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal("could not create golden image directory:", err)
+		}
+		if err := writePNG(path, got); err != nil {
+			t.Fatal("could not write golden image:", err)
+		}
+		return
+	}
+
+	want, err := readPNG(path)
+	if err != nil {
+		t.Fatalf("could not read golden image (run with -update to create it): %v", err)
+	}
+	if want.Bounds() != got.Bounds() {
+		t.Fatalf("%s: expected image size %v, got %v (run with -update if this is intended)", path, want.Bounds().Size(), got.Bounds().Size())
+	}
+	diff, mismatches, first := compare(want, got, tolerance)
+	if mismatches == 0 {
+		return
+	}
+	diffPath := strings.TrimSuffix(path, filepath.Ext(path)) + ".diff.png"
+	if err := writePNG(diffPath, diff); err != nil {
+		t.Errorf("could not write diff image: %v", err)
+	}
+	height := frames[0].Bounds().Dy()
+	t.Errorf("%s: %d pixels differ, the first in frame %d at (%d, %d): expected %v, got %v (see %s)",
+		path, mismatches, first.Y/height, first.X, first.Y%height,
+		want.RGBAAt(first.X, first.Y), got.RGBAAt(first.X, first.Y), diffPath)
+}
+
+// Compare two images of the same size. It returns an image that shows the
+// expected image dimmed, with mismatching pixels in bright red, the number of
+// mismatching pixels and the position of the first mismatch.
+func compare(want, got *image.RGBA, tolerance uint8) (*image.RGBA, int, image.Point) {
+	bounds := want.Bounds()
+	diff := image.NewRGBA(bounds)
+	mismatches := 0
+	var first image.Point
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			w, g := want.RGBAAt(x, y), got.RGBAAt(x, y)
+			if differs(w.R, g.R, tolerance) || differs(w.G, g.G, tolerance) || differs(w.B, g.B, tolerance) || differs(w.A, g.A, tolerance) {
+				if mismatches == 0 {
+					first = image.Pt(x, y)
+				}
+				mismatches++
+				diff.SetRGBA(x, y, color.RGBA{255, 0, 0, 255})
+			} else {
+				diff.SetRGBA(x, y, color.RGBA{w.R / 4, w.G / 4, w.B / 4, 255})
+			}
+		}
+	}
+	return diff, mismatches, first
+}
+
+func differs(a, b, tolerance uint8) bool {
+	if a > b {
+		return a-b > tolerance
+	}
+	return b-a > tolerance
+}
+
+// Return a single image with all frames below each other.
+func stack(frames []*image.RGBA) *image.RGBA {
+	size := frames[0].Bounds().Size()
+	img := image.NewRGBA(image.Rect(0, 0, size.X, size.Y*len(frames)))
+	for i, frame := range frames {
+		copy(img.Pix[i*len(frame.Pix):], frame.Pix)
+	}
+	return img
+}
+
+func readPNG(path string) (*image.RGBA, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+	if rgba, ok := img.(*image.RGBA); ok {
+		return rgba, nil
+	}
+	// The PNG encoder may have stored the image in a more compact format.
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			rgba.Set(x, y, img.At(x, y))
+		}
+	}
+	return rgba, nil
+}
+
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	return f.Close()
+}
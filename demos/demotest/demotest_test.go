@@ -0,0 +1,55 @@
+package demotest
+
+import (
+	"image"
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/aykevl/ledsgo/demos"
+)
+
+func TestRender(t *testing.T) {
+	var drawn []time.Time
+	animation := func(display demos.Displayer, now time.Time) {
+		width, height := display.Size()
+		display.SetPixel(width-1, height-1, color.RGBA{uint8(len(drawn)), 2, 3, 0})
+		display.SetPixel(width, 0, color.RGBA{}) // out of bounds
+		drawn = append(drawn, now)
+	}
+	start := time.Unix(1000, 0)
+	r := Render(animation, 3, 2, Times(start, time.Second, 3))
+	if len(r.Frames) != 3 || r.OutOfBounds != 3 {
+		t.Fatalf("expected 3 frames and 3 pixels out of bounds, got %d and %d", len(r.Frames), r.OutOfBounds)
+	}
+	if !drawn[2].Equal(start.Add(2 * time.Second)) {
+		t.Errorf("unexpected time of last frame: %v", drawn[2])
+	}
+	if c := r.Frames[1].RGBAAt(2, 1); c != (color.RGBA{1, 2, 3, 255}) {
+		t.Errorf("unexpected color in frame 1: %v", c)
+	}
+	if c := r.Frames[1].RGBAAt(0, 0); c != (color.RGBA{}) {
+		t.Errorf("unexpected color of pixel that wasn't drawn: %v", c)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	want := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	got := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	want.SetRGBA(0, 0, color.RGBA{100, 100, 100, 255})
+	got.SetRGBA(0, 0, color.RGBA{102, 100, 99, 255})
+	got.SetRGBA(1, 1, color.RGBA{0, 0, 3, 0})
+	if _, mismatches, _ := compare(want, got, 2); mismatches != 1 {
+		t.Errorf("tolerance 2: expected 1 mismatch, got %d", mismatches)
+	}
+	diff, mismatches, first := compare(want, got, 0)
+	if mismatches != 2 || first != image.Pt(0, 0) {
+		t.Errorf("tolerance 0: expected 2 mismatches starting at (0, 0), got %d at %v", mismatches, first)
+	}
+	if c := diff.RGBAAt(1, 1); c != (color.RGBA{255, 0, 0, 255}) {
+		t.Errorf("mismatch not marked in diff image: %v", c)
+	}
+	if c := diff.RGBAAt(1, 0); c != (color.RGBA{0, 0, 0, 255}) {
+		t.Errorf("unexpected color of matching pixel in diff image: %v", c)
+	}
+}
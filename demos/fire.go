@@ -16,8 +16,8 @@ func Fire(display Displayer, now time.Time) {
 	var cooling = 256 / height // higher means faster cooling
 	var detail = 12800 / width // higher means more detailed flames
 	for x := int16(0); x < width; x++ {
-		for y := int16(0); y < width; y++ {
-			heat := ledsgo.Noise2(int32(y*detail)+int32((now.UnixNano()>>20)*speed), int32(x*detail))/256 + 128
+		for y := int16(0); y < height; y++ {
+			heat := int16(ledsgo.Noise2(uint32(y*detail)+uint32((now.UnixNano()>>20)*speed), uint32(x*detail)) / 256)
 			heat -= int16((height-1)-y) * cooling
 			if heat < 0 {
 				heat = 0
@@ -14,8 +14,8 @@ func Noise(display Displayer, now time.Time) {
 	width, height := display.Size()
 	for x := int16(0); x < width; x++ {
 		for y := int16(0); y < height; y++ {
-			hue := uint16(ledsgo.Noise3(int32(now.UnixNano()>>speed), int32(x<<spread), int32(y<<spread))) * 2
-			display.SetPixel(x, y, ledsgo.Color{hue, 0xff, 0xff}.Spectrum())
+			hue := ledsgo.EqualizeNoise3(ledsgo.Noise3(uint32(now.UnixNano()>>speed), uint32(x<<spread), uint32(y<<spread)))
+			display.SetPixel(x, y, ledsgo.Color{H: hue, S: 0xff, V: 0xff}.Spectrum())
 		}
 	}
 }
@@ -1,9 +1,18 @@
 // Package patterns implements a number of animations for small LED displays.
 package demos
 
-//go:generate go run generate.go ./images
+//go:generate go run ../cmd/ledsgo-render -o images/fire.png fire
+//go:generate go run ../cmd/ledsgo-render -o images/noise.png noise
+//go:generate go run ../cmd/ledsgo-render -o images/warp.png warp
 
-import "image/color"
+import (
+	"errors"
+	"image/color"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Displayer is a surface that can be drawn upon.
 type Displayer interface {
@@ -13,3 +22,32 @@ type Displayer interface {
 	// SetPizel modifies the internal buffer.
 	SetPixel(x, y int16, c color.RGBA)
 }
+
+// Animations contains the animations in this package by name, so that tools
+// such as ledsgo-render can select an animation by name. Other packages may
+// register their own animations here.
+var Animations = map[string]func(display Displayer, now time.Time){
+	"fire":  Fire,
+	"noise": Noise,
+	"warp":  Warp,
+}
+
+// AnimationNames returns the names of all animations in Animations, sorted.
+func AnimationNames() []string {
+	var names []string
+	for name := range Animations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Animation returns the animation with the given name from Animations. If
+// there is no such animation, the error lists the available animations.
+func Animation(name string) (func(display Displayer, now time.Time), error) {
+	animation, ok := Animations[name]
+	if !ok {
+		return nil, errors.New("demos: unknown animation " + strconv.Quote(name) + ", choose one of: " + strings.Join(AnimationNames(), ", "))
+	}
+	return animation, nil
+}
@@ -0,0 +1,25 @@
+package demos
+
+import (
+	"time"
+
+	"github.com/aykevl/ledsgo"
+)
+
+// Warp shows domain-warped noise mapped to the lava color palette, which looks
+// somewhat like the blobs in a lava lamp. The 'now' time indicates which
+// instance of the animation is generated.
+func Warp(display Displayer, now time.Time) {
+	const spread = 6       // higher means the pattern gets more detailed
+	const speed = 20       // higher means slower
+	const strength = 0x100 // 8.8: how strongly the noise is warped
+	const octaves = 2      // more octaves means more detail, but slower
+	width, height := display.Size()
+	z := uint32(now.UnixNano() >> speed)
+	for x := int16(0); x < width; x++ {
+		for y := int16(0); y < height; y++ {
+			n := ledsgo.WarpedNoise3(uint32(x)<<spread, uint32(y)<<spread, z, strength, octaves)
+			display.SetPixel(x, y, ledsgo.LavaColors.ColorAt(n))
+		}
+	}
+}
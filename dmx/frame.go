@@ -0,0 +1,218 @@
+package dmx
+
+import (
+	"io"
+)
+
+// Commonly used start codes. Only frames with the null start code contain
+// dimmer (LED) data.
+const (
+	StartCodeNull = 0x00 // dimmer data
+	StartCodeText = 0x17 // ASCII text
+	StartCodeRDM  = 0xcc // Remote Device Management
+	StartCodeSIP  = 0xcf // System Information Packet
+)
+
+// Frame is a single DMX512 frame, as sent over RS-485: a start code followed
+// by up to 512 slots (channels). On the line, every frame is preceded by a
+// break and a mark-after-break, which must be generated by the UART driver.
+type Frame struct {
+	StartCode uint8
+	Length    int // number of slots in use (0-512)
+	Slots     [UniverseSize]byte
+}
+
+// Data returns the slots in use. Slot 1 (DMX channel 1) is at index 0.
+func (f *Frame) Data() []byte {
+	return f.Slots[:f.Length]
+}
+
+// Append appends the start code and slots of the frame to buf, and returns the
+// extended buffer. These are the bytes to send after the break and
+// mark-after-break, at 250kbaud with 8 data bits and 2 stop bits.
+func (f *Frame) Append(buf []byte) []byte {
+	buf = append(buf, f.StartCode)
+	return append(buf, f.Data()...)
+}
+
+// Pack copies the colors of the strip of the mapping into the slots of the
+// frame, and extends the frame to include all mapped slots. The universe of
+// the mapping is ignored.
+func (f *Frame) Pack(m *Mapping) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	m.Fill(f.Slots[:])
+	if end := m.End(); end > f.Length {
+		f.Length = end
+	}
+	return nil
+}
+
+// Unpack copies the colors in the slots of the frame into the strip of the
+// mapping. LEDs that are not (fully) in the frame are left unmodified. The
+// universe of the mapping is ignored.
+func (f *Frame) Unpack(m *Mapping) {
+	m.Apply(f.Data())
+}
+
+// DecoderStats contains statistics of a Decoder.
+type DecoderStats struct {
+	Frames       int // complete frames
+	ShortFrames  int // frames with fewer slots than MinSlots
+	Overruns     int // frames with more than 512 slots
+	Errors       int // frames aborted by a framing error
+	SkippedBytes int // bytes received outside of a frame
+}
+
+// States of the decoder.
+const (
+	decoderIdle      = iota // waiting for a break
+	decoderStartCode        // break received, waiting for the start code
+	decoderSlots            // receiving slots
+	decoderFinished         // received all 512 slots, waiting for a break
+)
+
+// Decoder decodes a stream of bytes received from an RS-485 line into frames.
+// As DMX512 frames don't have a length field, a frame is complete when the
+// next break is received or when it contains 512 slots.
+//
+// The UART driver must report breaks by calling Break, and received bytes by
+// calling Write or WriteByte. On Linux, a serial port with the PARMRK flag set
+// reports breaks and framing errors within the byte stream; such a stream can
+// be decoded with ReadMarked.
+type Decoder struct {
+	// OnFrame is called for every complete frame, with any start code. The
+	// frame is only valid until OnFrame returns.
+	OnFrame func(frame *Frame)
+
+	// MinSlots is the minimum number of slots in a frame, for example the
+	// number of slots used by the LEDs. Shorter frames are dropped.
+	MinSlots int
+
+	frame  Frame
+	state  uint8
+	marked uint8 // number of PARMRK escape bytes received (see ReadMarked)
+	stats  DecoderStats
+}
+
+// Stats returns the statistics since the decoder was created.
+func (d *Decoder) Stats() DecoderStats {
+	return d.stats
+}
+
+// Break signals a break on the line: the end of the previous frame (if any)
+// and the start of the next frame.
+func (d *Decoder) Break() {
+	if d.state == decoderSlots {
+		d.finish()
+	}
+	d.state = decoderStartCode
+}
+
+// FramingError signals that a byte was received with a framing error that is
+// not a break. The frame that is being received is dropped.
+func (d *Decoder) FramingError() {
+	if d.state == decoderStartCode || d.state == decoderSlots {
+		d.stats.Errors++
+	}
+	d.state = decoderIdle
+}
+
+// WriteByte processes a single received byte. It always returns nil.
+func (d *Decoder) WriteByte(b byte) error {
+	switch d.state {
+	case decoderIdle:
+		d.stats.SkippedBytes++
+	case decoderStartCode:
+		d.frame.StartCode = b
+		d.frame.Length = 0
+		d.state = decoderSlots
+	case decoderSlots:
+		d.frame.Slots[d.frame.Length] = b
+		d.frame.Length++
+		if d.frame.Length == len(d.frame.Slots) {
+			// This is the last possible slot, so don't wait for the next
+			// break to finish the frame.
+			d.finish()
+			d.state = decoderFinished
+		}
+	case decoderFinished:
+		// Too many slots. The frame has already been finished, wait for the
+		// next break.
+		d.stats.Overruns++
+		d.stats.SkippedBytes++
+		d.state = decoderIdle
+	}
+	return nil
+}
+
+// Write processes received bytes. It always returns len(p), nil.
+func (d *Decoder) Write(p []byte) (int, error) {
+	for _, b := range p {
+		d.WriteByte(b)
+	}
+	return len(p), nil
+}
+
+// Call OnFrame for the received frame, if it is valid.
+func (d *Decoder) finish() {
+	if d.frame.Length < d.MinSlots {
+		d.stats.ShortFrames++
+		return
+	}
+	d.stats.Frames++
+	if d.OnFrame != nil {
+		d.OnFrame(&d.frame)
+	}
+}
+
+// ReadMarked reads from r until it returns an error, decoding the byte stream
+// of a Linux serial port that has the PARMRK and INPCK termios flags set (and
+// IGNBRK, BRKINT and ISTRIP cleared). In this mode a break is received as
+// 0xff 0x00 0x00, a framing error as 0xff 0x00 followed by the received byte,
+// and a 0xff data byte as 0xff 0xff. It returns nil at the end of the stream.
+func (d *Decoder) ReadMarked(r io.Reader) error {
+	var buf [64]byte
+	for {
+		n, err := r.Read(buf[:])
+		for _, b := range buf[:n] {
+			d.writeMarked(b)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Process a single byte of a stream with PARMRK escapes.
+func (d *Decoder) writeMarked(b byte) {
+	switch d.marked {
+	case 0:
+		if b == 0xff {
+			d.marked = 1
+			return
+		}
+		d.WriteByte(b)
+	case 1:
+		if b == 0xff {
+			// Escaped 0xff data byte.
+			d.marked = 0
+			d.WriteByte(b)
+			return
+		}
+		// 0xff 0x00: a break or framing error follows. Other values should
+		// not occur.
+		d.marked = 2
+	case 2:
+		d.marked = 0
+		if b == 0 {
+			d.Break()
+		} else {
+			d.FramingError()
+		}
+	}
+}
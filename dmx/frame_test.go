@@ -0,0 +1,166 @@
+package dmx
+
+import (
+	"bytes"
+	"image/color"
+	"testing"
+
+	"github.com/aykevl/ledsgo"
+)
+
+func TestFootprint(t *testing.T) {
+	strip := ledsgo.Strip{{1, 2, 3, 0xff}, {4, 5, 6, 0xff}}
+	m := Mapping{Channel: 10, Strip: strip, Length: 2, Order: ledsgo.RGBW, Footprint: 6}
+	if err := m.Validate(); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if m.End() != 21 {
+		t.Errorf("unexpected end: %d", m.End())
+	}
+	data := make([]byte, UniverseSize)
+	for i := range data {
+		data[i] = 0xaa
+	}
+	m.Fill(data)
+	expected := []byte{0xaa, 0, 1, 2, 1, 0xaa, 0xaa, 0, 1, 2, 4, 0xaa, 0xaa, 0xaa}
+	if !bytes.Equal(data[8:22], expected) {
+		t.Errorf("unexpected data\nexpected: % x\nactual:   % x", expected, data[8:22])
+	}
+
+	result := make(ledsgo.Strip, 2)
+	m.Strip = result
+	m.Apply(data)
+	if result[0] != strip[0] || result[1] != strip[1] {
+		t.Errorf("unexpected strip after Apply: %v", result)
+	}
+
+	m.Footprint = 3 // smaller than RGBW
+	if m.Validate() == nil {
+		t.Error("expected error for a footprint that is too small")
+	}
+	m.Footprint = 10
+	m.Channel = 495
+	if m.Validate() == nil {
+		t.Error("expected error for a mapping that doesn't fit")
+	}
+}
+
+func TestFrame(t *testing.T) {
+	var frame Frame
+	strip := ledsgo.Strip{{1, 2, 3, 0xff}, {4, 5, 6, 0xff}}
+	if err := frame.Pack(&Mapping{Channel: 3, Strip: strip, Length: 2, Order: ledsgo.GRB}); err != nil {
+		t.Fatal("could not pack strip:", err)
+	}
+	buf := frame.Append(nil)
+	expected := []byte{StartCodeNull, 0, 0, 2, 1, 3, 5, 4, 6}
+	if !bytes.Equal(buf, expected) {
+		t.Errorf("unexpected frame\nexpected: % x\nactual:   % x", expected, buf)
+	}
+	if err := frame.Pack(&Mapping{Channel: 512, Strip: strip, Length: 1}); err == nil {
+		t.Error("expected error for a mapping that doesn't fit")
+	}
+
+	result := make(ledsgo.Strip, 3)
+	frame.Unpack(&Mapping{Channel: 3, Strip: result, Length: 3, Order: ledsgo.GRB})
+	if result[0] != strip[0] || result[1] != strip[1] || result[2] != (color.RGBA{}) {
+		t.Errorf("unexpected strip after Unpack: %v", result)
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	var frames []Frame
+	d := &Decoder{
+		MinSlots: 3,
+		OnFrame: func(frame *Frame) {
+			frames = append(frames, *frame)
+		},
+	}
+
+	d.Write([]byte{1, 2, 3}) // before the first break
+	d.Break()
+	d.Write([]byte{StartCodeNull, 10, 20, 30, 40})
+	d.Break()
+	d.Write([]byte{StartCodeNull, 10}) // too short
+	d.Break()
+	d.Break() // break without data
+	d.Write([]byte{StartCodeRDM, 1, 2, 3, 4})
+	d.FramingError()
+	d.Write([]byte{5})
+	d.Break()
+	full := make([]byte, 1+UniverseSize+2) // 2 slots too many
+	full[1] = 0xff
+	d.Write(full)
+	d.Break()
+
+	if len(frames) != 2 {
+		t.Fatalf("expected 2 frames, got %d", len(frames))
+	}
+	if f := frames[0]; f.StartCode != StartCodeNull || !bytes.Equal(f.Data(), []byte{10, 20, 30, 40}) {
+		t.Errorf("unexpected first frame: start code %#x, data % x", f.StartCode, f.Data())
+	}
+	if f := frames[1]; f.Length != UniverseSize || f.Slots[0] != 0xff {
+		t.Errorf("unexpected second frame: length %d", f.Length)
+	}
+	stats := d.Stats()
+	expected := DecoderStats{Frames: 2, ShortFrames: 1, Overruns: 1, Errors: 1, SkippedBytes: 3 + 2 + 1}
+	if stats != expected {
+		t.Errorf("unexpected stats\nexpected: %+v\nactual:   %+v", expected, stats)
+	}
+}
+
+func TestDecoderFullFrame(t *testing.T) {
+	calls := 0
+	d := &Decoder{
+		OnFrame: func(frame *Frame) {
+			calls++
+			if frame.Length != UniverseSize || frame.Slots[UniverseSize-1] != 0x42 {
+				t.Errorf("unexpected frame: length %d, last slot %#x", frame.Length, frame.Slots[UniverseSize-1])
+			}
+		},
+	}
+
+	// Two frames with exactly 512 slots, as sent by most consoles.
+	full := make([]byte, 1+UniverseSize)
+	full[UniverseSize] = 0x42
+	for i := 0; i < 2; i++ {
+		d.Break()
+		d.Write(full)
+	}
+	d.Break()
+
+	if calls != 2 {
+		t.Errorf("expected 2 calls to OnFrame, got %d", calls)
+	}
+	if stats := d.Stats(); stats != (DecoderStats{Frames: 2}) {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestDecoderMarked(t *testing.T) {
+	strip := make(ledsgo.Strip, 2)
+	m := Mapping{Channel: 1, Strip: strip, Length: 2, Order: ledsgo.RGB}
+	d := &Decoder{
+		MinSlots: m.End(),
+		OnFrame: func(frame *Frame) {
+			if frame.StartCode == StartCodeNull {
+				frame.Unpack(&m)
+			}
+		},
+	}
+	stream := []byte{
+		0xff, 0x00, 0x00, // break
+		0x00, 1, 0xff, 0xff, 3, 4, 5, 6, // frame with an escaped 0xff slot
+		0xff, 0x00, 0x00, // break
+		0x00, 7, 8, 9, 0xff, 0x00, 0x42, // framing error
+		0xff, 0x00, 0x00, // break
+	}
+	if err := d.ReadMarked(bytes.NewReader(stream)); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if strip[0] != (color.RGBA{1, 0xff, 3, 0xff}) || strip[1] != (color.RGBA{4, 5, 6, 0xff}) {
+		t.Errorf("unexpected strip: %v", strip)
+	}
+	if stats := d.Stats(); stats.Frames != 1 || stats.Errors != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
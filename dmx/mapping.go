@@ -0,0 +1,193 @@
+// Package dmx contains the DMX512 concepts shared by the lighting protocols in
+// ledsgo, such as the mapping of DMX universes onto LED strips. It also
+// contains an encoder and decoder for DMX512 frames as sent over RS-485.
+package dmx
+
+import (
+	"errors"
+
+	"github.com/aykevl/ledsgo"
+)
+
+// Number of channels (slots) in a DMX universe.
+const UniverseSize = 512
+
+var errInvalidMapping = errors.New("dmx: mapping does not fit in universe or strip")
+
+// Mapping maps a range of DMX channels of a universe onto a range of LEDs of a
+// strip. Every LED uses Order.Channels() consecutive DMX channels by default,
+// so a universe fits 170 RGB LEDs or 128 RGBW LEDs.
+type Mapping struct {
+	Universe uint16
+	Channel  int // first DMX channel (1-512)
+	Strip    ledsgo.Strip
+	Start    int // index of the first LED in the strip
+	Length   int // number of LEDs
+	Order    ledsgo.ColorOrder
+
+	// Footprint is the number of DMX channels per LED, or 0 to use
+	// Order.Channels(). A larger footprint is used by fixtures that have
+	// extra channels (such as a dimmer or strobe channel) after the color
+	// channels. These extra channels are ignored by Apply and left unmodified
+	// by Fill.
+	Footprint int
+}
+
+// Return the number of channels per LED.
+func (m *Mapping) footprint() int {
+	if m.Footprint == 0 {
+		return m.Order.Channels()
+	}
+	return m.Footprint
+}
+
+// Validate checks whether the mapping fits in the universe and the strip, and
+// whether the color order is valid.
+func (m *Mapping) Validate() error {
+	if m.Channel < 1 || m.Length < 0 || m.Start < 0 || !m.Order.Valid() {
+		return errInvalidMapping
+	}
+	if m.Footprint != 0 && m.Footprint < m.Order.Channels() {
+		return errInvalidMapping
+	}
+	if m.Channel-1+m.Length*m.footprint() > UniverseSize {
+		return errInvalidMapping
+	}
+	if m.Start+m.Length > len(m.Strip) {
+		return errInvalidMapping
+	}
+	return nil
+}
+
+// Apply copies the colors from the DMX channel data of the universe into the
+// strip. The data starts at channel 1 and doesn't include the start code. If
+// the data is too short, only the LEDs that are fully covered are updated.
+func (m *Mapping) Apply(data []byte) {
+	channels := m.Order.Channels()
+	footprint := m.footprint()
+	offset := m.Channel - 1
+	for i := 0; i < m.Length; i++ {
+		if offset+channels > len(data) {
+			break
+		}
+		m.Strip[m.Start+i] = m.Order.Get(data[offset:])
+		offset += footprint
+	}
+}
+
+// Fill copies the colors of the strip into the DMX channel data of the
+// universe. This is the inverse of Apply. The data must be large enough to
+// hold all mapped channels.
+func (m *Mapping) Fill(data []byte) {
+	footprint := m.footprint()
+	offset := m.Channel - 1
+	for _, c := range m.Strip[m.Start : m.Start+m.Length] {
+		m.Order.Put(data[offset:], c)
+		offset += footprint
+	}
+}
+
+// End returns the number of DMX channels used in the universe, in other words
+// the last channel used by this mapping.
+func (m *Mapping) End() int {
+	return m.Channel - 1 + m.Length*m.footprint()
+}
+
+// Map is a set of mappings, for example of multiple universes onto one long
+// strip.
+type Map []Mapping
+
+// Validate checks whether all mappings are valid.
+func (m Map) Validate() error {
+	for i := range m {
+		if err := m[i].Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply copies the DMX channel data of the given universe into the strips of
+// all mappings for this universe. It returns whether there was any mapping for
+// this universe.
+func (m Map) Apply(universe uint16, data []byte) bool {
+	found := false
+	for i := range m {
+		if m[i].Universe == universe {
+			m[i].Apply(data)
+			found = true
+		}
+	}
+	return found
+}
+
+// Fill copies the strip colors of all mappings for the given universe into
+// data, and returns the number of channels used in the universe. The data
+// must be at least UniverseSize bytes.
+func (m Map) Fill(universe uint16, data []byte) int {
+	n := 0
+	for i := range m {
+		if m[i].Universe == universe {
+			m[i].Fill(data)
+			if end := m[i].End(); end > n {
+				n = end
+			}
+		}
+	}
+	return n
+}
+
+// Universes returns the list of universes in this map, in the order they
+// first appear.
+func (m Map) Universes() []uint16 {
+	var universes []uint16
+	for i := range m {
+		if !containsUniverse(universes, m[i].Universe) {
+			universes = append(universes, m[i].Universe)
+		}
+	}
+	return universes
+}
+
+// Contains returns whether there is a mapping for the given universe.
+func (m Map) Contains(universe uint16) bool {
+	for i := range m {
+		if m[i].Universe == universe {
+			return true
+		}
+	}
+	return false
+}
+
+func containsUniverse(universes []uint16, universe uint16) bool {
+	for _, u := range universes {
+		if u == universe {
+			return true
+		}
+	}
+	return false
+}
+
+// MapStrip creates a map that spreads the strip over as many universes as
+// needed, starting at the given universe. Every universe is filled with as
+// many LEDs as fit in it (170 for RGB orders, 128 for RGBW orders), starting
+// at channel 1. This is the most common way to map a long strip.
+func MapStrip(strip ledsgo.Strip, firstUniverse uint16, order ledsgo.ColorOrder) Map {
+	perUniverse := UniverseSize / order.Channels()
+	var m Map
+	for start := 0; start < len(strip); start += perUniverse {
+		length := len(strip) - start
+		if length > perUniverse {
+			length = perUniverse
+		}
+		m = append(m, Mapping{
+			Universe: firstUniverse + uint16(len(m)),
+			Channel:  1,
+			Strip:    strip,
+			Start:    start,
+			Length:   length,
+			Order:    order,
+		})
+	}
+	return m
+}
@@ -0,0 +1,115 @@
+package dmx
+
+import (
+	"bytes"
+	"image/color"
+	"testing"
+
+	"github.com/aykevl/ledsgo"
+)
+
+func TestMapStrip(t *testing.T) {
+	strip := make(ledsgo.Strip, 400)
+	m := MapStrip(strip, 5, ledsgo.GRB)
+	if len(m) != 3 {
+		t.Fatalf("expected 3 universes, got %d", len(m))
+	}
+	if err := m.Validate(); err != nil {
+		t.Error("invalid map:", err)
+	}
+	for i, expected := range []struct {
+		universe      uint16
+		start, length int
+	}{
+		{5, 0, 170},
+		{6, 170, 170},
+		{7, 340, 60},
+	} {
+		if m[i].Universe != expected.universe || m[i].Start != expected.start || m[i].Length != expected.length {
+			t.Errorf("mapping %d: expected %v, got universe %d start %d length %d", i, expected, m[i].Universe, m[i].Start, m[i].Length)
+		}
+	}
+	if universes := m.Universes(); len(universes) != 3 || universes[0] != 5 || universes[2] != 7 {
+		t.Errorf("unexpected universes: %v", universes)
+	}
+
+	m = MapStrip(make(ledsgo.Strip, 200), 1, ledsgo.RGBW)
+	if len(m) != 2 || m[0].Length != 128 || m[1].Length != 72 {
+		t.Errorf("unexpected RGBW map: %+v", m)
+	}
+}
+
+func TestMapApplyFill(t *testing.T) {
+	strip := make(ledsgo.Strip, 4)
+	m := Map{
+		{Universe: 1, Channel: 1, Strip: strip, Start: 0, Length: 2, Order: ledsgo.RGB},
+		{Universe: 2, Channel: 10, Strip: strip, Start: 2, Length: 2, Order: ledsgo.GRB},
+	}
+	if err := m.Validate(); err != nil {
+		t.Fatal("invalid map:", err)
+	}
+
+	data := make([]byte, UniverseSize)
+	copy(data, []byte{1, 2, 3, 4, 5, 6})
+	if !m.Apply(1, data) {
+		t.Error("universe 1 not found")
+	}
+	data2 := make([]byte, 20)
+	copy(data2[9:], []byte{10, 11, 12, 13, 14, 15})
+	if !m.Apply(2, data2) {
+		t.Error("universe 2 not found")
+	}
+	if m.Apply(3, data) {
+		t.Error("universe 3 should not be mapped")
+	}
+	expected := ledsgo.Strip{
+		{R: 1, G: 2, B: 3, A: 0xff},
+		{R: 4, G: 5, B: 6, A: 0xff},
+		{R: 11, G: 10, B: 12, A: 0xff},
+		{R: 14, G: 13, B: 15, A: 0xff},
+	}
+	for i := range strip {
+		if strip[i] != expected[i] {
+			t.Errorf("LED %d: expected %v, got %v", i, expected[i], strip[i])
+		}
+	}
+
+	// Fill is the inverse of Apply.
+	out := make([]byte, UniverseSize)
+	if n := m.Fill(2, out); n != 15 {
+		t.Errorf("expected 15 channels, got %d", n)
+	}
+	if !bytes.Equal(out[:15], data2[:15]) {
+		t.Errorf("unexpected Fill output: %v", out[:15])
+	}
+}
+
+func TestMappingShortData(t *testing.T) {
+	// Short universes only update the LEDs that are fully covered.
+	strip := ledsgo.Strip{{}, {}, {}}
+	m := Mapping{Universe: 1, Channel: 1, Strip: strip, Length: 3}
+	m.Apply([]byte{1, 2, 3, 4, 5})
+	if strip[0] != (color.RGBA{1, 2, 3, 0xff}) || strip[1] != (color.RGBA{}) {
+		t.Errorf("unexpected strip: %v", strip)
+	}
+}
+
+func TestMappingValidate(t *testing.T) {
+	strip := make(ledsgo.Strip, 200)
+	for _, m := range []Mapping{
+		{Channel: 0, Strip: strip, Length: 1},
+		{Channel: 1, Strip: strip, Length: 171},
+		{Channel: 4, Strip: strip, Length: 170},
+		{Channel: 1, Strip: strip, Start: 100, Length: 101},
+		{Channel: 1, Strip: strip, Length: 129, Order: ledsgo.RGBW},
+		{Channel: 1, Strip: strip, Length: 1, Order: ledsgo.ColorOrder(7)},
+	} {
+		if m.Validate() == nil {
+			t.Errorf("expected mapping to be invalid: channel %d start %d length %d", m.Channel, m.Start, m.Length)
+		}
+	}
+	m := Mapping{Channel: 3, Strip: strip, Length: 170}
+	if err := m.Validate(); err != nil {
+		t.Errorf("expected mapping to be valid: %v", err)
+	}
+}
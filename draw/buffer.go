@@ -0,0 +1,72 @@
+package draw
+
+import (
+	"image/color"
+
+	"github.com/aykevl/ledsgo/demos"
+)
+
+// Buffer is an in-memory display that implements Reader. It can be used to
+// draw with proper blending on displays that can't read back their pixels:
+// draw into the buffer and copy it to the display with DrawTo.
+type Buffer struct {
+	Width, Height int16
+	Pixels        []color.RGBA // row-major
+}
+
+// NewBuffer creates a new black buffer with the given size.
+func NewBuffer(width, height int16) *Buffer {
+	return &Buffer{
+		Width:  width,
+		Height: height,
+		Pixels: make([]color.RGBA, int(width)*int(height)),
+	}
+}
+
+// Size returns the size of the buffer.
+func (b *Buffer) Size() (width, height int16) {
+	return b.Width, b.Height
+}
+
+// SetPixel sets the color of a pixel. Pixels outside of the buffer are
+// ignored.
+func (b *Buffer) SetPixel(x, y int16, c color.RGBA) {
+	if x < 0 || y < 0 || x >= b.Width || y >= b.Height {
+		return
+	}
+	b.Pixels[int(y)*int(b.Width)+int(x)] = c
+}
+
+// GetPixel returns the color of a pixel, or black for pixels outside of the
+// buffer.
+func (b *Buffer) GetPixel(x, y int16) color.RGBA {
+	if x < 0 || y < 0 || x >= b.Width || y >= b.Height {
+		return color.RGBA{}
+	}
+	return b.Pixels[int(y)*int(b.Width)+int(x)]
+}
+
+// Clear sets all pixels to the given color.
+func (b *Buffer) Clear(c color.RGBA) {
+	for i := range b.Pixels {
+		b.Pixels[i] = c
+	}
+}
+
+// DrawTo copies the buffer to the display, with the top left corner of the
+// buffer at the top left corner of the display. Pixels that don't fit on the
+// display are skipped.
+func (b *Buffer) DrawTo(display demos.Displayer) {
+	width, height := display.Size()
+	if width > b.Width {
+		width = b.Width
+	}
+	if height > b.Height {
+		height = b.Height
+	}
+	for y := int16(0); y < height; y++ {
+		for x := int16(0); x < width; x++ {
+			display.SetPixel(x, y, b.Pixels[int(y)*int(b.Width)+int(x)])
+		}
+	}
+}
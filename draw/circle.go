@@ -0,0 +1,107 @@
+package draw
+
+import (
+	"image/color"
+
+	"github.com/aykevl/ledsgo/demos"
+)
+
+// Circle draws an anti-aliased circle outline of one pixel wide.
+func Circle(display demos.Displayer, center Point, radius Fixed, c color.RGBA) {
+	drawCircle(display, center, radius, c, false, 0, 0)
+}
+
+// FillCircle draws an anti-aliased filled circle.
+func FillCircle(display demos.Displayer, center Point, radius Fixed, c color.RGBA) {
+	drawCircle(display, center, radius, c, true, 0, 0)
+}
+
+// Arc draws part of an anti-aliased circle outline, from the start angle
+// clockwise to the end angle. Angles are 16-bit: 0 is at the right of the
+// circle (3 o'clock), 0x4000 at the bottom (6 o'clock) and so on. If start and
+// end are equal, the whole circle is drawn.
+func Arc(display demos.Displayer, center Point, radius Fixed, start, end uint16, c color.RGBA) {
+	drawCircle(display, center, radius, c, false, start, end)
+}
+
+// Draw a circle, filled or not, optionally limited to the given angles.
+func drawCircle(display demos.Displayer, center Point, radius Fixed, c color.RGBA, fill bool, start, end uint16) {
+	if radius < 0 {
+		return
+	}
+	width, height := display.Size()
+	reach := radius + One // pixels further away are not covered at all
+	xFirst, xLast := pixelRange(center.X-reach, center.X+reach, width)
+	yFirst, yLast := pixelRange(center.Y-reach, center.Y+reach, height)
+	for y := yFirst; y <= yLast; y++ {
+		dy := int64(Int(y) - center.Y)
+		for x := xFirst; x <= xLast; x++ {
+			dx := int64(Int(x) - center.X)
+			if start != end {
+				angle := atan2(dy, dx)
+				if angle-start > end-start {
+					continue
+				}
+			}
+			distance := Fixed(isqrt(uint64(dx*dx + dy*dy))) // .8
+			var coverage int
+			if fill {
+				// Pixels half a pixel inside the circle are fully covered.
+				coverage = int(radius - distance + 128)
+			} else {
+				// Pixels at exactly the radius are fully covered, the coverage
+				// decreases linearly to zero at one pixel distance.
+				coverage = 256 - int(abs(distance-radius))
+			}
+			plot(display, width, height, x, y, c, coverage)
+		}
+	}
+}
+
+// Return the angle of the vector (x, y) as a 16-bit angle, where 0x10000 is a
+// full circle. The maximum error is about 0.004 radians (45 units).
+func atan2(y, x int64) uint16 {
+	if x == 0 && y == 0 {
+		return 0
+	}
+	// Reduce to the first octant: 0 <= y <= x.
+	var octant uint16
+	if y < 0 {
+		x, y = -x, -y
+		octant = 0x8000
+	}
+	if x <= 0 {
+		x, y = y, -x
+		octant += 0x4000
+	}
+	swap := y > x
+	if swap {
+		x, y = y, x
+	}
+	// atan(r) ≈ π/4*r + 0.273*r*(1-r), with r = y/x in .16.
+	r := y << 16 / x
+	angle := (8192*r + 2847*r*(0x10000-r)>>16) >> 16
+	if swap {
+		angle = 0x4000 - angle
+	}
+	return octant + uint16(angle)
+}
+
+// Return the integer square root of n, rounded down.
+func isqrt(n uint64) uint32 {
+	var result uint64
+	bit := uint64(1) << 62
+	for bit > n {
+		bit >>= 2
+	}
+	for bit != 0 {
+		if n >= result+bit {
+			n -= result + bit
+			result = result>>1 + bit
+		} else {
+			result >>= 1
+		}
+		bit >>= 2
+	}
+	return uint32(result)
+}
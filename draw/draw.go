@@ -0,0 +1,196 @@
+// Package draw contains anti-aliased drawing primitives (lines, circles, arcs,
+// rectangles, polygons and dots) that work on any demos.Displayer.
+//
+// Coordinates are fixed-point numbers (see Fixed) so that shapes can move
+// smoothly on low-resolution LED matrices. Integer coordinates are at the
+// center of a pixel: the pixel (x, y) covers the area from x-0.5 to x+0.5 and
+// from y-0.5 to y+0.5.
+//
+// Pixels are blended with the existing content of the display using
+// ledsgo.Blend when the display implements Reader. Otherwise the background is
+// assumed to be black, and the color is scaled by its coverage.
+package draw
+
+import (
+	"image/color"
+
+	"github.com/aykevl/ledsgo"
+	"github.com/aykevl/ledsgo/demos"
+)
+
+// Fixed is a signed 24.8 fixed-point number: 256 is 1.0.
+type Fixed int32
+
+// One is the fixed-point number 1.0.
+const One Fixed = 256
+
+// Int returns n as a fixed-point number.
+func Int(n int) Fixed {
+	return Fixed(n) << 8
+}
+
+// Round returns f rounded to the nearest integer.
+func (f Fixed) Round() int {
+	return int((f + 128) >> 8)
+}
+
+// Point is a point with fixed-point coordinates.
+type Point struct {
+	X, Y Fixed
+}
+
+// Pt returns a point at integer coordinates (the center of a pixel).
+func Pt(x, y int) Point {
+	return Point{Int(x), Int(y)}
+}
+
+// Reader is a display that can read back the colors of pixels, so that new
+// pixels can be blended with the existing content.
+type Reader interface {
+	demos.Displayer
+
+	// GetPixel returns the color of the given pixel.
+	GetPixel(x, y int16) color.RGBA
+}
+
+// Draw a single pixel with the given coverage (0-256). Pixels outside of the
+// display are ignored.
+func plot(display demos.Displayer, width, height int16, x, y int, c color.RGBA, coverage int) {
+	if x < 0 || y < 0 || x >= int(width) || y >= int(height) || coverage <= 0 {
+		return
+	}
+	if coverage > 256 {
+		coverage = 256
+	}
+	alpha := uint8(int(c.A) * coverage >> 8)
+	if coverage == 256 {
+		alpha = c.A
+	}
+	if alpha == 0 {
+		return
+	}
+	if reader, ok := display.(Reader); ok {
+		top := c
+		top.A = alpha
+		display.SetPixel(int16(x), int16(y), ledsgo.Blend(reader.GetPixel(int16(x), int16(y)), top))
+		return
+	}
+	c = ledsgo.ApplyAlpha(c, alpha)
+	c.A = 255
+	display.SetPixel(int16(x), int16(y), c)
+}
+
+// Dot draws a dot of one pixel in size at the given position. If the position
+// is not at the center of a pixel, the dot is spread over up to four pixels
+// (bilinear filtering), which makes it possible to move a dot smoothly.
+func Dot(display demos.Displayer, p Point, c color.RGBA) {
+	width, height := display.Size()
+	x := int(p.X >> 8)
+	y := int(p.Y >> 8)
+	fx := int(p.X & 0xff)
+	fy := int(p.Y & 0xff)
+	plot(display, width, height, x, y, c, (256-fx)*(256-fy)>>8)
+	plot(display, width, height, x+1, y, c, fx*(256-fy)>>8)
+	plot(display, width, height, x, y+1, c, (256-fx)*fy>>8)
+	plot(display, width, height, x+1, y+1, c, fx*fy>>8)
+}
+
+// Line draws an anti-aliased line of one pixel wide from p0 to p1, using
+// Xiaolin Wu's algorithm. Both end points are included, so a horizontal line
+// from (0, 0) to (3, 0) covers 4 pixels.
+func Line(display demos.Displayer, p0, p1 Point, c color.RGBA) {
+	width, height := display.Size()
+	x0, y0, x1, y1 := p0.X, p0.Y, p1.X, p1.Y
+	steep := abs(y1-y0) > abs(x1-x0)
+	if steep {
+		// Iterate over y instead of x.
+		x0, y0, x1, y1 = y0, x0, y1, x1
+	}
+	if x0 > x1 {
+		x0, y0, x1, y1 = x1, y1, x0, y0
+	}
+	gradient := int64(0) // .16
+	if x1 != x0 {
+		gradient = int64(y1-y0) << 16 / int64(x1-x0)
+	}
+	xStart := int((x0 + 128) >> 8)
+	xEnd := int((x1 + 128) >> 8)
+	for x := xStart; x <= xEnd; x++ {
+		// Part of this column that is covered by the line, which is extended
+		// by half a pixel at both ends.
+		coverage := overlap(Int(x)-128, Int(x)+128, x0-128, x1+128)
+		y := y0 + Fixed((int64(Int(x)-x0)*gradient)>>16)
+		yInt := int(y >> 8)
+		frac := int(y & 0xff)
+		if steep {
+			plot(display, width, height, yInt, x, c, (256-frac)*coverage>>8)
+			plot(display, width, height, yInt+1, x, c, frac*coverage>>8)
+		} else {
+			plot(display, width, height, x, yInt, c, (256-frac)*coverage>>8)
+			plot(display, width, height, x, yInt+1, c, frac*coverage>>8)
+		}
+	}
+}
+
+// FillRect fills the rectangle with corners p0 and p1 (in any order). Like
+// Line, the pixels at the corners are fully included: FillRect from (0, 0) to
+// (3, 1) fills 4x2 pixels. Edges that are not at a pixel boundary are
+// anti-aliased.
+func FillRect(display demos.Displayer, p0, p1 Point, c color.RGBA) {
+	width, height := display.Size()
+	if p0.X > p1.X {
+		p0.X, p1.X = p1.X, p0.X
+	}
+	if p0.Y > p1.Y {
+		p0.Y, p1.Y = p1.Y, p0.Y
+	}
+	left, right := p0.X-128, p1.X+128
+	top, bottom := p0.Y-128, p1.Y+128
+	xFirst, xLast := pixelRange(left, right, width)
+	yFirst, yLast := pixelRange(top, bottom, height)
+	for y := yFirst; y <= yLast; y++ {
+		coverageY := overlap(Int(y)-128, Int(y)+128, top, bottom)
+		for x := xFirst; x <= xLast; x++ {
+			coverageX := overlap(Int(x)-128, Int(x)+128, left, right)
+			plot(display, width, height, x, y, c, coverageX*coverageY>>8)
+		}
+	}
+}
+
+// Return the first and last pixel that overlap with the range [lo, hi),
+// clipped to the range of pixels [0, size).
+func pixelRange(lo, hi Fixed, size int16) (first, last int) {
+	first = int((lo + 128) >> 8)
+	last = int((hi+128+255)>>8) - 1
+	if first < 0 {
+		first = 0
+	}
+	if last >= int(size) {
+		last = int(size) - 1
+	}
+	return first, last
+}
+
+// Return the length (in 1/256 pixels) of the overlap of the ranges [a0, a1]
+// and [b0, b1], which is at most 256 in practice.
+func overlap(a0, a1, b0, b1 Fixed) int {
+	lo := a0
+	if b0 > lo {
+		lo = b0
+	}
+	hi := a1
+	if b1 < hi {
+		hi = b1
+	}
+	if hi <= lo {
+		return 0
+	}
+	return int(hi - lo)
+}
+
+func abs(n Fixed) Fixed {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,271 @@
+package draw
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/aykevl/ledsgo/demos"
+)
+
+var white = color.RGBA{255, 255, 255, 255}
+
+// testDisplay is a displayer without read-back that panics on pixels outside
+// of the display, to check that all drawing is clipped.
+type testDisplay struct {
+	buf *Buffer
+}
+
+var _ demos.Displayer = testDisplay{}
+
+func newTestDisplay(width, height int16) testDisplay {
+	return testDisplay{NewBuffer(width, height)}
+}
+
+func (d testDisplay) Size() (int16, int16) {
+	return d.buf.Size()
+}
+
+func (d testDisplay) SetPixel(x, y int16, c color.RGBA) {
+	if x < 0 || y < 0 || x >= d.buf.Width || y >= d.buf.Height {
+		panic("pixel out of bounds")
+	}
+	d.buf.SetPixel(x, y, c)
+}
+
+// Return the brightness of each pixel (the red channel) as a row-major slice.
+func (d testDisplay) values() []int {
+	values := make([]int, len(d.buf.Pixels))
+	for i, c := range d.buf.Pixels {
+		values[i] = int(c.R)
+	}
+	return values
+}
+
+// Return the sum of all brightness values, divided by 255: the total area
+// covered.
+func (d testDisplay) area() float64 {
+	sum := 0
+	for _, v := range d.values() {
+		sum += v
+	}
+	return float64(sum) / 255
+}
+
+func checkValues(t *testing.T, name string, d testDisplay, expected []int) {
+	t.Helper()
+	values := d.values()
+	for i := range values {
+		if diff := values[i] - expected[i]; diff < -2 || diff > 2 {
+			t.Errorf("%s: unexpected pixels\nexpected: %v\nactual:   %v", name, expected, values)
+			return
+		}
+	}
+}
+
+func TestFixed(t *testing.T) {
+	if Int(3) != 768 || Fixed(383).Round() != 1 || Fixed(384).Round() != 2 || Fixed(-129).Round() != -1 {
+		t.Error("unexpected fixed-point conversion")
+	}
+	if Pt(1, 2) != (Point{256, 512}) {
+		t.Error("unexpected point")
+	}
+}
+
+func TestDot(t *testing.T) {
+	d := newTestDisplay(3, 2)
+	Dot(d, Pt(1, 0), white)
+	checkValues(t, "center", d, []int{0, 255, 0, 0, 0, 0})
+
+	d = newTestDisplay(3, 2)
+	Dot(d, Point{Int(1) + 128, 128}, white)
+	checkValues(t, "between pixels", d, []int{0, 64, 64, 0, 64, 64})
+
+	// Partially outside of the display.
+	Dot(d, Point{-128, -128}, white)
+	Dot(d, Point{Int(2) + 128, Int(1) + 128}, white)
+}
+
+func TestLine(t *testing.T) {
+	d := newTestDisplay(5, 2)
+	Line(d, Pt(0, 0), Pt(3, 0), white)
+	checkValues(t, "horizontal", d, []int{255, 255, 255, 255, 0, 0, 0, 0, 0, 0})
+
+	d = newTestDisplay(5, 2)
+	Line(d, Point{Int(3), 128}, Point{Int(1), 128}, white)
+	checkValues(t, "half pixel", d, []int{0, 128, 128, 128, 0, 0, 128, 128, 128, 0})
+
+	d = newTestDisplay(2, 4)
+	Line(d, Pt(1, 0), Pt(1, 3), white)
+	checkValues(t, "vertical", d, []int{0, 255, 0, 255, 0, 255, 0, 255})
+
+	d = newTestDisplay(3, 3)
+	Line(d, Pt(0, 0), Pt(2, 2), white)
+	checkValues(t, "diagonal", d, []int{255, 0, 0, 0, 255, 0, 0, 0, 255})
+
+	// A line with a shallow slope covers one pixel per column.
+	d = newTestDisplay(20, 10)
+	Line(d, Pt(1, 2), Pt(18, 7), white)
+	if area := d.area(); math.Abs(area-18) > 0.2 {
+		t.Errorf("shallow line: expected an area of 18, got %.2f", area)
+	}
+
+	// Clipping.
+	d = newTestDisplay(4, 4)
+	Line(d, Pt(-10, -3), Pt(10, 7), white)
+	Line(d, Pt(-3, -10), Pt(7, 10), white)
+}
+
+func TestFillRect(t *testing.T) {
+	d := newTestDisplay(4, 3)
+	FillRect(d, Pt(2, 1), Pt(1, 0), white)
+	checkValues(t, "integer", d, []int{0, 255, 255, 0, 0, 255, 255, 0, 0, 0, 0, 0})
+
+	d = newTestDisplay(4, 3)
+	FillRect(d, Point{Int(1) + 128, 0}, Point{Int(2) + 128, Int(1)}, white)
+	checkValues(t, "half pixel", d, []int{0, 128, 255, 128, 0, 128, 255, 128, 0, 0, 0, 0})
+
+	d = newTestDisplay(4, 3)
+	FillRect(d, Pt(-5, -5), Pt(10, 10), white)
+	if d.area() != 12 {
+		t.Errorf("clipped rectangle: unexpected area %.2f", d.area())
+	}
+}
+
+func TestCircle(t *testing.T) {
+	center := Point{Int(15) + 77, Int(15) + 200}
+	for _, radius := range []Fixed{Int(3), Int(7) + 100, Int(12)} {
+		r := float64(radius) / 256
+
+		d := newTestDisplay(32, 32)
+		FillCircle(d, center, radius, white)
+		if area, expected := d.area(), math.Pi*r*r; math.Abs(area-expected)/expected > 0.02 {
+			t.Errorf("filled circle with radius %.2f: expected area %.2f, got %.2f", r, expected, area)
+		}
+
+		d = newTestDisplay(32, 32)
+		Circle(d, center, radius, white)
+		if area, expected := d.area(), 2*math.Pi*r; math.Abs(area-expected)/expected > 0.05 {
+			t.Errorf("circle with radius %.2f: expected area %.2f, got %.2f", r, expected, area)
+		}
+
+		// Quarter arc at the bottom right.
+		d = newTestDisplay(32, 32)
+		Arc(d, center, radius, 0, 0x4000, white)
+		if area, expected := d.area(), math.Pi*r/2; math.Abs(area-expected)/expected > 0.1 {
+			t.Errorf("arc with radius %.2f: expected area %.2f, got %.2f", r, expected, area)
+		}
+		for y := int16(0); y < 32; y++ {
+			for x := int16(0); x < 32; x++ {
+				if d.buf.GetPixel(x, y).R != 0 && (Int(int(x)) < center.X-One || Int(int(y)) < center.Y-One) {
+					t.Errorf("arc with radius %.2f: pixel (%d, %d) outside of the quadrant", r, x, y)
+				}
+			}
+		}
+	}
+
+	// Clipping.
+	d := newTestDisplay(4, 4)
+	FillCircle(d, Pt(0, 0), Int(10), white)
+	Circle(d, Pt(3, 3), Int(2), white)
+}
+
+func TestFillPolygon(t *testing.T) {
+	// A square with corners at pixel boundaries fills whole pixels.
+	d := newTestDisplay(4, 3)
+	FillPolygon(d, []Point{{Int(1) - 128, -128}, {Int(2) + 128, -128}, {Int(2) + 128, Int(1) + 128}, {Int(1) - 128, Int(1) + 128}}, white)
+	checkValues(t, "square", d, []int{0, 255, 255, 0, 0, 255, 255, 0, 0, 0, 0, 0})
+
+	// A triangle covers half of the square.
+	d = newTestDisplay(12, 12)
+	FillPolygon(d, []Point{Pt(1, 1), Pt(11, 1), Pt(1, 11)}, white)
+	if area := d.area(); math.Abs(area-50) > 0.5 {
+		t.Errorf("triangle: expected area 50, got %.2f", area)
+	}
+
+	// Two overlapping squares in the same direction: the overlap is filled
+	// once (non-zero winding).
+	d = newTestDisplay(10, 10)
+	FillPolygon(d, []Point{Pt(0, 0), Pt(6, 0), Pt(6, 6), Pt(0, 6), Pt(0, 0), Pt(3, 3), Pt(9, 3), Pt(9, 9), Pt(3, 9), Pt(3, 3)}, white)
+	if area := d.area(); math.Abs(area-63) > 0.5 {
+		t.Errorf("overlapping squares: expected area 63, got %.2f", area)
+	}
+
+	// Clipping.
+	d = newTestDisplay(4, 4)
+	FillPolygon(d, []Point{Pt(-10, -10), Pt(10, -5), Pt(2, 20)}, white)
+	if d.area() != 16 {
+		t.Errorf("clipped polygon: unexpected area %.2f", d.area())
+	}
+}
+
+func TestBlend(t *testing.T) {
+	// With read-back, colors are blended with the existing content.
+	b := NewBuffer(2, 1)
+	b.Clear(color.RGBA{0, 0, 255, 255})
+	Dot(b, Point{128, 0}, color.RGBA{255, 0, 0, 255})
+	if c := b.GetPixel(0, 0); c.R < 126 || c.R > 129 || c.B < 126 || c.B > 129 {
+		t.Errorf("unexpected blended color: %v", c)
+	}
+
+	// Alpha of the color is taken into account.
+	b.Clear(color.RGBA{0, 0, 0, 255})
+	FillRect(b, Pt(0, 0), Pt(1, 0), color.RGBA{255, 255, 255, 128})
+	if c := b.GetPixel(1, 0); c.R < 127 || c.R > 129 {
+		t.Errorf("unexpected color with alpha: %v", c)
+	}
+
+	// Without read-back, the color is scaled.
+	d := newTestDisplay(2, 1)
+	FillRect(d, Point{0, 0}, Point{128, 0}, color.RGBA{200, 100, 0, 255})
+	if c := d.buf.GetPixel(1, 0); c.R < 99 || c.R > 100 || c.G < 49 || c.G > 50 || c.B != 0 || c.A != 255 {
+		t.Errorf("unexpected scaled color: %v", c)
+	}
+}
+
+func TestAtan2(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	maxError := 0.0
+	for i := 0; i < 100000; i++ {
+		x := r.Int63n(20001) - 10000
+		y := r.Int63n(20001) - 10000
+		if x == 0 && y == 0 {
+			continue
+		}
+		expected := math.Atan2(float64(y), float64(x)) / (2 * math.Pi) * 0x10000
+		diff := math.Mod(float64(atan2(y, x))-expected+0x18000, 0x10000) - 0x8000
+		if math.Abs(diff) > maxError {
+			maxError = math.Abs(diff)
+		}
+	}
+	t.Logf("max error: %.1f", maxError)
+	if maxError > 50 {
+		t.Errorf("atan2 error too large: %.1f", maxError)
+	}
+}
+
+func TestIsqrt(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 10000; i++ {
+		n := uint64(r.Int63n(1 << 50))
+		s := uint64(isqrt(n))
+		if s*s > n || (s+1)*(s+1) <= n {
+			t.Fatalf("isqrt(%d) = %d", n, s)
+		}
+	}
+}
+
+func BenchmarkFillCircle(b *testing.B) {
+	buf := NewBuffer(32, 32)
+	for i := 0; i < b.N; i++ {
+		FillCircle(buf, Pt(16, 16), Int(10), white)
+	}
+}
+
+func BenchmarkLine(b *testing.B) {
+	buf := NewBuffer(32, 32)
+	for i := 0; i < b.N; i++ {
+		Line(buf, Pt(1, 3), Pt(30, 20), white)
+	}
+}
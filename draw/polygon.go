@@ -0,0 +1,118 @@
+package draw
+
+import (
+	"image/color"
+
+	"github.com/aykevl/ledsgo/demos"
+)
+
+// Number of sub-scanlines per pixel row used for anti-aliasing polygons.
+const polygonSubsamples = 4
+
+// FillPolygon fills the polygon with the given corner points, using the
+// non-zero winding rule. The polygon is closed automatically. Unlike FillRect,
+// the points are the exact corners of the filled area: a square from (0, 0) to
+// (2, 2) fully covers pixel (1, 1) and partially covers the pixels around it.
+//
+// Edges are anti-aliased exactly in the horizontal direction and with 4
+// samples per pixel in the vertical direction.
+func FillPolygon(display demos.Displayer, points []Point, c color.RGBA) {
+	if len(points) < 3 {
+		return
+	}
+	width, height := display.Size()
+
+	top, bottom := points[0].Y, points[0].Y
+	for _, p := range points {
+		if p.Y < top {
+			top = p.Y
+		}
+		if p.Y > bottom {
+			bottom = p.Y
+		}
+	}
+	yFirst, yLast := pixelRange(top, bottom, height)
+
+	coverage := make([]int, width) // coverage of the current row, 256*polygonSubsamples is full
+	var crossings []crossing
+	for y := yFirst; y <= yLast; y++ {
+		for i := range coverage {
+			coverage[i] = 0
+		}
+		for s := 0; s < polygonSubsamples; s++ {
+			// y coordinate of this sub-scanline, in the middle of the sample.
+			sy := Int(y) - 128 + Fixed((2*s+1)*128/polygonSubsamples)
+			crossings = findCrossings(crossings[:0], points, sy)
+			winding := 0
+			for i, cr := range crossings {
+				winding += cr.dir
+				if winding != 0 && i+1 < len(crossings) {
+					// Convert to pixel-edge coordinates.
+					addSpan(coverage, cr.x+128, crossings[i+1].x+128)
+				}
+			}
+		}
+		for x, cov := range coverage {
+			plot(display, width, height, x, y, c, cov/polygonSubsamples)
+		}
+	}
+}
+
+// A crossing of a polygon edge with a horizontal line.
+type crossing struct {
+	x   Fixed
+	dir int // +1 for downwards edges, -1 for upwards edges
+}
+
+// Find all crossings of the polygon edges with the horizontal line at y, sorted
+// by x coordinate. The crossings are appended to the given slice.
+func findCrossings(crossings []crossing, points []Point, y Fixed) []crossing {
+	for i, a := range points {
+		b := points[(i+1)%len(points)]
+		dir := 1
+		if a.Y > b.Y {
+			a, b = b, a
+			dir = -1
+		}
+		// Edges include their top point but not their bottom point, so that
+		// a vertex shared by two edges is only counted once.
+		if y < a.Y || y >= b.Y {
+			continue
+		}
+		x := a.X + Fixed(int64(y-a.Y)*int64(b.X-a.X)/int64(b.Y-a.Y))
+		// Insertion sort: there are usually very few crossings.
+		j := len(crossings)
+		crossings = append(crossings, crossing{})
+		for j > 0 && crossings[j-1].x > x {
+			crossings[j] = crossings[j-1]
+			j--
+		}
+		crossings[j] = crossing{x, dir}
+	}
+	return crossings
+}
+
+// Add the span [x0, x1) (in pixel-edge coordinates, where pixel x covers
+// [x, x+1)) to the coverage of a row.
+func addSpan(coverage []int, x0, x1 Fixed) {
+	if x0 < 0 {
+		x0 = 0
+	}
+	if end := Int(len(coverage)); x1 > end {
+		x1 = end
+	}
+	if x1 <= x0 {
+		return
+	}
+	first := int(x0 >> 8)
+	last := int((x1 - 1) >> 8)
+	if first == last {
+		coverage[first] += int(x1 - x0)
+		return
+	}
+	coverage[first] += int(Int(first+1) - x0)
+	for x := first + 1; x < last; x++ {
+		coverage[x] += 256
+	}
+	coverage[last] += int(x1 - Int(last))
+}
@@ -0,0 +1,294 @@
+package e131
+
+import (
+	"bytes"
+	"image/color"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/aykevl/ledsgo"
+	"github.com/aykevl/ledsgo/dmx"
+)
+
+var testCID = [16]byte{0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0, 1, 2, 3, 4, 5, 6, 7, 8}
+
+func TestDataPacket(t *testing.T) {
+	p := &DataPacket{
+		CID:        testCID,
+		SourceName: "console",
+		Priority:   100,
+		Sequence:   7,
+		Universe:   0x0102,
+		Data:       []byte{1, 2, 3},
+	}
+	buf := p.Append(nil)
+	if len(buf) != 126+3 {
+		t.Fatalf("unexpected packet length: %d", len(buf))
+	}
+
+	// Check a few fields against the E1.31 packet layout.
+	expected := map[int][]byte{
+		0:   {0x00, 0x10, 0x00, 0x00, 'A', 'S', 'C', '-', 'E', '1', '.', '1', '7', 0, 0, 0},
+		16:  {0x70, 129 - 16, 0x00, 0x00, 0x00, 0x04}, // root flags, length and vector
+		22:  testCID[:],
+		38:  {0x70, 129 - 38, 0x00, 0x00, 0x00, 0x02}, // framing flags, length and vector
+		44:  {'c', 'o', 'n', 's', 'o', 'l', 'e', 0},
+		108: {100, 0, 0, 7, 0, 0x01, 0x02}, // priority, sync address, sequence, options, universe
+		115: {0x70, 129 - 115, 0x02, 0xa1, 0x00, 0x00, 0x00, 0x01, 0x00, 0x04, 0x00, 1, 2, 3},
+	}
+	for offset, data := range expected {
+		if !bytes.Equal(buf[offset:offset+len(data)], data) {
+			t.Errorf("offset %d: expected % x, got % x", offset, data, buf[offset:offset+len(data)])
+		}
+	}
+
+	// Parse it back.
+	packet, err := Parse(buf)
+	if err != nil {
+		t.Fatal("failed to parse packet:", err)
+	}
+	p2, ok := packet.(*DataPacket)
+	if !ok {
+		t.Fatalf("expected *DataPacket, got %T", packet)
+	}
+	if p2.CID != p.CID || p2.SourceName != p.SourceName || p2.Priority != p.Priority || p2.Sequence != p.Sequence || p2.Universe != p.Universe || !bytes.Equal(p2.Data, p.Data) {
+		t.Errorf("packet changed after round trip: %+v", p2)
+	}
+}
+
+func TestSyncPacket(t *testing.T) {
+	p := &SyncPacket{CID: testCID, Sequence: 3, SyncAddress: 7000}
+	buf := p.Append(nil)
+	if len(buf) != 49 {
+		t.Fatalf("unexpected packet length: %d", len(buf))
+	}
+	if !bytes.Equal(buf[16:22], []byte{0x70, 49 - 16, 0, 0, 0, 0x08}) {
+		t.Errorf("unexpected root layer: % x", buf[16:22])
+	}
+	if !bytes.Equal(buf[38:49], []byte{0x70, 49 - 38, 0, 0, 0, 0x01, 3, 0x1b, 0x58, 0, 0}) {
+		t.Errorf("unexpected framing layer: % x", buf[38:49])
+	}
+	packet, err := Parse(buf)
+	if err != nil {
+		t.Fatal("failed to parse packet:", err)
+	}
+	if p2, ok := packet.(*SyncPacket); !ok || *p2 != *p {
+		t.Errorf("packet changed after round trip: %+v", packet)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	valid := (&DataPacket{Universe: 1, Data: []byte{1, 2, 3}}).Append(nil)
+	modify := func(offset int, value byte) []byte {
+		buf := append([]byte{}, valid...)
+		buf[offset] = value
+		return buf
+	}
+	for name, buf := range map[string][]byte{
+		"empty":      nil,
+		"short":      valid[:100],
+		"truncated":  valid[:len(valid)-1],
+		"identifier": modify(4, 'X'),
+		"length":     modify(17, 0),
+		"vector":     modify(21, 0x05),
+		"DMP":        modify(118, 0xa2),
+	} {
+		if _, err := Parse(buf); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// Helper to send packets to a receiver directly.
+type testSender struct {
+	t        *testing.T
+	r        *Receiver
+	cid      [16]byte
+	sequence uint8
+	now      time.Time
+}
+
+func (s *testSender) send(universe uint16, priority uint8, syncAddress uint16, data ...byte) {
+	s.sequence++
+	buf := (&DataPacket{
+		CID:         s.cid,
+		Priority:    priority,
+		SyncAddress: syncAddress,
+		Sequence:    s.sequence,
+		Universe:    universe,
+		Data:        data,
+	}).Append(nil)
+	if err := s.r.HandlePacket(buf, s.now); err != nil {
+		s.t.Error("failed to handle packet:", err)
+	}
+}
+
+func TestReceiver(t *testing.T) {
+	strip := make(ledsgo.Strip, 2)
+	frames := 0
+	r := NewReceiver(nil, dmx.Map{
+		{Universe: 1, Channel: 1, Strip: strip, Start: 0, Length: 1},
+		{Universe: 2, Channel: 1, Strip: strip, Start: 1, Length: 1},
+	})
+	r.OnFrame = func() {
+		frames++
+	}
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := &testSender{t: t, r: r, cid: [16]byte{1}, now: now}
+	b := &testSender{t: t, r: r, cid: [16]byte{2}, now: now}
+
+	// A frame is complete once both universes have been received.
+	a.send(1, 100, 0, 1, 2, 3)
+	if frames != 0 {
+		t.Error("frame complete after one universe")
+	}
+	a.send(2, 100, 0, 4, 5, 6)
+	if frames != 1 {
+		t.Error("frame not complete after both universes")
+	}
+	if strip[0] != (color.RGBA{1, 2, 3, 0xff}) || strip[1] != (color.RGBA{4, 5, 6, 0xff}) {
+		t.Errorf("unexpected strip: %v", strip)
+	}
+
+	// Out of order packets are dropped.
+	a.sequence -= 2
+	a.send(1, 100, 0, 9, 9, 9)
+	if strip[0] != (color.RGBA{1, 2, 3, 0xff}) {
+		t.Error("old packet was not dropped")
+	}
+	// ...but the sequence number can wrap around.
+	a.sequence = 100
+	a.send(1, 100, 0, 10, 11, 12)
+	a.sequence = 200
+	a.send(1, 100, 0, 13, 14, 15)
+	a.sequence = 255
+	a.send(1, 100, 0, 16, 17, 18) // sequence 0
+	if strip[0] != (color.RGBA{16, 17, 18, 0xff}) {
+		t.Error("sequence number did not wrap around")
+	}
+
+	// A source with a lower priority is ignored.
+	b.send(1, 50, 0, 20, 20, 20)
+	if strip[0] != (color.RGBA{16, 17, 18, 0xff}) {
+		t.Error("source with lower priority was not ignored")
+	}
+	// A source with a higher priority takes over.
+	b.send(1, 150, 0, 21, 22, 23)
+	if strip[0] != (color.RGBA{21, 22, 23, 0xff}) {
+		t.Error("source with higher priority was ignored")
+	}
+	a.send(1, 100, 0, 30, 30, 30)
+	if strip[0] != (color.RGBA{21, 22, 23, 0xff}) {
+		t.Error("source with lower priority was not ignored")
+	}
+	// Until it times out.
+	a.now = now.Add(3 * time.Second)
+	a.send(1, 100, 0, 31, 32, 33)
+	if strip[0] != (color.RGBA{31, 32, 33, 0xff}) {
+		t.Error("source with higher priority did not time out")
+	}
+}
+
+func TestReceiverTerminated(t *testing.T) {
+	strip := make(ledsgo.Strip, 1)
+	r := NewReceiver(nil, dmx.MapStrip(strip, 1, ledsgo.RGB))
+	now := time.Now()
+	high := &testSender{t: t, r: r, cid: [16]byte{1}, now: now}
+	low := &testSender{t: t, r: r, cid: [16]byte{2}, now: now}
+	high.send(1, 200, 0, 1, 1, 1)
+	low.send(1, 100, 0, 2, 2, 2)
+	if strip[0].R != 1 {
+		t.Fatal("unexpected strip:", strip)
+	}
+
+	// When the high priority source terminates, the other one is used
+	// immediately.
+	buf := (&DataPacket{CID: high.cid, Priority: 200, Sequence: 10, Options: OptionTerminated, Universe: 1, Data: []byte{3, 3, 3}}).Append(nil)
+	r.HandlePacket(buf, now)
+	if strip[0].R != 1 {
+		t.Error("data in terminated packet was used")
+	}
+	low.send(1, 100, 0, 4, 4, 4)
+	if strip[0].R != 4 {
+		t.Error("terminated source still has priority")
+	}
+}
+
+func TestReceiverSync(t *testing.T) {
+	strip := make(ledsgo.Strip, 2)
+	frames := 0
+	r := NewReceiver(nil, dmx.Map{
+		{Universe: 1, Channel: 1, Strip: strip, Start: 0, Length: 1},
+		{Universe: 2, Channel: 1, Strip: strip, Start: 1, Length: 1},
+	})
+	r.OnFrame = func() {
+		frames++
+	}
+	s := &testSender{t: t, r: r, cid: testCID, now: time.Now()}
+	s.send(1, 100, 7000, 1, 2, 3)
+	s.send(2, 100, 7000, 4, 5, 6)
+	if frames != 0 || strip[0] != (color.RGBA{}) {
+		t.Error("data was used before the sync packet")
+	}
+	r.HandlePacket((&SyncPacket{CID: testCID, SyncAddress: 7000}).Append(nil), s.now)
+	if frames != 1 {
+		t.Errorf("expected 1 frame, got %d", frames)
+	}
+	if strip[0] != (color.RGBA{1, 2, 3, 0xff}) || strip[1] != (color.RGBA{4, 5, 6, 0xff}) {
+		t.Errorf("unexpected strip: %v", strip)
+	}
+
+	// Sync packets for another address don't do anything.
+	s.send(1, 100, 7000, 7, 8, 9)
+	r.HandlePacket((&SyncPacket{CID: testCID, SyncAddress: 7001}).Append(nil), s.now)
+	if frames != 1 || strip[0] != (color.RGBA{1, 2, 3, 0xff}) {
+		t.Error("sync packet for another address was used")
+	}
+}
+
+func TestReceiverLoopback(t *testing.T) {
+	strip := make(ledsgo.Strip, 200)
+	r, err := Listen("127.0.0.1:0", dmx.MapStrip(strip, 1, ledsgo.GRB))
+	if err != nil {
+		t.Fatal("could not listen:", err)
+	}
+	frames := make(chan ledsgo.Strip, 1)
+	r.OnFrame = func() {
+		frames <- append(ledsgo.Strip(nil), strip...)
+	}
+	done := make(chan error)
+	go func() {
+		done <- r.Serve()
+	}()
+
+	conn, err := net.Dial("udp", r.Addr().String())
+	if err != nil {
+		t.Fatal("could not dial:", err)
+	}
+	defer conn.Close()
+	for universe := uint16(1); universe <= 2; universe++ {
+		data := make([]byte, 510)
+		for i := range data {
+			data[i] = byte(universe)
+		}
+		_, err := conn.Write((&DataPacket{CID: testCID, Priority: 100, Universe: universe, Data: data}).Append(nil))
+		if err != nil {
+			t.Fatal("could not send packet:", err)
+		}
+	}
+
+	select {
+	case frame := <-frames:
+		if frame[0] != (color.RGBA{1, 1, 1, 0xff}) || frame[169] != (color.RGBA{1, 1, 1, 0xff}) || frame[170] != (color.RGBA{2, 2, 2, 0xff}) || frame[199] != (color.RGBA{2, 2, 2, 0xff}) {
+			t.Errorf("unexpected frame: %v", frame)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("timeout waiting for frame")
+	}
+
+	r.Close()
+	if err := <-done; err != nil {
+		t.Error("Serve returned an error:", err)
+	}
+}
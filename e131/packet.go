@@ -0,0 +1,224 @@
+// Package e131 implements the E1.31 (sACN, streaming ACN) protocol, which is
+// used by lighting consoles to send DMX512 data over UDP. It contains a parser
+// and encoder for data and synchronization packets, and a receiver that maps
+// universes onto LED strips.
+//
+// See ANSI E1.31-2018 for the specification.
+package e131
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+)
+
+// Port is the UDP port used by E1.31.
+const Port = 5568
+
+// Option bits in a data packet.
+const (
+	OptionPreview    = 0x80 // preview data, not meant for live output
+	OptionTerminated = 0x40 // the source stops sending this universe
+	OptionForceSync  = 0x20 // don't output data without synchronization
+)
+
+// Vectors used in the various layers.
+const (
+	vectorRootData     = 0x00000004
+	vectorRootExtended = 0x00000008
+	vectorFramingData  = 0x00000002
+	vectorFramingSync  = 0x00000001
+	vectorDMPSetProp   = 0x02
+)
+
+// Sizes of the packet formats.
+const (
+	dataHeaderSize = 126 // size of a data packet without DMX slots
+	syncPacketSize = 49
+)
+
+var acnPacketIdentifier = [12]byte{'A', 'S', 'C', '-', 'E', '1', '.', '1', '7', 0, 0, 0}
+
+var (
+	errShortPacket       = errors.New("e131: packet too short")
+	errInvalidIdentifier = errors.New("e131: not an ACN packet")
+	errInvalidLength     = errors.New("e131: invalid length field")
+	errUnknownVector     = errors.New("e131: unknown vector")
+	errInvalidDMP        = errors.New("e131: invalid DMP layer")
+	errTooManySlots      = errors.New("e131: more than 512 slots")
+)
+
+// Packet is either a *DataPacket or a *SyncPacket.
+type Packet interface {
+	// Append appends the binary encoding of the packet to buf and returns the
+	// extended buffer.
+	Append(buf []byte) []byte
+}
+
+// DataPacket is an E1.31 data packet, containing the DMX data of a single
+// universe.
+type DataPacket struct {
+	CID         [16]byte // unique identifier of the source
+	SourceName  string   // user readable name of the source (max 63 bytes)
+	Priority    uint8    // 0-200, default 100
+	SyncAddress uint16   // universe used for synchronization, or 0
+	Sequence    uint8
+	Options     uint8
+	Universe    uint16 // 1-63999
+	StartCode   uint8  // 0 for DMX data
+	Data        []byte // DMX slots, not including the start code
+}
+
+// SyncPacket is an E1.31 synchronization packet. It tells receivers to output
+// all data they have received for the given synchronization address.
+type SyncPacket struct {
+	CID         [16]byte
+	Sequence    uint8
+	SyncAddress uint16
+}
+
+// Parse parses an E1.31 packet. The data of a DataPacket refers to buf, so buf
+// must not be modified while the packet is in use.
+func Parse(buf []byte) (Packet, error) {
+	if len(buf) < 38 {
+		return nil, errShortPacket
+	}
+	if binary.BigEndian.Uint16(buf[0:]) != 0x0010 || binary.BigEndian.Uint16(buf[2:]) != 0 || !bytes.Equal(buf[4:16], acnPacketIdentifier[:]) {
+		return nil, errInvalidIdentifier
+	}
+	if !checkLength(buf, 16) {
+		return nil, errInvalidLength
+	}
+	var cid [16]byte
+	copy(cid[:], buf[22:38])
+
+	switch binary.BigEndian.Uint32(buf[18:]) {
+	case vectorRootData:
+		if len(buf) < dataHeaderSize {
+			return nil, errShortPacket
+		}
+		if !checkLength(buf, 38) || !checkLength(buf, 115) {
+			return nil, errInvalidLength
+		}
+		if binary.BigEndian.Uint32(buf[40:]) != vectorFramingData {
+			return nil, errUnknownVector
+		}
+		if buf[117] != vectorDMPSetProp || buf[118] != 0xa1 || binary.BigEndian.Uint16(buf[119:]) != 0 || binary.BigEndian.Uint16(buf[121:]) != 1 {
+			return nil, errInvalidDMP
+		}
+		count := int(binary.BigEndian.Uint16(buf[123:]))
+		if count < 1 || 125+count != len(buf) {
+			return nil, errInvalidDMP
+		}
+		if count > 513 {
+			return nil, errTooManySlots
+		}
+		name := buf[44:108]
+		if i := bytes.IndexByte(name, 0); i >= 0 {
+			name = name[:i]
+		}
+		return &DataPacket{
+			CID:         cid,
+			SourceName:  string(name),
+			Priority:    buf[108],
+			SyncAddress: binary.BigEndian.Uint16(buf[109:]),
+			Sequence:    buf[111],
+			Options:     buf[112],
+			Universe:    binary.BigEndian.Uint16(buf[113:]),
+			StartCode:   buf[125],
+			Data:        buf[126:],
+		}, nil
+	case vectorRootExtended:
+		if len(buf) < syncPacketSize {
+			return nil, errShortPacket
+		}
+		if !checkLength(buf, 38) {
+			return nil, errInvalidLength
+		}
+		if binary.BigEndian.Uint32(buf[40:]) != vectorFramingSync {
+			// Probably a universe discovery packet, which is not supported.
+			return nil, errUnknownVector
+		}
+		return &SyncPacket{
+			CID:         cid,
+			Sequence:    buf[44],
+			SyncAddress: binary.BigEndian.Uint16(buf[45:]),
+		}, nil
+	default:
+		return nil, errUnknownVector
+	}
+}
+
+// Check whether the flags and length field at the given offset is valid: the
+// length must cover the rest of the packet.
+func checkLength(buf []byte, offset int) bool {
+	flagsLength := binary.BigEndian.Uint16(buf[offset:])
+	return flagsLength>>12 == 0x7 && int(flagsLength&0xfff) == len(buf)-offset
+}
+
+// Store the flags and length field at the given offset, for a layer that ends
+// at the given end offset.
+func putFlagsLength(buf []byte, offset, end int) {
+	binary.BigEndian.PutUint16(buf[offset:], 0x7000|uint16(end-offset))
+}
+
+// Append appends the binary encoding of the data packet to buf and returns the
+// extended buffer. The data must not be longer than 512 bytes.
+func (p *DataPacket) Append(buf []byte) []byte {
+	start := len(buf)
+	size := dataHeaderSize + len(p.Data)
+	buf = append(buf, make([]byte, size)...)
+	b := buf[start:]
+	putRootLayer(b, vectorRootData, p.CID)
+	putFlagsLength(b, 38, size)
+	binary.BigEndian.PutUint32(b[40:], vectorFramingData)
+	copy(b[44:107], p.SourceName) // the last byte is always zero
+	b[108] = p.Priority
+	binary.BigEndian.PutUint16(b[109:], p.SyncAddress)
+	b[111] = p.Sequence
+	b[112] = p.Options
+	binary.BigEndian.PutUint16(b[113:], p.Universe)
+	putFlagsLength(b, 115, size)
+	b[117] = vectorDMPSetProp
+	b[118] = 0xa1 // address type and data type
+	binary.BigEndian.PutUint16(b[119:], 0)
+	binary.BigEndian.PutUint16(b[121:], 1)
+	binary.BigEndian.PutUint16(b[123:], uint16(1+len(p.Data)))
+	b[125] = p.StartCode
+	copy(b[126:], p.Data)
+	return buf
+}
+
+// Append appends the binary encoding of the synchronization packet to buf and
+// returns the extended buffer.
+func (p *SyncPacket) Append(buf []byte) []byte {
+	start := len(buf)
+	buf = append(buf, make([]byte, syncPacketSize)...)
+	b := buf[start:]
+	putRootLayer(b, vectorRootExtended, p.CID)
+	putFlagsLength(b, 38, syncPacketSize)
+	binary.BigEndian.PutUint32(b[40:], vectorFramingSync)
+	b[44] = p.Sequence
+	binary.BigEndian.PutUint16(b[45:], p.SyncAddress)
+	return buf
+}
+
+// Write the root layer to the start of b, which must be the whole packet.
+func putRootLayer(b []byte, vector uint32, cid [16]byte) {
+	binary.BigEndian.PutUint16(b[0:], 0x0010) // preamble size
+	binary.BigEndian.PutUint16(b[2:], 0)      // postamble size
+	copy(b[4:16], acnPacketIdentifier[:])
+	putFlagsLength(b, 16, len(b))
+	binary.BigEndian.PutUint32(b[18:], vector)
+	copy(b[22:38], cid[:])
+}
+
+// MulticastAddr returns the multicast address for the given universe, which is
+// 239.255.x.y where x and y are the high and low byte of the universe.
+func MulticastAddr(universe uint16) *net.UDPAddr {
+	return &net.UDPAddr{
+		IP:   net.IPv4(239, 255, byte(universe>>8), byte(universe)),
+		Port: Port,
+	}
+}
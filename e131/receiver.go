@@ -0,0 +1,210 @@
+package e131
+
+import (
+	"errors"
+	"net"
+	"time"
+
+	"github.com/aykevl/ledsgo/dmx"
+)
+
+// Sources that haven't sent any data for this long are considered to be gone,
+// as specified by E1.31 (E131_NETWORK_DATA_LOSS_TIMEOUT).
+const sourceTimeout = 2500 * time.Millisecond
+
+// Receiver receives E1.31 data and copies it into the strips of a DMX map.
+//
+// It follows the E1.31 rules for receivers: packets that arrive out of order
+// are dropped, and when multiple sources send the same universe only the
+// sources with the highest priority are used. Sources that stop sending are
+// forgotten after 2.5 seconds. If multiple sources send data with the same
+// priority, the most recent packet wins.
+//
+// A frame is complete when all universes in the map have been received since
+// the previous frame, or when a synchronization packet arrives for universes
+// that use synchronization. In both cases, the OnFrame callback is called.
+type Receiver struct {
+	// OnFrame is called when a frame is complete, with all strips updated. It
+	// is called from the goroutine running Serve, so the strips are not
+	// modified while it runs.
+	OnFrame func()
+
+	conn      net.PacketConn
+	mapping   dmx.Map
+	universes []uint16
+	sources   map[uint16]map[[16]byte]*source // indexed by universe and CID
+	received  map[uint16]bool                 // universes received since the last frame
+	pending   map[uint16]map[uint16][]byte    // data waiting for a sync packet, indexed by sync address and universe
+}
+
+// State of a single source for a single universe.
+type source struct {
+	priority uint8
+	sequence uint8
+	lastSeen time.Time
+}
+
+// NewReceiver creates a new receiver that reads packets from the given
+// connection. The connection can be a unicast or a multicast UDP connection
+// (see MulticastAddr).
+func NewReceiver(conn net.PacketConn, mapping dmx.Map) *Receiver {
+	return &Receiver{
+		conn:      conn,
+		mapping:   mapping,
+		universes: mapping.Universes(),
+		sources:   make(map[uint16]map[[16]byte]*source),
+		received:  make(map[uint16]bool),
+		pending:   make(map[uint16]map[uint16][]byte),
+	}
+}
+
+// Listen creates a new receiver listening on the given UDP address, for
+// example ":5568" for unicast E1.31 on all interfaces.
+func Listen(address string, mapping dmx.Map) (*Receiver, error) {
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenPacket("udp", address)
+	if err != nil {
+		return nil, err
+	}
+	return NewReceiver(conn, mapping), nil
+}
+
+// Addr returns the local address of the receiver.
+func (r *Receiver) Addr() net.Addr {
+	return r.conn.LocalAddr()
+}
+
+// Serve receives packets until the receiver is closed. Invalid packets are
+// ignored. It returns nil after Close is called.
+func (r *Receiver) Serve() error {
+	buf := make([]byte, 1500)
+	for {
+		n, _, err := r.conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		r.HandlePacket(buf[:n], time.Now())
+	}
+}
+
+// Close stops the receiver and closes the underlying connection.
+func (r *Receiver) Close() error {
+	return r.conn.Close()
+}
+
+// HandlePacket processes a single E1.31 packet received at the given time.
+// This is normally done by Serve, but can be used directly if the packets are
+// received in another way. It must not be called concurrently with Serve.
+func (r *Receiver) HandlePacket(buf []byte, now time.Time) error {
+	packet, err := Parse(buf)
+	if err != nil {
+		return err
+	}
+	switch packet := packet.(type) {
+	case *DataPacket:
+		r.handleData(packet, now)
+	case *SyncPacket:
+		r.handleSync(packet)
+	}
+	return nil
+}
+
+func (r *Receiver) handleData(packet *DataPacket, now time.Time) {
+	if !r.mapping.Contains(packet.Universe) {
+		return // not interested in this universe
+	}
+	if packet.Options&OptionPreview != 0 {
+		return // preview data is not meant for LEDs
+	}
+
+	sources := r.sources[packet.Universe]
+	if sources == nil {
+		sources = make(map[[16]byte]*source)
+		r.sources[packet.Universe] = sources
+	}
+	for cid, src := range sources {
+		if now.Sub(src.lastSeen) > sourceTimeout {
+			delete(sources, cid)
+		}
+	}
+
+	src := sources[packet.CID]
+	if src != nil {
+		// Drop packets that are older than the last received packet, taking
+		// into account that sequence numbers wrap around.
+		diff := int8(packet.Sequence - src.sequence)
+		if diff <= 0 && diff > -20 {
+			return
+		}
+	}
+	if packet.Options&OptionTerminated != 0 {
+		// The data in a terminated packet must be ignored.
+		delete(sources, packet.CID)
+		return
+	}
+	if src == nil {
+		src = &source{}
+		sources[packet.CID] = src
+	}
+	src.priority = packet.Priority
+	src.sequence = packet.Sequence
+	src.lastSeen = now
+
+	if packet.StartCode != 0 {
+		return // not DMX data (for example, per-channel priorities)
+	}
+
+	// Only use data from the sources with the highest priority.
+	for _, other := range sources {
+		if other.priority > packet.Priority {
+			return
+		}
+	}
+
+	if packet.SyncAddress != 0 {
+		// Keep the data until the sync packet arrives.
+		universes := r.pending[packet.SyncAddress]
+		if universes == nil {
+			universes = make(map[uint16][]byte)
+			r.pending[packet.SyncAddress] = universes
+		}
+		universes[packet.Universe] = append(universes[packet.Universe][:0], packet.Data...)
+		return
+	}
+
+	r.mapping.Apply(packet.Universe, packet.Data)
+	r.received[packet.Universe] = true
+	for _, universe := range r.universes {
+		if !r.received[universe] {
+			return // frame is not yet complete
+		}
+	}
+	r.frameDone()
+}
+
+func (r *Receiver) handleSync(packet *SyncPacket) {
+	universes := r.pending[packet.SyncAddress]
+	if len(universes) == 0 {
+		return
+	}
+	for universe, data := range universes {
+		r.mapping.Apply(universe, data)
+		delete(universes, universe)
+	}
+	r.frameDone()
+}
+
+// Called when a complete frame has been received.
+func (r *Receiver) frameDone() {
+	for universe := range r.received {
+		delete(r.received, universe)
+	}
+	if r.OnFrame != nil {
+		r.OnFrame()
+	}
+}
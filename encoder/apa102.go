@@ -0,0 +1,157 @@
+package encoder
+
+import (
+	"image/color"
+
+	"github.com/aykevl/ledsgo"
+)
+
+// APA102 encodes colors for the APA102 SPI LED chip (also sold as DotStar).
+// The data starts with a start frame of 32 zero bits, followed by 4 bytes per
+// LED: 3 marker bits and a 5-bit global brightness, followed by blue, green
+// and red. It ends with an end frame of at least half a clock pulse per LED,
+// to push the data through to the last LED.
+//
+// The Brightness is the 5-bit global brightness (0-31) used for every LED. It
+// controls the LED current, so 31 is the normal setting. A value of 0 turns
+// all LEDs off.
+type APA102 struct {
+	Brightness uint8
+}
+
+// Encode implements the Encoder interface.
+func (e APA102) Encode(buf []byte, strip ledsgo.Strip) []byte {
+	buf = appendBytes(buf, 0x00, 4) // start frame
+	buf = appendLEDFrames(buf, strip, e.Brightness)
+	return appendBytes(buf, 0xff, (len(strip)+15)/16) // end frame
+}
+
+// SK9822 encodes colors for the SK9822 SPI LED chip, which is a clone of the
+// APA102. The data is the same, except that there is a reset frame of 32 zero
+// bits after the LED data, and the end frame is made of zero bits: the SK9822
+// only applies the new colors after the reset frame. This byte stream also
+// works for the APA102.
+//
+// The Brightness is the 5-bit global brightness (0-31), see APA102.
+type SK9822 struct {
+	Brightness uint8
+}
+
+// Encode implements the Encoder interface.
+func (e SK9822) Encode(buf []byte, strip ledsgo.Strip) []byte {
+	buf = appendBytes(buf, 0x00, 4) // start frame
+	buf = appendLEDFrames(buf, strip, e.Brightness)
+	buf = appendBytes(buf, 0x00, 4)                   // reset frame
+	return appendBytes(buf, 0x00, (len(strip)+15)/16) // end frame
+}
+
+// appendLEDFrames appends the 4-byte LED frames for APA102 compatible LEDs.
+func appendLEDFrames(buf []byte, strip ledsgo.Strip, brightness uint8) []byte {
+	buf, data := grow(buf, len(strip)*4)
+	header := 0xe0 | brightness&0x1f
+	for i, c := range strip {
+		data[i*4+0] = header
+		data[i*4+1] = c.B
+		data[i*4+2] = c.G
+		data[i*4+3] = c.R
+	}
+	return buf
+}
+
+// APA102HDR encodes colors for APA102 and SK9822 LEDs with a higher dynamic
+// range than the 8 bits per channel of a Strip. It combines the 5-bit global
+// brightness field with the 8-bit PWM values, which gives about 13 bits of
+// dynamic range: for every LED, the lowest brightness that can still show the
+// brightest channel is picked so that dim colors keep their precision.
+//
+// Both Encode and EncodeRGBA64 scale the colors by Scale, where 0xffff is full
+// brightness. A Scale of 0 is also full brightness, so that the zero value can
+// be used directly.
+//
+// Note that colors are assumed to be linear (like a Strip normally is), the
+// brightness field has a linear effect on the LED output.
+type APA102HDR struct {
+	Scale  uint16
+	SK9822 bool // use SK9822 framing (also works for the APA102)
+}
+
+// Encode implements the Encoder interface.
+func (e APA102HDR) Encode(buf []byte, strip ledsgo.Strip) []byte {
+	buf = appendBytes(buf, 0x00, 4) // start frame
+	buf, data := grow(buf, len(strip)*4)
+	scale := e.scale()
+	for i, c := range strip {
+		// Convert to 16 bits: c * 0x101 * scale / 0xffff = c * scale / 0xff
+		r := uint16(uint32(c.R) * scale / 0xff)
+		g := uint16(uint32(c.G) * scale / 0xff)
+		b := uint16(uint32(c.B) * scale / 0xff)
+		putAPA102HDR(data[i*4:], r, g, b)
+	}
+	return e.appendEnd(buf, len(strip))
+}
+
+// EncodeRGBA64 appends the byte stream for the given 16-bit colors to buf and
+// returns the extended buffer. The alpha channel is ignored.
+func (e APA102HDR) EncodeRGBA64(buf []byte, colors []color.RGBA64) []byte {
+	buf = appendBytes(buf, 0x00, 4) // start frame
+	buf, data := grow(buf, len(colors)*4)
+	scale := e.scale()
+	for i, c := range colors {
+		r := uint16(uint32(c.R) * scale / 0xffff)
+		g := uint16(uint32(c.G) * scale / 0xffff)
+		b := uint16(uint32(c.B) * scale / 0xffff)
+		putAPA102HDR(data[i*4:], r, g, b)
+	}
+	return e.appendEnd(buf, len(colors))
+}
+
+// scale returns the scale to use, where 0 means full brightness.
+func (e APA102HDR) scale() uint32 {
+	if e.Scale == 0 {
+		return 0xffff
+	}
+	return uint32(e.Scale)
+}
+
+// appendEnd appends the frames after the LED data.
+func (e APA102HDR) appendEnd(buf []byte, numLEDs int) []byte {
+	if e.SK9822 {
+		buf = appendBytes(buf, 0x00, 4)                // reset frame
+		return appendBytes(buf, 0x00, (numLEDs+15)/16) // end frame
+	}
+	return appendBytes(buf, 0xff, (numLEDs+15)/16) // end frame
+}
+
+// putAPA102HDR stores the LED frame for the given 16-bit color in the first
+// four bytes of dst. It picks the lowest 5-bit brightness that can still
+// represent the brightest channel, and scales the PWM values accordingly.
+func putAPA102HDR(dst []byte, r, g, b uint16) {
+	max := r
+	if g > max {
+		max = g
+	}
+	if b > max {
+		max = b
+	}
+
+	// Lowest brightness where max*31/brightness still fits in 0xffff.
+	brightness := (uint32(max)*31 + 0xfffe) / 0xffff
+
+	if brightness == 0 {
+		// Black: the brightness doesn't matter.
+		dst[0] = 0xe0
+		dst[1] = 0
+		dst[2] = 0
+		dst[3] = 0
+		return
+	}
+
+	// The PWM value for a channel c is c/0xffff * 0xff * 31/brightness,
+	// rounded to the nearest integer. This never exceeds 0xff because
+	// max*31/brightness <= 0xffff.
+	divisor := 0x101 * brightness
+	dst[0] = 0xe0 | uint8(brightness)
+	dst[1] = uint8((uint32(b)*31 + divisor/2) / divisor)
+	dst[2] = uint8((uint32(g)*31 + divisor/2) / divisor)
+	dst[3] = uint8((uint32(r)*31 + divisor/2) / divisor)
+}
@@ -0,0 +1,154 @@
+package encoder
+
+import (
+	"bytes"
+	"image/color"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/aykevl/ledsgo"
+)
+
+// Return the light output of a channel of an APA102 LED frame, as a fraction
+// of the maximum output.
+func apa102Output(header, pwm byte) float64 {
+	return float64(header&0x1f) / 31 * float64(pwm) / 255
+}
+
+func TestAPA102HDRError(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	const numTests = 100000
+	maxRelErr := 0.0
+	for i := 0; i < numTests; i++ {
+		// Pick colors across the whole dynamic range, with most of them
+		// being dim.
+		var c color.RGBA64
+		shift := uint(r.Intn(16))
+		c.R = uint16(r.Uint32()) >> shift
+		c.G = uint16(r.Uint32()) >> shift
+		c.B = uint16(r.Uint32()) >> shift
+		out := APA102HDR{}.EncodeRGBA64(nil, []color.RGBA64{c})
+		frame := out[4:8]
+		if frame[0]&0xe0 != 0xe0 {
+			t.Fatalf("%v: invalid frame header: %02x", c, frame[0])
+		}
+		brightness := frame[0] & 0x1f
+		for j, pair := range [][2]uint16{{c.B, uint16(frame[1])}, {c.G, uint16(frame[2])}, {c.R, uint16(frame[3])}} {
+			expected := float64(pair[0]) / 0xffff
+			actual := apa102Output(frame[0], byte(pair[1]))
+
+			// The error must be at most half a PWM step at the chosen
+			// brightness.
+			step := float64(brightness) / 31 / 255
+			if diff := math.Abs(expected - actual); diff > step/2+1e-9 {
+				t.Errorf("%v channel %d: expected %f, got %f (diff %f, step %f)", c, j, expected, actual, diff, step)
+			}
+		}
+
+		// Unless the lowest brightness is used, the brightest channel must
+		// keep at least 7 bits of precision: that's only possible if the
+		// lowest possible brightness is picked.
+		max := c.R
+		if c.G > max {
+			max = c.G
+		}
+		if c.B > max {
+			max = c.B
+		}
+		if brightness > 1 {
+			maxPWM := frame[1]
+			if frame[2] > maxPWM {
+				maxPWM = frame[2]
+			}
+			if frame[3] > maxPWM {
+				maxPWM = frame[3]
+			}
+			if maxPWM < 0x80 {
+				t.Errorf("%v: brightest channel only uses PWM value %d at brightness %d", c, maxPWM, brightness)
+			}
+			relErr := math.Abs(float64(max)/0xffff-apa102Output(frame[0], maxPWM)) / (float64(max) / 0xffff)
+			if relErr > maxRelErr {
+				maxRelErr = relErr
+			}
+		}
+	}
+	t.Logf("max relative error of the brightest channel: %f", maxRelErr)
+	if maxRelErr > 0.004 {
+		t.Errorf("relative error too high: %f", maxRelErr)
+	}
+}
+
+func TestAPA102HDRDimColors(t *testing.T) {
+	// Dim colors would all be rounded to 0 with only 8 bits, but can be
+	// represented with the lowest brightness setting.
+	c := color.RGBA64{R: 3000, G: 500, B: 100}
+	out := APA102HDR{}.EncodeRGBA64(nil, []color.RGBA64{c})
+	expected := []byte{0x00, 0x00, 0x00, 0x00, 0xe2, 6, 30, 181, 0xff}
+	if !bytes.Equal(out, expected) {
+		t.Errorf("unexpected output\nexpected: % x\nactual:   % x", expected, out)
+	}
+}
+
+func TestAPA102HDR(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoder APA102HDR
+		out     []byte
+	}{
+		{"full", APA102HDR{Scale: 0xffff}, []byte{
+			0x00, 0x00, 0x00, 0x00, // start frame
+			0xe0 | 7, 0xe2, 0x97, 0x4b, // 0x33/0xff*31 = 6.2 => brightness 7
+			0xff, 0x01, 0x80, 0xff,
+			0xff, // end frame
+		}},
+		{"zero value", APA102HDR{}, []byte{
+			0x00, 0x00, 0x00, 0x00, // start frame
+			0xe0 | 7, 0xe2, 0x97, 0x4b,
+			0xff, 0x01, 0x80, 0xff,
+			0xff, // end frame
+		}},
+		{"SK9822", APA102HDR{Scale: 0xffff, SK9822: true}, []byte{
+			0x00, 0x00, 0x00, 0x00, // start frame
+			0xe0 | 7, 0xe2, 0x97, 0x4b,
+			0xff, 0x01, 0x80, 0xff,
+			0x00, 0x00, 0x00, 0x00, // reset frame
+			0x00, // end frame
+		}},
+	}
+	for _, tc := range tests {
+		out := tc.encoder.Encode(nil, testStrip)
+		if !bytes.Equal(out, tc.out) {
+			t.Errorf("%s: unexpected output\nexpected: % x\nactual:   % x", tc.name, tc.out, out)
+		}
+	}
+}
+
+func TestAPA102HDRScale(t *testing.T) {
+	// Encoding a strip with a scale should give the same result as encoding
+	// the equivalent 16-bit colors.
+	strip := ledsgo.Strip{{R: 0xff, G: 0x80, B: 0x01}, {R: 0x12, G: 0x34, B: 0x56}}
+	const scale = 0x1234
+	colors := make([]color.RGBA64, len(strip))
+	for i, c := range strip {
+		colors[i] = color.RGBA64{
+			R: uint16(uint32(c.R) * 0x101 * scale / 0xffff),
+			G: uint16(uint32(c.G) * 0x101 * scale / 0xffff),
+			B: uint16(uint32(c.B) * 0x101 * scale / 0xffff),
+		}
+	}
+	out1 := APA102HDR{Scale: scale}.Encode(nil, strip)
+	out2 := APA102HDR{}.EncodeRGBA64(nil, colors)
+	if !bytes.Equal(out1, out2) {
+		t.Errorf("Encode and EncodeRGBA64 differ:\nEncode:       % x\nEncodeRGBA64: % x", out1, out2)
+	}
+
+	// EncodeRGBA64 applies the same scale.
+	for i, c := range strip {
+		colors[i] = color.RGBA64{R: uint16(c.R) * 0x101, G: uint16(c.G) * 0x101, B: uint16(c.B) * 0x101}
+	}
+	out3 := APA102HDR{Scale: scale}.EncodeRGBA64(nil, colors)
+	if !bytes.Equal(out1, out3) {
+		t.Errorf("Encode and EncodeRGBA64 scale differently:\nEncode:       % x\nEncodeRGBA64: % x", out1, out3)
+	}
+}
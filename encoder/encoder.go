@@ -0,0 +1,53 @@
+// Package encoder converts a LED strip to the exact byte stream expected by
+// common addressable LED chips. It does not drive any hardware: drivers (such
+// as the ones in TinyGo) only need to send the resulting bytes over SPI or a
+// bit-banged one-wire protocol.
+//
+// All encoders append to a byte slice so that a buffer can be reused between
+// frames without allocating memory.
+package encoder
+
+import "github.com/aykevl/ledsgo"
+
+// Encoder is the interface implemented by all LED chip encoders.
+type Encoder interface {
+	// Encode appends the byte stream for the given strip to buf and returns
+	// the extended buffer. The result can be sent directly to the LED strip.
+	Encode(buf []byte, strip ledsgo.Strip) []byte
+}
+
+// ColorOrder is the order in which color channels are sent to the LED chip.
+// It is an alias for ledsgo.ColorOrder, for convenience.
+type ColorOrder = ledsgo.ColorOrder
+
+// The three-channel color orders. See ledsgo.ColorOrder for the RGBW variants.
+const (
+	RGB = ledsgo.RGB
+	RBG = ledsgo.RBG
+	GRB = ledsgo.GRB
+	GBR = ledsgo.GBR
+	BRG = ledsgo.BRG
+	BGR = ledsgo.BGR
+)
+
+// grow extends buf by n bytes and returns the extended buffer and the newly
+// added part of it.
+func grow(buf []byte, n int) ([]byte, []byte) {
+	start := len(buf)
+	if cap(buf)-start < n {
+		newBuf := make([]byte, start, start+n)
+		copy(newBuf, buf)
+		buf = newBuf
+	}
+	buf = buf[:start+n]
+	return buf, buf[start:]
+}
+
+// appendBytes appends n copies of the byte b to buf.
+func appendBytes(buf []byte, b byte, n int) []byte {
+	buf, data := grow(buf, n)
+	for i := range data {
+		data[i] = b
+	}
+	return buf
+}
@@ -0,0 +1,137 @@
+package encoder
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aykevl/ledsgo"
+)
+
+var testStrip = ledsgo.Strip{
+	{R: 0x11, G: 0x22, B: 0x33, A: 0xff},
+	{R: 0xff, G: 0x80, B: 0x01, A: 0xff},
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoder Encoder
+		strip   ledsgo.Strip
+		out     []byte
+	}{
+		{"WS2812-GRB", WS2812{Order: GRB}, testStrip, []byte{
+			0x22, 0x11, 0x33,
+			0x80, 0xff, 0x01,
+		}},
+		{"WS2812-RGB", WS2812{Order: RGB}, testStrip, []byte{
+			0x11, 0x22, 0x33,
+			0xff, 0x80, 0x01,
+		}},
+		{"WS2812-BRG", WS2812{Order: BRG}, testStrip, []byte{
+			0x33, 0x11, 0x22,
+			0x01, 0xff, 0x80,
+		}},
+		{"WS2812-empty", WS2812{Order: GRB}, nil, []byte{}},
+		{"WS2801", WS2801{}, testStrip, []byte{
+			0x11, 0x22, 0x33,
+			0xff, 0x80, 0x01,
+		}},
+		{"SK6812RGBW", SK6812RGBW{Order: GRB}, testStrip, []byte{
+			0x22, 0x11, 0x33, 0x00,
+			0x80, 0xff, 0x01, 0x00,
+		}},
+		{"SK6812RGBW-white", SK6812RGBW{Order: GRB, ExtractWhite: true}, testStrip, []byte{
+			0x11, 0x00, 0x22, 0x11,
+			0x7f, 0xfe, 0x00, 0x01,
+		}},
+		{"SK6812RGBW-GRBW", SK6812RGBW{Order: ledsgo.GRBW}, testStrip, []byte{
+			0x22, 0x11, 0x33, 0x00,
+			0x80, 0xff, 0x01, 0x00,
+		}},
+		{"SK6812RGBW-GRBW-white", SK6812RGBW{Order: ledsgo.GRBW, ExtractWhite: true}, testStrip, []byte{
+			0x11, 0x00, 0x22, 0x11,
+			0x7f, 0xfe, 0x00, 0x01,
+		}},
+		{"APA102", APA102{Brightness: 31}, testStrip, []byte{
+			0x00, 0x00, 0x00, 0x00, // start frame
+			0xff, 0x33, 0x22, 0x11,
+			0xff, 0x01, 0x80, 0xff,
+			0xff, // end frame
+		}},
+		{"APA102-dim", APA102{Brightness: 0x23}, ledsgo.Strip{{R: 1, G: 2, B: 3}}, []byte{
+			0x00, 0x00, 0x00, 0x00, // start frame
+			0xe3, 0x03, 0x02, 0x01,
+			0xff, // end frame
+		}},
+		{"APA102-empty", APA102{Brightness: 31}, nil, []byte{
+			0x00, 0x00, 0x00, 0x00, // start frame
+		}},
+		{"SK9822", SK9822{Brightness: 31}, testStrip, []byte{
+			0x00, 0x00, 0x00, 0x00, // start frame
+			0xff, 0x33, 0x22, 0x11,
+			0xff, 0x01, 0x80, 0xff,
+			0x00, 0x00, 0x00, 0x00, // reset frame
+			0x00, // end frame
+		}},
+		{"LPD8806", LPD8806{}, testStrip, []byte{
+			0x91, 0x88, 0x99,
+			0xc0, 0xff, 0x80,
+			0x00, // latch
+		}},
+		{"TM1814", TM1814{WhiteCurrent: 63, RedCurrent: 10, GreenCurrent: 20, BlueCurrent: 0x40 | 30}, testStrip, []byte{
+			0x3f, 0x0a, 0x14, 0x1e, // current settings
+			0xc0, 0xf5, 0xeb, 0xe1, // inverted current settings
+			0x00, 0x11, 0x22, 0x33,
+			0x00, 0xff, 0x80, 0x01,
+		}},
+		{"TM1814-white", TM1814{ExtractWhite: true}, testStrip, []byte{
+			0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff,
+			0x11, 0x00, 0x11, 0x22,
+			0x01, 0xfe, 0x7f, 0x00,
+		}},
+	}
+	for _, tc := range tests {
+		out := tc.encoder.Encode(nil, tc.strip)
+		if !bytes.Equal(out, tc.out) {
+			t.Errorf("%s: unexpected output\nexpected: % x\nactual:   % x", tc.name, tc.out, out)
+		}
+
+		// Encoders must append to the buffer.
+		prefix := []byte{0xaa, 0xbb}
+		out = tc.encoder.Encode(prefix, tc.strip)
+		if !bytes.Equal(out[:2], prefix) || !bytes.Equal(out[2:], tc.out) {
+			t.Errorf("%s: encoder did not append to the buffer: % x", tc.name, out)
+		}
+	}
+}
+
+func TestLatchLength(t *testing.T) {
+	// The end frames must grow with the length of the strip.
+	tests := []struct {
+		name     string
+		encoder  Encoder
+		leds     int
+		expected int
+	}{
+		{"APA102", APA102{}, 16, 4 + 16*4 + 1},
+		{"APA102", APA102{}, 17, 4 + 17*4 + 2},
+		{"SK9822", SK9822{}, 100, 4 + 100*4 + 4 + 7},
+		{"LPD8806", LPD8806{}, 32, 32*3 + 1},
+		{"LPD8806", LPD8806{}, 33, 33*3 + 2},
+	}
+	for _, tc := range tests {
+		out := tc.encoder.Encode(nil, make(ledsgo.Strip, tc.leds))
+		if len(out) != tc.expected {
+			t.Errorf("%s with %d LEDs: expected %d bytes, got %d", tc.name, tc.leds, tc.expected, len(out))
+		}
+	}
+}
+
+func BenchmarkWS2812(b *testing.B) {
+	strip := make(ledsgo.Strip, 300)
+	buf := make([]byte, 0, len(strip)*3)
+	for i := 0; i < b.N; i++ {
+		buf = WS2812{Order: GRB}.Encode(buf[:0], strip)
+	}
+}
@@ -0,0 +1,20 @@
+package encoder
+
+import "github.com/aykevl/ledsgo"
+
+// LPD8806 encodes colors for the LPD8806 SPI LED chip. Every LED takes 3
+// bytes in GRB order. Only 7 bits per channel are used: the top bit of each
+// byte is always set to mark it as color data. After the LED data, a latch of
+// one zero byte per 32 LEDs resets the strip for the next frame.
+type LPD8806 struct{}
+
+// Encode implements the Encoder interface.
+func (e LPD8806) Encode(buf []byte, strip ledsgo.Strip) []byte {
+	buf, data := grow(buf, len(strip)*3)
+	for i, c := range strip {
+		data[i*3+0] = 0x80 | c.G>>1
+		data[i*3+1] = 0x80 | c.R>>1
+		data[i*3+2] = 0x80 | c.B>>1
+	}
+	return appendBytes(buf, 0x00, (len(strip)+31)/32) // latch
+}
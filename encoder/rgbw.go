@@ -0,0 +1,80 @@
+package encoder
+
+import "github.com/aykevl/ledsgo"
+
+// SK6812RGBW encodes colors for the SK6812 RGBW one-wire LED chip (also sold
+// as RGBW NeoPixels). Every LED takes 4 bytes: the three color channels in the
+// given three-channel color order (usually GRB) followed by the white channel.
+// RGBW orders are treated like their three-channel variant, so GRBW is the
+// same as GRB.
+//
+// Because a Strip only stores RGB colors, the white channel is derived from
+// the color when ExtractWhite is set: the part common to all three color
+// channels is sent to the white LED instead. Otherwise the white LED stays
+// off.
+type SK6812RGBW struct {
+	Order        ColorOrder
+	ExtractWhite bool
+}
+
+// Encode implements the Encoder interface.
+func (e SK6812RGBW) Encode(buf []byte, strip ledsgo.Strip) []byte {
+	buf, data := grow(buf, len(strip)*4)
+	order := e.Order.WithoutWhite()
+	for i, c := range strip {
+		var w uint8
+		if e.ExtractWhite {
+			c, w = ledsgo.ExtractWhite(c)
+		}
+		order.Put(data[i*4:], c)
+		data[i*4+3] = w
+	}
+	return buf
+}
+
+// TM1814 encodes colors for the TM1814 RGBW one-wire LED chip. The data starts
+// with the constant current settings for each channel, followed by 4 bytes
+// per LED in the order white, red, green, blue. The white channel is derived
+// from the color like for SK6812RGBW.
+//
+// The current settings are 6-bit values (0-63), where the current is
+// 6.5mA + 0.5mA * setting. Note that the zero value therefore means the lowest
+// current, not that the LEDs are off.
+//
+// The TM1814 uses inverted signal levels compared to the WS2812. Inverting
+// the signal is up to the driver, this encoder only produces the bytes.
+type TM1814 struct {
+	WhiteCurrent uint8
+	RedCurrent   uint8
+	GreenCurrent uint8
+	BlueCurrent  uint8
+	ExtractWhite bool
+}
+
+// Encode implements the Encoder interface.
+func (e TM1814) Encode(buf []byte, strip ledsgo.Strip) []byte {
+	buf, data := grow(buf, 8+len(strip)*4)
+
+	// The current settings are sent twice: once normally and once inverted,
+	// as a checksum.
+	data[0] = e.WhiteCurrent & 0x3f
+	data[1] = e.RedCurrent & 0x3f
+	data[2] = e.GreenCurrent & 0x3f
+	data[3] = e.BlueCurrent & 0x3f
+	for i := 0; i < 4; i++ {
+		data[i+4] = ^data[i]
+	}
+
+	data = data[8:]
+	for i, c := range strip {
+		var w uint8
+		if e.ExtractWhite {
+			c, w = ledsgo.ExtractWhite(c)
+		}
+		data[i*4+0] = w
+		data[i*4+1] = c.R
+		data[i*4+2] = c.G
+		data[i*4+3] = c.B
+	}
+	return buf
+}
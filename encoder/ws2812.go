@@ -0,0 +1,32 @@
+package encoder
+
+import "github.com/aykevl/ledsgo"
+
+// WS2812 encodes colors for the WS2812, WS2812B, WS2811 and compatible
+// one-wire LED chips. Every LED takes 3 bytes, sent in the given color order.
+// The strip is latched by keeping the data line low for a while after the
+// data, which is up to the driver.
+//
+// Most WS2812 LEDs use the GRB order, while WS2811 chips are often wired as
+// RGB. RGBW orders can be used for RGBW chips with the same protocol, in which
+// case every LED takes 4 bytes.
+type WS2812 struct {
+	Order ColorOrder
+}
+
+// Encode implements the Encoder interface.
+func (e WS2812) Encode(buf []byte, strip ledsgo.Strip) []byte {
+	return strip.AppendBytes(buf, e.Order)
+}
+
+// WS2801 encodes colors for the WS2801 SPI LED chip. Every LED takes 3 bytes,
+// sent in the given color order (usually RGB). The strip is latched by
+// keeping the clock line low for at least 500µs after the data.
+type WS2801 struct {
+	Order ColorOrder
+}
+
+// Encode implements the Encoder interface.
+func (e WS2801) Encode(buf []byte, strip ledsgo.Strip) []byte {
+	return strip.AppendBytes(buf, e.Order)
+}
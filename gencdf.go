@@ -0,0 +1,72 @@
+// +build none
+
+// This file is used in `go generate` to update noisecdf.go. It samples the
+// noise functions at random positions and stores the cumulative distribution
+// of the output values in lookup tables.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"math/rand"
+	"os"
+
+	"github.com/aykevl/ledsgo"
+)
+
+const numSamples = 1 << 24
+
+func main() {
+	r := rand.New(rand.NewSource(0))
+	buf := &bytes.Buffer{}
+	buf.WriteString("// Code generated by gencdf.go; DO NOT EDIT.\n\npackage ledsgo\n\n")
+	writeTable(buf, "noise1CDF", func() uint16 {
+		return ledsgo.Noise1(r.Uint32())
+	})
+	writeTable(buf, "noise2CDF", func() uint16 {
+		return ledsgo.Noise2(r.Uint32(), r.Uint32())
+	})
+	writeTable(buf, "noise3CDF", func() uint16 {
+		return ledsgo.Noise3(r.Uint32(), r.Uint32(), r.Uint32())
+	})
+	writeTable(buf, "noise4CDF", func() uint16 {
+		return ledsgo.Noise4(r.Uint32(), r.Uint32(), r.Uint32(), r.Uint32())
+	})
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to format source:", err)
+		os.Exit(1)
+	}
+	err = ioutil.WriteFile("noisecdf.go", src, 0666)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to write noisecdf.go:", err)
+		os.Exit(1)
+	}
+}
+
+// writeTable samples the noise function and writes the resulting lookup table
+// to buf.
+func writeTable(buf *bytes.Buffer, name string, noise func() uint16) {
+	var histogram [0x10000]int
+	for i := 0; i < numSamples; i++ {
+		histogram[noise()]++
+	}
+	fmt.Fprintf(buf, "var %s = [cdfTableSize + 1]uint16{", name)
+	sum := 0
+	for i := 0; i <= 0x10000; i++ {
+		if i%0x200 == 0 {
+			if i%(0x200*8) == 0 {
+				buf.WriteString("\n")
+			}
+			// Fraction of samples that are below i, scaled to 0..0xffff.
+			fmt.Fprintf(buf, "%d, ", (sum*0xffff+numSamples/2)/numSamples)
+		}
+		if i < 0x10000 {
+			sum += histogram[i]
+		}
+	}
+	buf.WriteString("\n}\n\n")
+}
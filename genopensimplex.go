@@ -0,0 +1,229 @@
+// +build none
+
+// This file is used in `go generate` to update opensimplextables.go. It
+// calculates the 4D gradients of the OpenSimplex2 reference implementation and
+// the lattice vertices that may be within range of OpenSimplexSmooth4.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"math"
+	"os"
+)
+
+const (
+	g4 = 0.1381966011250105 // (5-sqrt(5))/20: unskew factor of the 4D simplex lattice
+	r2 = 0.8                // squared kernel radius of OpenSimplexSmooth4
+)
+
+func main() {
+	buf := &bytes.Buffer{}
+	buf.WriteString("// Code generated by genopensimplex.go; DO NOT EDIT.\n\npackage ledsgo\n\n")
+	writeGradients(buf)
+	writeLookup(buf)
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to format source:", err)
+		os.Exit(1)
+	}
+	err = ioutil.WriteFile("opensimplextables.go", src, 0666)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to write opensimplextables.go:", err)
+		os.Exit(1)
+	}
+}
+
+// writeGradients writes the 160 gradients of 4D OpenSimplex2 noise. The 4D
+// lattice has five shortest vectors that sum to zero (the four skewed axes and
+// the main diagonal), which makes it easier to describe the gradients in
+// these five coordinates: they are all permutations of two patterns and their
+// negations. This results in exactly the same set of gradients as the
+// reference implementation, in a different order.
+func writeGradients(buf *bytes.Buffer) {
+	// The five shortest lattice vectors, normalized.
+	var axes [5][4]float64
+	for i := 0; i < 4; i++ {
+		for j := range axes[i] {
+			axes[i][j] = -g4
+		}
+		axes[i][i] = 1 - g4
+	}
+	for j := range axes[4] {
+		axes[4][j] = -(1 - 4*g4)
+	}
+	for i := range axes {
+		normalize(axes[i][:])
+	}
+
+	r := math.Sqrt(2.5)
+	patterns := [][5]float64{
+		{0, 1, 1, 1 + r, 2 + r},
+		{-(2 + r), -(1 + r), -1, -1, 0},
+		{0, 1 + r, 1 + r, 1 + r, 2 + r},
+		{-(2 + r), -(1 + r), -(1 + r), -(1 + r), 0},
+	}
+	var gradients [][4]float64
+	for _, pattern := range patterns {
+		for _, p := range permutations(pattern) {
+			var mean float64
+			for _, v := range p {
+				mean += v / 5
+			}
+			var g [4]float64
+			for i, v := range p {
+				for j := range g {
+					g[j] += (v - mean) * axes[i][j]
+				}
+			}
+			normalize(g[:])
+			gradients = append(gradients, g)
+		}
+	}
+	if len(gradients) != 160 {
+		fmt.Fprintln(os.Stderr, "unexpected number of gradients:", len(gradients))
+		os.Exit(1)
+	}
+
+	buf.WriteString("// Gradients for 4D OpenSimplex2 noise: 160 unit vectors.\n")
+	buf.WriteString("var openSimplexGrad4 = [160][4]int16{ // .14\n")
+	for i, g := range gradients {
+		fmt.Fprintf(buf, "{%d, %d, %d, %d},", round14(g[0]), round14(g[1]), round14(g[2]), round14(g[3]))
+		if i%4 == 3 {
+			buf.WriteString("\n")
+		}
+	}
+	buf.WriteString("}\n\n")
+}
+
+// All distinct permutations of p, in lexicographic order (p must be sorted).
+func permutations(p [5]float64) [][5]float64 {
+	var result [][5]float64
+	var used [5]bool
+	var current [5]float64
+	var permute func(n int)
+	permute = func(n int) {
+		if n == 5 {
+			result = append(result, current)
+			return
+		}
+		for i := range p {
+			if used[i] || (i > 0 && p[i] == p[i-1] && !used[i-1]) {
+				continue
+			}
+			used[i] = true
+			current[n] = p[i]
+			permute(n + 1)
+			used[i] = false
+		}
+	}
+	permute(0)
+	return result
+}
+
+// writeLookup writes, for each of the 256 sub-cells (1/4 of the cell size in
+// each dimension) of the skewed 4D lattice, the lattice vertices that may be
+// within the kernel radius of a point in that sub-cell. Vertices further away
+// than one cell in any direction are never within range.
+func writeLookup(buf *bytes.Buffer) {
+	var starts []int
+	var vertices []byte
+	for index := 0; index < 256; index++ {
+		starts = append(starts, len(vertices))
+		var lo [4]float64
+		for axis := range lo {
+			lo[axis] = float64((index>>(axis*2))&3) / 4
+		}
+		var found []byte
+		for code := 0; code < 256; code++ {
+			var c [4]float64
+			for axis := range c {
+				c[axis] = float64((code>>(axis*2))&3) - 1
+			}
+			if minDistance(lo, c) < r2 {
+				found = append(found, byte(code))
+			}
+		}
+		vertices = append(vertices, found...)
+	}
+	starts = append(starts, len(vertices))
+
+	buf.WriteString("// Start of the list of lattice vertices for each sub-cell of\n")
+	buf.WriteString("// OpenSimplexSmooth4 in openSimplexVertices4.\n")
+	fmt.Fprintf(buf, "var openSimplexLookup4 = [257]uint16{")
+	for i, start := range starts {
+		if i%16 == 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(buf, "%d, ", start)
+	}
+	buf.WriteString("\n}\n\n")
+	buf.WriteString("// Lattice vertices relative to the cell origin, with 2 bits per axis\n")
+	buf.WriteString("// (x in the lowest bits) storing the offset plus one.\n")
+	fmt.Fprintf(buf, "var openSimplexVertices4 = [%d]uint8{", len(vertices))
+	for i, v := range vertices {
+		if i%16 == 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(buf, "%#02x, ", v)
+	}
+	buf.WriteString("\n}\n")
+}
+
+// Calculate the smallest squared distance (in unskewed space) between the
+// vertex c and a point in the sub-cell starting at lo, using projected gradient
+// descent. The function is convex, so this finds the global minimum. A tiny
+// margin makes sure that rounding errors never exclude a vertex.
+func minDistance(lo, c [4]float64) float64 {
+	p := lo
+	for axis := range p {
+		p[axis] += 1.0 / 8
+	}
+	distance := func(p [4]float64) (float64, [4]float64) {
+		var d [4]float64
+		var s float64
+		for axis := range d {
+			d[axis] = p[axis] - c[axis]
+			s += d[axis]
+		}
+		var sum float64
+		for axis := range d {
+			d[axis] -= g4 * s
+			sum += d[axis] * d[axis]
+		}
+		return sum, d
+	}
+	for iter := 0; iter < 2000; iter++ {
+		_, d := distance(p)
+		var s float64
+		for _, v := range d {
+			s += v
+		}
+		for axis := range p {
+			// Gradient of |d|² with respect to p.
+			grad := 2 * (d[axis] - g4*s)
+			p[axis] -= 0.4 * grad
+			p[axis] = math.Max(lo[axis], math.Min(lo[axis]+0.25, p[axis]))
+		}
+	}
+	sum, _ := distance(p)
+	return sum - 1e-9
+}
+
+func normalize(v []float64) {
+	var sum float64
+	for _, x := range v {
+		sum += x * x
+	}
+	length := math.Sqrt(sum)
+	for i := range v {
+		v[i] /= length
+	}
+}
+
+func round14(x float64) int {
+	return int(math.Round(x * (1 << 14)))
+}
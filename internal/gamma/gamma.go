@@ -0,0 +1,20 @@
+// Package gamma converts the linear colors of LEDs to the sRGB colors of
+// screens and images, for the packages and tools that show animations on a
+// computer.
+package gamma
+
+import (
+	"math"
+)
+
+// Default is the gamma used to show LED colors on a screen.
+const Default = 2.2
+
+// Table returns a lookup table that converts linear 8-bit values to values
+// with the given gamma. A gamma of 1 leaves values unmodified.
+func Table(gamma float64) (table [256]uint8) {
+	for i := range table {
+		table[i] = uint8(math.Pow(float64(i)/255, 1/gamma)*255 + 0.5)
+	}
+	return
+}
@@ -139,6 +139,117 @@ func mul16AVR(x, y uint16) uint16 {
 	return uint16(C.ledsgo_mul16(C.uint16_t(x), C.uint16_t(y)))
 }
 
+// Same as grad2, but for 16-bit integers.
+func grad2AVR(hash uint8, x, y int16) int16 {
+	h := hash & 7 // Convert low 3 bits of hash code
+	u, v := x, y  // into 8 simple gradient directions,
+	if h >= 4 {   // and compute the dot product with (x,y).
+		u, v = y, x
+	}
+	if h&1 != 0 {
+		u = -u
+	}
+	if h&2 != 0 {
+		v = -v
+	}
+	return u + 2*v
+}
+
+// Same as grad3, but for 16-bit integers.
+func grad3AVR(hash uint8, x, y, z int16) int16 {
+	h := hash & 15 // Convert low 4 bits of hash code into 12 simple
+	u := y         // gradient directions, and compute dot product.
+	if h < 8 {
+		u = x
+	}
+	v := z
+	if h < 4 {
+		v = y
+	} else if h == 12 || h == 14 { // Fix repeats at h = 12 to 15
+		v = x
+	}
+	if h&1 != 0 {
+		u = -u
+	}
+	if h&2 != 0 {
+		v = -v
+	}
+	return u + v
+}
+
+// Return the absolute value of x.
+func abs16(x int16) uint16 {
+	if x < 0 {
+		return uint16(-x)
+	}
+	return uint16(x)
+}
+
+// Calculate t⁴ for 2D simplex noise, where t = 0.5 - x² - y². The inputs are
+// .14 values, the result is a .20 value that is zero outside the radius.
+func falloff2AVR(x, y int16) uint16 {
+	ax := abs16(x)
+	ay := abs16(y)
+	if ax >= 0x4000 || ay >= 0x4000 {
+		return 0 // far outside the radius
+	}
+	sq := mul16AVR(ax<<2, ax<<2) // .16
+	sq += mul16AVR(ay<<2, ay<<2) // .16
+	if sq >= 0x8000 {
+		return 0 // outside the radius
+	}
+	t := 0xffff - sq<<1   // .17: (0.5 - x² - y²), the -1 avoids an overflow
+	t = mul16AVR(t, t)    // .18
+	return mul16AVR(t, t) // .20
+}
+
+// Calculate (t/0.6)⁴ for 3D simplex noise, where t = 0.6 - x² - y² - z². The
+// division by 0.6 makes sure the full 16 bits are used for better precision.
+// The inputs are .14 values, the result is a .16 value that is zero outside
+// the radius.
+func falloff3AVR(x, y, z int16) uint16 {
+	const fix0_6 = 39321 // .16: 0.6
+	ax := abs16(x)
+	ay := abs16(y)
+	az := abs16(z)
+	if ax >= 0x4000 || ay >= 0x4000 || az >= 0x4000 {
+		return 0 // far outside the radius
+	}
+	sq := mul16AVR(ax<<2, ax<<2) // .16
+	sq += mul16AVR(ay<<2, ay<<2) // .16
+	sq += mul16AVR(az<<2, az<<2) // .16
+	if sq >= fix0_6 {
+		return 0 // outside the radius
+	}
+	t := fix0_6 - sq        // .16: 0.6 - x² - y² - z²
+	t += mul16AVR(t, 43690) // .16: t / 0.6 = t * (1 + 2/3)
+	t = mul16AVR(t, t)      // .16
+	return mul16AVR(t, t)   // .16
+}
+
+// Multiply the falloff value t4 with a .14 gradient. The result has two fewer
+// fractional bits than t4.
+func contribAVR(t4 uint16, grad int16) int16 {
+	if grad < 0 {
+		return -int16(mul16AVR(t4, uint16(-grad)<<1))
+	}
+	return int16(mul16AVR(t4, uint16(grad)<<1))
+}
+
+// Multiply the signed noise value n with the factor whole+frac/65536 and
+// convert it to the full uint16 range.
+func scaleAVR(n int16, whole, frac uint16) uint16 {
+	un := abs16(n)
+	r := un*whole + mul16AVR(un, frac)
+	if r > 0x7fff {
+		r = 0x7fff
+	}
+	if n < 0 {
+		return 0x8000 - r
+	}
+	return 0x8000 + r
+}
+
 // 1D simplex noise.
 //
 // The x input is a 20.12 fixed-point value. The result covers the full range of
@@ -266,6 +377,66 @@ func Noise2(x, y uint32) uint16 {
 	return uint16(n) + 0x8000
 }
 
+// Fast 2D simplex noise for 8-bit microcontrollers. It uses 16-bit arithmetic
+// almost everywhere, only the skew of the input uses a single 32-bit multiply.
+// It is less precise than Noise2: when the output is seen as a value in the
+// range [-1, 1], it differs on average by about 0.001 and at most by about 0.01
+// from Noise2 (with the input converted to 20.12). The lattice wraps around
+// every 256 units.
+//
+// The x and y inputs are 8.8 fixed-point values. The result covers the full
+// range of a uint16, averaging around 32768.
+func Noise2AVR(x, y uint16) uint16 {
+	const F2 = 23988 // .16: 0.5*(sqrt(3.0)-1.0)
+	const G2 = 13849 // .16: (3.0-Math.sqrt(3.0))/6.0
+
+	// Skew the input space to determine which simplex cell we're in. The
+	// product has 8 more fractional bits than s, 6 of which are used for the
+	// offsets below.
+	p := (uint32(x) + uint32(y)) * F2 // .24
+	s := uint16(p >> 16)              // .8
+	rem := uint16(p>>10) & 0x3f       // .14: bits below s
+	xs := x + s                       // .8
+	ys := y + s                       // .8
+	i := uint8(xs >> 8)
+	j := uint8(ys >> 8)
+	xf := int16((xs&0xff)<<6 | rem) // .14: skewed offsets from the cell origin
+	yf := int16((ys&0xff)<<6 | rem) // .14
+
+	// Unskew the offsets back to (x,y) space.
+	t := int16(mul16AVR(uint16(xf+yf)<<1, G2) >> 1) // .14
+	x0 := xf - t                                    // .14
+	y0 := yf - t                                    // .14
+
+	// Determine which simplex we are in.
+	var i1, j1 uint8
+	if x0 > y0 {
+		i1 = 1 // lower triangle, XY order: (0,0)->(1,0)->(1,1)
+	} else {
+		j1 = 1 // upper triangle, YX order: (0,0)->(0,1)->(1,1)
+	}
+
+	x1 := x0 - int16(i1)<<14 + G2>>2 // .14: Offsets for middle corner
+	y1 := y0 - int16(j1)<<14 + G2>>2 // .14
+	x2 := x0 - (1 << 14) + G2>>1     // .14: Offsets for last corner
+	y2 := y0 - (1 << 14) + G2>>1     // .14
+
+	// Calculate the contribution from the three corners.
+	var n int16 // .19
+	if t0 := falloff2AVR(x0, y0); t0 != 0 {
+		n += contribAVR(t0, grad2AVR(perm[i+perm[j]], x0, y0))
+	}
+	if t1 := falloff2AVR(x1, y1); t1 != 0 {
+		n += contribAVR(t1, grad2AVR(perm[i+i1+perm[j+j1]], x1, y1))
+	}
+	if t2 := falloff2AVR(x2, y2); t2 != 0 {
+		n += contribAVR(t2, grad2AVR(perm[i+1+perm[j+1]], x2, y2))
+	}
+
+	// Scale the result to fit in an int16 (multiply by 2.8269).
+	return scaleAVR(n, 2, 54193)
+}
+
 // 3D simplex noise.
 //
 // The x, y and z inputs are 20.12 fixed-point value. The result covers the full
@@ -401,6 +572,91 @@ func Noise3(x, y, z uint32) uint16 {
 	return uint16(n) + 0x8000
 }
 
+// Fast 3D simplex noise for 8-bit microcontrollers. It uses 16-bit arithmetic
+// almost everywhere, only the skew of the input uses a single 32-bit multiply.
+// It is less precise than Noise3: when the output is seen as a value in the
+// range [-1, 1], it differs on average by about 0.0025 and at most by about
+// 0.02 from Noise3 (with the input converted to 20.12). The lattice wraps
+// around every 256 units.
+//
+// The x, y and z inputs are 8.8 fixed-point values. The result covers the full
+// range of a uint16, averaging around 32768.
+func Noise3AVR(x, y, z uint16) uint16 {
+	const F3 = 21845 // .16: 1/3
+	const G3 = 10923 // .16: 1/6
+
+	// Skew the input space to determine which simplex cell we're in. The
+	// product has 8 more fractional bits than s, 6 of which are used for the
+	// offsets below.
+	p := (uint32(x) + uint32(y) + uint32(z)) * F3 // .24
+	s := uint16(p >> 16)                          // .8
+	rem := uint16(p>>10) & 0x3f                   // .14: bits below s
+	xs := x + s                                   // .8
+	ys := y + s                                   // .8
+	zs := z + s                                   // .8
+	i := uint8(xs >> 8)
+	j := uint8(ys >> 8)
+	k := uint8(zs >> 8)
+	xf := int16((xs&0xff)<<6 | rem) // .14: skewed offsets from the cell origin
+	yf := int16((ys&0xff)<<6 | rem) // .14
+	zf := int16((zs&0xff)<<6 | rem) // .14
+
+	// Unskew the offsets back to (x,y,z) space.
+	t := int16(mul16AVR(uint16(xf+yf+zf), G3)) // .14
+	x0 := xf - t                               // .14
+	y0 := yf - t                               // .14
+	z0 := zf - t                               // .14
+
+	// Determine which simplex we are in.
+	var i1, j1, k1 uint8 // Offsets for second corner of simplex in (i,j,k) coords
+	var i2, j2, k2 uint8 // Offsets for third corner of simplex in (i,j,k) coords
+	if x0 >= y0 {
+		if y0 >= z0 {
+			i1, i2, j2 = 1, 1, 1 // X Y Z order
+		} else if x0 >= z0 {
+			i1, i2, k2 = 1, 1, 1 // X Z Y order
+		} else {
+			k1, i2, k2 = 1, 1, 1 // Z X Y order
+		}
+	} else { // x0<y0
+		if y0 < z0 {
+			k1, j2, k2 = 1, 1, 1 // Z Y X order
+		} else if x0 < z0 {
+			j1, j2, k2 = 1, 1, 1 // Y Z X order
+		} else {
+			j1, i2, j2 = 1, 1, 1 // Y X Z order
+		}
+	}
+
+	x1 := x0 - int16(i1)<<14 + G3>>2 // .14: Offsets for second corner
+	y1 := y0 - int16(j1)<<14 + G3>>2 // .14
+	z1 := z0 - int16(k1)<<14 + G3>>2 // .14
+	x2 := x0 - int16(i2)<<14 + G3>>1 // .14: Offsets for third corner
+	y2 := y0 - int16(j2)<<14 + G3>>1 // .14
+	z2 := z0 - int16(k2)<<14 + G3>>1 // .14
+	x3 := x0 - (1 << 14) + 3*(G3>>2) // .14: Offsets for last corner
+	y3 := y0 - (1 << 14) + 3*(G3>>2) // .14
+	z3 := z0 - (1 << 14) + 3*(G3>>2) // .14
+
+	// Calculate the contribution from the four corners.
+	var n int16 // .15
+	if t0 := falloff3AVR(x0, y0, z0); t0 != 0 {
+		n += contribAVR(t0, grad3AVR(perm[i+perm[j+perm[k]]], x0, y0, z0))
+	}
+	if t1 := falloff3AVR(x1, y1, z1); t1 != 0 {
+		n += contribAVR(t1, grad3AVR(perm[i+i1+perm[j+j1+perm[k+k1]]], x1, y1, z1))
+	}
+	if t2 := falloff3AVR(x2, y2, z2); t2 != 0 {
+		n += contribAVR(t2, grad3AVR(perm[i+i2+perm[j+j2+perm[k+k2]]], x2, y2, z2))
+	}
+	if t3 := falloff3AVR(x3, y3, z3); t3 != 0 {
+		n += contribAVR(t3, grad3AVR(perm[i+1+perm[j+1+perm[k+1]]], x3, y3, z3))
+	}
+
+	// Scale the result to fit in an int16 (multiply by 0.6⁴ * 32.7275 = 4.2415).
+	return scaleAVR(n, 4, 15826)
+}
+
 // 4D simplex noise.
 //
 // The x, y, z and w inputs are 20.12 fixed-point value. The result covers the
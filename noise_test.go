@@ -109,6 +109,139 @@ func TestFastNoise1(t *testing.T) {
 	t.Logf("diff:  avg %+2.6f max %+2.6f min %+2.6f", diffavg, diffmax, diffmin)
 }
 
+func TestFastNoise2(t *testing.T) {
+	r := rand.NewSource(0)
+	numTests := int64(1 << 20)
+	rangemax := 0.0
+	rangemin := 0.0
+	rangesum := 0.0
+	diffsum := 0.0
+	diffmax := 0.0
+	diffmin := 0.0
+	const maxdiff = 0.01
+	diffMaxTooHigh := 0
+	diffMinTooLow := 0
+
+	// The documented precision is relative to the fixed-point Noise2.
+	fixedDiffSum := 0.0
+	fixedDiffMax := 0.0
+	for n := int64(0); n < numTests; n++ {
+		x := uint16(r.Int63())
+		y := uint16(r.Int63())
+		n1 := simplexnoise.Noise2(float64(x)/0x100, float64(y)/0x100)
+		n2 := float64(int16(Noise2AVR(x, y)-0x8000)) / 0x8000
+		fixedDiff := math.Abs(n2 - float64(int16(Noise2(uint32(x)<<4, uint32(y)<<4)-0x8000))/0x8000)
+		fixedDiffSum += fixedDiff
+		fixedDiffMax = math.Max(fixedDiffMax, fixedDiff)
+		rangesum += n2
+		if n2 > rangemax {
+			rangemax = n2
+		}
+		if n2 < rangemin {
+			rangemin = n2
+		}
+		diff := n1 - n2
+		diffsum += math.Abs(diff)
+		if diff > maxdiff {
+			diffMaxTooHigh++
+		}
+		if diff < -maxdiff {
+			diffMinTooLow++
+		}
+		if diff > diffmax {
+			diffmax = diff
+		}
+		if diff < diffmin {
+			diffmin = diff
+		}
+	}
+	rangeavg := rangesum / float64(numTests)
+	diffavg := diffsum / float64(numTests)
+	if diffavg >= 0.002 {
+		t.Errorf("diffavg between float and fixed-point is too big: %f", diffavg)
+	}
+	if diffmax > maxdiff {
+		t.Errorf("diffmax is too high: %f (%d times, %.1f%%)", diffmax, diffMaxTooHigh, float64(diffMaxTooHigh)/float64(numTests)*100)
+	}
+	if diffmin < -maxdiff {
+		t.Errorf("diffmin is too low: %f (%d times, %.1f%%)", diffmin, diffMinTooLow, float64(diffMinTooLow)/float64(numTests)*100)
+	}
+	if fixedDiffSum/float64(numTests) >= 0.0013 || fixedDiffMax > 0.01 {
+		t.Errorf("diff with Noise2 is too big: avg %f max %f", fixedDiffSum/float64(numTests), fixedDiffMax)
+	}
+	t.Logf("number of tests: %d", numTests)
+	t.Logf("range: avg %+2.6f max %+2.6f min %+2.6f", rangeavg, rangemax, rangemin)
+	t.Logf("diff:  avg %+2.6f max %+2.6f min %+2.6f", diffavg, diffmax, diffmin)
+	t.Logf("diff with Noise2: avg %2.6f max %2.6f", fixedDiffSum/float64(numTests), fixedDiffMax)
+}
+
+func TestFastNoise3(t *testing.T) {
+	r := rand.NewSource(0)
+	numTests := int64(1 << 20)
+	rangemax := 0.0
+	rangemin := 0.0
+	rangesum := 0.0
+	diffsum := 0.0
+	diffmax := 0.0
+	diffmin := 0.0
+	const maxdiff = 0.025
+	diffMaxTooHigh := 0
+	diffMinTooLow := 0
+
+	// The documented precision is relative to the fixed-point Noise3.
+	fixedDiffSum := 0.0
+	fixedDiffMax := 0.0
+	for n := int64(0); n < numTests; n++ {
+		x := uint16(r.Int63())
+		y := uint16(r.Int63())
+		z := uint16(r.Int63())
+		n1 := simplexnoise.Noise3(float64(x)/0x100, float64(y)/0x100, float64(z)/0x100)
+		n2 := float64(int16(Noise3AVR(x, y, z)-0x8000)) / 0x8000
+		fixedDiff := math.Abs(n2 - float64(int16(Noise3(uint32(x)<<4, uint32(y)<<4, uint32(z)<<4)-0x8000))/0x8000)
+		fixedDiffSum += fixedDiff
+		fixedDiffMax = math.Max(fixedDiffMax, fixedDiff)
+		rangesum += n2
+		if n2 > rangemax {
+			rangemax = n2
+		}
+		if n2 < rangemin {
+			rangemin = n2
+		}
+		diff := n1 - n2
+		diffsum += math.Abs(diff)
+		if diff > maxdiff {
+			diffMaxTooHigh++
+		}
+		if diff < -maxdiff {
+			diffMinTooLow++
+		}
+		if diff > diffmax {
+			diffmax = diff
+		}
+		if diff < diffmin {
+			diffmin = diff
+		}
+	}
+	rangeavg := rangesum / float64(numTests)
+	diffavg := diffsum / float64(numTests)
+	if diffavg >= 0.003 {
+		t.Errorf("diffavg between float and fixed-point is too big: %f", diffavg)
+	}
+	if diffmax > maxdiff {
+		t.Errorf("diffmax is too high: %f (%d times, %.1f%%)", diffmax, diffMaxTooHigh, float64(diffMaxTooHigh)/float64(numTests)*100)
+	}
+	if diffmin < -maxdiff {
+		t.Errorf("diffmin is too low: %f (%d times, %.1f%%)", diffmin, diffMinTooLow, float64(diffMinTooLow)/float64(numTests)*100)
+	}
+	if fixedDiffSum/float64(numTests) >= 0.003 || fixedDiffMax > 0.02 {
+		t.Errorf("diff with Noise3 is too big: avg %f max %f", fixedDiffSum/float64(numTests), fixedDiffMax)
+	}
+	t.Logf("number of tests: %d", numTests)
+	t.Logf("range: avg %+2.6f max %+2.6f min %+2.6f", rangeavg, rangemax, rangemin)
+	t.Logf("diff:  avg %+2.6f max %+2.6f min %+2.6f", diffavg, diffmax, diffmin)
+	t.Logf("diff with Noise3: avg %2.6f max %2.6f", fixedDiffSum/float64(numTests), fixedDiffMax)
+}
+
 func TestMul(t *testing.T) {
 	t.Parallel()
 
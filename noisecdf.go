@@ -0,0 +1,83 @@
+// Code generated by gencdf.go; DO NOT EDIT.
+
+package ledsgo
+
+var noise1CDF = [cdfTableSize + 1]uint16{
+	0, 28, 40, 49, 65, 147, 188, 221,
+	283, 365, 423, 476, 544, 677, 787, 925,
+	1039, 1209, 1348, 1511, 1714, 1898, 2153, 2403,
+	2626, 2933, 3205, 3484, 3828, 4158, 4570, 4974,
+	5363, 5725, 6145, 6580, 7074, 7631, 8346, 8923,
+	9478, 10057, 10659, 11433, 12133, 12815, 13687, 14500,
+	15317, 16117, 16985, 17819, 18603, 19346, 20194, 21087,
+	21975, 22847, 23817, 24865, 25949, 26987, 28049, 29297,
+	30610, 31835, 33036, 34235, 35480, 36885, 38021, 39087,
+	40132, 41287, 42368, 43400, 44322, 45261, 46221, 47297,
+	48080, 48882, 49637, 50354, 51072, 51784, 52486, 53189,
+	53717, 54249, 54810, 55356, 55864, 56322, 56763, 57247,
+	57703, 58213, 58686, 59107, 59548, 59977, 60361, 60720,
+	61092, 61485, 61809, 62128, 62472, 62673, 62867, 63083,
+	63302, 63494, 63637, 63800, 63989, 64149, 64347, 64523,
+	64761, 64878, 64954, 65040, 65164, 65242, 65297, 65368,
+	65535,
+}
+
+var noise2CDF = [cdfTableSize + 1]uint16{
+	0, 86, 258, 487, 724, 1030, 1450, 1968,
+	2516, 3050, 3578, 4115, 4685, 5246, 5803, 6342,
+	6881, 7409, 7932, 8454, 8984, 9527, 10071, 10626,
+	11171, 11718, 12260, 12800, 13336, 13877, 14421, 14958,
+	15496, 16035, 16580, 17122, 17654, 18179, 18702, 19224,
+	19747, 20272, 20799, 21333, 21878, 22417, 22962, 23520,
+	24079, 24639, 25201, 25769, 26342, 26910, 27472, 28022,
+	28564, 29099, 29628, 30152, 30676, 31202, 31730, 32265,
+	32803, 33341, 33874, 34405, 34929, 35450, 35972, 36498,
+	37027, 37558, 38102, 38651, 39210, 39771, 40334, 40887,
+	41439, 41988, 42533, 43071, 43603, 44143, 44673, 45198,
+	45722, 46243, 46766, 47288, 47812, 48340, 48883, 49426,
+	49966, 50506, 51045, 51590, 52131, 52672, 53215, 53763,
+	54318, 54872, 55434, 55982, 56537, 57075, 57609, 58144,
+	58684, 59247, 59805, 60374, 60940, 61508, 62051, 62580,
+	63108, 63651, 64167, 64578, 64866, 65085, 65302, 65462,
+	65535,
+}
+
+var noise3CDF = [cdfTableSize + 1]uint16{
+	0, 15, 55, 123, 223, 348, 490, 649,
+	824, 1015, 1224, 1448, 1687, 1939, 2202, 2477,
+	2765, 3065, 3377, 3701, 4042, 4397, 4769, 5160,
+	5574, 6002, 6445, 6900, 7370, 7858, 8361, 8878,
+	9418, 9972, 10541, 11126, 11732, 12343, 12968, 13602,
+	14252, 14915, 15591, 16282, 16983, 17690, 18412, 19134,
+	19864, 20604, 21355, 22119, 22892, 23677, 24476, 25278,
+	26083, 26891, 27708, 28526, 29353, 30174, 31005, 31840,
+	32684, 33531, 34364, 35194, 36021, 36850, 37676, 38499,
+	39316, 40133, 40939, 41748, 42545, 43332, 44104, 44865,
+	45613, 46358, 47092, 47820, 48539, 49249, 49948, 50636,
+	51309, 51967, 52613, 53249, 53872, 54485, 55078, 55658,
+	56219, 56760, 57283, 57782, 58270, 58740, 59195, 59636,
+	60062, 60472, 60858, 61226, 61577, 61913, 62235, 62542,
+	62838, 63119, 63389, 63648, 63894, 64126, 64342, 64544,
+	64730, 64901, 65055, 65195, 65316, 65416, 65481, 65520,
+	65535,
+}
+
+var noise4CDF = [cdfTableSize + 1]uint16{
+	0, 0, 1, 6, 16, 31, 51, 76,
+	107, 144, 187, 237, 293, 358, 430, 509,
+	598, 694, 800, 917, 1044, 1182, 1329, 1490,
+	1663, 1851, 2051, 2266, 2494, 2738, 2998, 3276,
+	3570, 3883, 4218, 4571, 4946, 5346, 5769, 6218,
+	6694, 7200, 7738, 8306, 8908, 9549, 10228, 10951,
+	11721, 12541, 13414, 14346, 15341, 16412, 17550, 18763,
+	20052, 21413, 22850, 24361, 25942, 27582, 29278, 31011,
+	32761, 34509, 36247, 37940, 39583, 41166, 42679, 44115,
+	45479, 46769, 47982, 49126, 50194, 51192, 52129, 53007,
+	53829, 54600, 55324, 56003, 56646, 57249, 57817, 58351,
+	58856, 59334, 59782, 60203, 60601, 60977, 61330, 61662,
+	61975, 62270, 62546, 62808, 63052, 63278, 63491, 63691,
+	63877, 64050, 64210, 64358, 64496, 64623, 64740, 64846,
+	64943, 65031, 65109, 65179, 65242, 65298, 65348, 65391,
+	65429, 65460, 65486, 65505, 65520, 65529, 65534, 65535,
+	65535,
+}
@@ -0,0 +1,206 @@
+package opc
+
+import (
+	"errors"
+	"image/color"
+	"net"
+	"time"
+
+	"github.com/aykevl/ledsgo"
+	"github.com/aykevl/ledsgo/demos"
+)
+
+// Don't try to reconnect more often than this.
+const reconnectDelay = time.Second
+
+var (
+	errNotConnected     = errors.New("opc: not connected")
+	errStripTooLong     = errors.New("opc: strip too long for a single message")
+	errInvalidFrameRate = errors.New("opc: frame rate must be positive")
+)
+
+// Maximum number of LEDs in a single message: the data length is 16 bits.
+const maxLEDs = 0xffff / 3
+
+// Client sends strips to an OPC server. It connects on the first send and
+// automatically reconnects when the connection is lost.
+type Client struct {
+	address  string
+	conn     net.Conn
+	lastDial time.Time
+	buf      []byte
+}
+
+// NewClient returns a new client for the OPC server at the given TCP address,
+// for example "localhost:7890". It doesn't connect yet.
+func NewClient(address string) *Client {
+	return &Client{address: address}
+}
+
+// connect makes sure there is a connection, or returns an error if there is
+// none and it is too soon to try again.
+func (c *Client) connect() error {
+	if c.conn != nil {
+		return nil
+	}
+	if !c.lastDial.IsZero() && time.Since(c.lastDial) < reconnectDelay {
+		return errNotConnected
+	}
+	c.lastDial = time.Now()
+	conn, err := net.DialTimeout("tcp", c.address, reconnectDelay)
+	if err != nil {
+		return err
+	}
+	c.conn = conn
+	return nil
+}
+
+// Send sends a single message to the server. If there is no connection, it
+// tries to connect first. If sending fails, the connection is closed and a
+// new connection is made on the next call (at most once per second).
+func (c *Client) Send(msg Message) error {
+	if err := c.connect(); err != nil {
+		return err
+	}
+	c.buf = msg.Append(c.buf[:0])
+	if _, err := c.conn.Write(c.buf); err != nil {
+		c.conn.Close()
+		c.conn = nil
+		return err
+	}
+	return nil
+}
+
+// SendStrip sends the colors of the strip to the given channel. A strip can
+// have at most 21845 LEDs, which is the most that fits in a single message.
+func (c *Client) SendStrip(channel uint8, strip ledsgo.Strip) error {
+	if len(strip) > maxLEDs {
+		return errStripTooLong
+	}
+	if err := c.connect(); err != nil {
+		return err
+	}
+	c.buf = append(c.buf[:0], channel, CmdSetPixelColors, uint8(len(strip)*3>>8), uint8(len(strip)*3))
+	c.buf = strip.AppendBytes(c.buf, ledsgo.RGB)
+	if _, err := c.conn.Write(c.buf); err != nil {
+		c.conn.Close()
+		c.conn = nil
+		return err
+	}
+	return nil
+}
+
+// SendFirmwareConfig sends a Fadecandy firmware configuration message.
+func (c *Client) SendFirmwareConfig(config FirmwareConfig) error {
+	return c.Send(FirmwareConfigMessage(config))
+}
+
+// Stream calls draw and sends the display to the given channel at the given
+// frame rate (frames per second), until stop is closed. The draw function has
+// the same signature as the animations in demos.Animations, so they can be
+// streamed directly.
+//
+// If display is a *Displayer its strip is sent, otherwise the pixels are sent
+// row by row while they are also drawn on display, and the Display method of
+// display is called after every frame if it has one. Errors while sending are
+// ignored: frames are dropped while there is no connection, and the client
+// keeps reconnecting. Stream only returns an error if its parameters are
+// invalid.
+func (c *Client) Stream(channel uint8, display demos.Displayer, frameRate int, draw func(display demos.Displayer, now time.Time), stop <-chan struct{}) error {
+	if frameRate <= 0 {
+		return errInvalidFrameRate
+	}
+	target, _ := display.(interface{ Display() error })
+	strip, ok := display.(*Displayer)
+	if !ok {
+		width, height := display.Size()
+		if int(width)*int(height) > maxLEDs {
+			return errStripTooLong
+		}
+		tee := &teeDisplay{
+			Displayer: display,
+			strip:     NewDisplayer(width, height),
+		}
+		display = tee
+		strip = tee.strip
+	} else if len(strip.Strip) > maxLEDs {
+		return errStripTooLong
+	}
+	ticker := time.NewTicker(time.Second / time.Duration(frameRate))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return nil
+		case now := <-ticker.C:
+			if draw != nil {
+				draw(display, now)
+			}
+			if target != nil {
+				target.Display()
+			}
+			c.SendStrip(channel, strip.Strip)
+		}
+	}
+}
+
+// Close closes the connection, if there is one.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// Displayer is a rectangular display backed by a strip, which implements the
+// demos.Displayer interface. Use it together with Client.Stream to send the
+// animations from the demos package to an OPC server.
+//
+// Pixels are stored row by row. With Serpentine set, every odd row is stored
+// in reverse, which matches the wiring of many LED matrices.
+type Displayer struct {
+	Strip      ledsgo.Strip
+	Width      int16
+	Height     int16
+	Serpentine bool
+}
+
+// NewDisplayer returns a new displayer of the given size.
+func NewDisplayer(width, height int16) *Displayer {
+	return &Displayer{
+		Strip:  make(ledsgo.Strip, int(width)*int(height)),
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Size returns the size of the display.
+func (d *Displayer) Size() (int16, int16) {
+	return d.Width, d.Height
+}
+
+// SetPixel sets the color of a single pixel.
+func (d *Displayer) SetPixel(x, y int16, c color.RGBA) {
+	if x < 0 || y < 0 || x >= d.Width || y >= d.Height {
+		return
+	}
+	if d.Serpentine && y%2 == 1 {
+		x = d.Width - 1 - x
+	}
+	d.Strip[int(y)*int(d.Width)+int(x)] = c
+}
+
+// teeDisplay draws pixels on a display and also stores them in a strip, so
+// that any display can be streamed.
+type teeDisplay struct {
+	demos.Displayer
+	strip *Displayer
+}
+
+// SetPixel sets the pixel on both the display and the strip.
+func (d *teeDisplay) SetPixel(x, y int16, c color.RGBA) {
+	d.Displayer.SetPixel(x, y, c)
+	d.strip.SetPixel(x, y, c)
+}
@@ -0,0 +1,122 @@
+// Package opc implements Open Pixel Control (OPC), a simple protocol to send
+// pixel colors over TCP that is used by Fadecandy and various LED preview
+// tools. It contains a server that receives pixel colors into strips and a
+// client that streams strips to an OPC server.
+//
+// See http://openpixelcontrol.org/ for the protocol description.
+package opc
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// Port is the default TCP port used by OPC servers.
+const Port = 7890
+
+// OPC commands.
+const (
+	CmdSetPixelColors  = 0
+	CmdSystemExclusive = 255
+)
+
+// System IDs and commands for system exclusive messages.
+const (
+	SystemIDFadecandy = 0x0001
+
+	fadecandyFirmwareConfig = 0x0002
+)
+
+// Size of the message header: channel, command and length.
+const headerSize = 4
+
+var errShortSysEx = errors.New("opc: system exclusive message too short")
+
+// Message is a single OPC message.
+type Message struct {
+	Channel uint8 // 0 means all channels
+	Command uint8
+	Data    []byte
+}
+
+// ReadMessage reads a single message from r. The data of the message is
+// stored in buf if it is big enough, otherwise a new buffer is allocated. It
+// returns the message and the (possibly new) buffer for reuse.
+func ReadMessage(r io.Reader, buf []byte) (Message, []byte, error) {
+	var header [headerSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return Message{}, buf, err
+	}
+	length := int(binary.BigEndian.Uint16(header[2:]))
+	if cap(buf) < length {
+		buf = make([]byte, length)
+	}
+	buf = buf[:length]
+	if _, err := io.ReadFull(r, buf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return Message{}, buf, err
+	}
+	return Message{
+		Channel: header[0],
+		Command: header[1],
+		Data:    buf,
+	}, buf, nil
+}
+
+// Append appends the binary encoding of the message to buf and returns the
+// extended buffer. The data must not be longer than 65535 bytes.
+func (m Message) Append(buf []byte) []byte {
+	buf = append(buf, m.Channel, m.Command, uint8(len(m.Data)>>8), uint8(len(m.Data)))
+	return append(buf, m.Data...)
+}
+
+// SystemExclusive returns the system ID and the remaining data of a system
+// exclusive message.
+func (m Message) SystemExclusive() (systemID uint16, data []byte, err error) {
+	if m.Command != CmdSystemExclusive || len(m.Data) < 2 {
+		return 0, nil, errShortSysEx
+	}
+	return binary.BigEndian.Uint16(m.Data), m.Data[2:], nil
+}
+
+// FirmwareConfig is the configuration byte of a Fadecandy firmware
+// configuration message.
+type FirmwareConfig uint8
+
+// Flags for the Fadecandy firmware configuration.
+const (
+	ConfigNoDithering     FirmwareConfig = 0x01 // disable dithering
+	ConfigNoInterpolation FirmwareConfig = 0x02 // disable keyframe interpolation
+	ConfigManualLED       FirmwareConfig = 0x04 // control the status LED manually
+	ConfigLEDOn           FirmwareConfig = 0x08 // turn on the status LED (in manual mode)
+)
+
+// FirmwareConfigMessage returns a system exclusive message that sets the
+// Fadecandy firmware configuration.
+func FirmwareConfigMessage(config FirmwareConfig) Message {
+	return Message{
+		Channel: 0,
+		Command: CmdSystemExclusive,
+		Data: []byte{
+			SystemIDFadecandy >> 8, SystemIDFadecandy & 0xff,
+			fadecandyFirmwareConfig >> 8, fadecandyFirmwareConfig & 0xff,
+			uint8(config),
+		},
+	}
+}
+
+// ParseFirmwareConfig parses a Fadecandy firmware configuration message. It
+// returns false if the message is not a firmware configuration message.
+func ParseFirmwareConfig(m Message) (FirmwareConfig, bool) {
+	systemID, data, err := m.SystemExclusive()
+	if err != nil || systemID != SystemIDFadecandy || len(data) < 3 {
+		return 0, false
+	}
+	if binary.BigEndian.Uint16(data) != fadecandyFirmwareConfig {
+		return 0, false
+	}
+	return FirmwareConfig(data[2]), true
+}
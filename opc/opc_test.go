@@ -0,0 +1,256 @@
+package opc
+
+import (
+	"bytes"
+	"image/color"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/aykevl/ledsgo"
+	"github.com/aykevl/ledsgo/demos"
+)
+
+var _ demos.Displayer = (*Displayer)(nil)
+
+func TestMessage(t *testing.T) {
+	msg := Message{Channel: 2, Command: CmdSetPixelColors, Data: []byte{1, 2, 3, 4, 5, 6}}
+	buf := msg.Append(nil)
+	if !bytes.Equal(buf, []byte{2, 0, 0, 6, 1, 2, 3, 4, 5, 6}) {
+		t.Errorf("unexpected message: % x", buf)
+	}
+	msg2, _, err := ReadMessage(bytes.NewReader(buf), nil)
+	if err != nil {
+		t.Fatal("could not read message:", err)
+	}
+	if msg2.Channel != 2 || msg2.Command != 0 || !bytes.Equal(msg2.Data, msg.Data) {
+		t.Errorf("message changed after round trip: %+v", msg2)
+	}
+
+	// Truncated messages result in an error.
+	if _, _, err := ReadMessage(bytes.NewReader(buf[:7]), nil); err == nil {
+		t.Error("expected an error for a truncated message")
+	}
+}
+
+func TestFirmwareConfig(t *testing.T) {
+	msg := FirmwareConfigMessage(ConfigNoDithering | ConfigManualLED)
+	buf := msg.Append(nil)
+	expected := []byte{0, 0xff, 0, 5, 0x00, 0x01, 0x00, 0x02, 0x05}
+	if !bytes.Equal(buf, expected) {
+		t.Errorf("unexpected firmware config message\nexpected: % x\nactual:   % x", expected, buf)
+	}
+	config, ok := ParseFirmwareConfig(msg)
+	if !ok || config != ConfigNoDithering|ConfigManualLED {
+		t.Errorf("could not parse firmware config: %v %x", ok, config)
+	}
+	if _, ok := ParseFirmwareConfig(Message{Command: CmdSystemExclusive, Data: []byte{0, 2, 0, 2, 1}}); ok {
+		t.Error("parsed firmware config with a wrong system ID")
+	}
+}
+
+func TestDisplayer(t *testing.T) {
+	d := NewDisplayer(3, 2)
+	d.Serpentine = true
+	d.SetPixel(0, 0, color.RGBA{R: 1})
+	d.SetPixel(0, 1, color.RGBA{R: 2})
+	d.SetPixel(3, 0, color.RGBA{R: 3}) // out of bounds
+	if d.Strip[0].R != 1 || d.Strip[5].R != 2 {
+		t.Errorf("unexpected strip: %v", d.Strip)
+	}
+}
+
+func TestClientServer(t *testing.T) {
+	strip1 := make(ledsgo.Strip, 3)
+	strip2 := make(ledsgo.Strip, 2)
+	server, err := Listen("127.0.0.1:0", map[uint8]ledsgo.Strip{1: strip1, 2: strip2})
+	if err != nil {
+		t.Fatal("could not listen:", err)
+	}
+	frames := make(chan uint8, 10)
+	server.OnFrame = func(channel uint8) {
+		frames <- channel
+	}
+	configs := make(chan FirmwareConfig, 1)
+	server.OnSystemExclusive = func(msg Message) {
+		if config, ok := ParseFirmwareConfig(msg); ok {
+			configs <- config
+		}
+	}
+	done := make(chan error)
+	go func() {
+		done <- server.Serve()
+	}()
+
+	waitFrame := func(expected uint8) {
+		t.Helper()
+		select {
+		case channel := <-frames:
+			if channel != expected {
+				t.Errorf("expected frame for channel %d, got %d", expected, channel)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for frame")
+		}
+	}
+
+	client := NewClient(server.Addr().String())
+	defer client.Close()
+	if err := client.SendStrip(1, ledsgo.Strip{{1, 2, 3, 0xff}, {4, 5, 6, 0xff}}); err != nil {
+		t.Fatal("could not send strip:", err)
+	}
+	waitFrame(1)
+	server.lock.Lock()
+	if strip1[0] != (color.RGBA{1, 2, 3, 0xff}) || strip1[1] != (color.RGBA{4, 5, 6, 0xff}) || strip1[2] != (color.RGBA{}) {
+		t.Errorf("unexpected strip 1: %v", strip1)
+	}
+	server.lock.Unlock()
+
+	// Channel 0 sets all strips.
+	if err := client.SendStrip(0, ledsgo.Strip{{7, 8, 9, 0xff}}); err != nil {
+		t.Fatal("could not send strip:", err)
+	}
+	waitFrame(0)
+	server.lock.Lock()
+	if strip1[0] != (color.RGBA{7, 8, 9, 0xff}) || strip2[0] != (color.RGBA{7, 8, 9, 0xff}) {
+		t.Errorf("broadcast did not set all strips: %v %v", strip1, strip2)
+	}
+	server.lock.Unlock()
+
+	if err := client.SendFirmwareConfig(ConfigNoInterpolation); err != nil {
+		t.Fatal("could not send firmware config:", err)
+	}
+	select {
+	case config := <-configs:
+		if config != ConfigNoInterpolation {
+			t.Errorf("unexpected firmware config: %x", config)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("timeout waiting for firmware config")
+	}
+
+	// Streaming.
+	stop := make(chan struct{})
+	draws := 0
+	go func() {
+		for i := 0; i < 3; i++ {
+			waitFrame(2)
+		}
+		close(stop)
+	}()
+	display := NewDisplayer(1, 1)
+	err = client.Stream(2, display, 100, func(d demos.Displayer, now time.Time) {
+		d.SetPixel(0, 0, color.RGBA{1, 1, 1, 0xff})
+		draws++
+	}, stop)
+	if err != nil {
+		t.Error("could not stream:", err)
+	}
+	if draws < 3 {
+		t.Errorf("expected at least 3 frames, got %d", draws)
+	}
+
+	// Streaming any other display.
+	stop = make(chan struct{})
+	other := &testDisplay{}
+	err = client.Stream(2, other, 100, func(d demos.Displayer, now time.Time) {
+		d.SetPixel(1, 0, color.RGBA{4, 5, 6, 0xff})
+		if other.frames == 2 {
+			close(stop) // the third frame is still sent
+		}
+	}, stop)
+	if err != nil {
+		t.Error("could not stream:", err)
+	}
+	if other.pixel != (color.RGBA{4, 5, 6, 0xff}) || other.frames < 3 {
+		t.Errorf("pixel was not drawn on the display: %v (%d frames)", other.pixel, other.frames)
+	}
+	for i := 0; ; i++ {
+		waitFrame(2)
+		server.lock.Lock()
+		pixel := strip2[1]
+		server.lock.Unlock()
+		if pixel == (color.RGBA{4, 5, 6, 0xff}) {
+			break
+		}
+		if i == 5 {
+			t.Fatalf("unexpected streamed pixel: %v", pixel)
+		}
+	}
+
+	// Invalid parameters.
+	if err := client.Stream(2, display, 0, nil, stop); err != errInvalidFrameRate {
+		t.Errorf("expected errInvalidFrameRate, got %v", err)
+	}
+	if err := client.SendStrip(2, make(ledsgo.Strip, 21846)); err != errStripTooLong {
+		t.Errorf("expected errStripTooLong, got %v", err)
+	}
+
+	server.Close()
+	if err := <-done; err != nil {
+		t.Error("Serve returned an error:", err)
+	}
+}
+
+func TestClientReconnect(t *testing.T) {
+	// Reserve an address, but don't listen on it yet.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("could not listen:", err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	client := NewClient(address)
+	defer client.Close()
+	if err := client.SendStrip(1, ledsgo.Strip{{}}); err == nil {
+		t.Fatal("expected an error without server")
+	}
+	// Don't reconnect immediately.
+	if err := client.SendStrip(1, ledsgo.Strip{{}}); err != errNotConnected {
+		t.Errorf("expected errNotConnected, got %v", err)
+	}
+
+	strip := make(ledsgo.Strip, 1)
+	server, err := Listen(address, map[uint8]ledsgo.Strip{1: strip})
+	if err != nil {
+		t.Skip("could not listen on the same address again:", err)
+	}
+	frames := make(chan uint8, 1)
+	server.OnFrame = func(channel uint8) {
+		frames <- channel
+	}
+	go server.Serve()
+	defer server.Close()
+
+	client.lastDial = time.Now().Add(-reconnectDelay) // don't wait in the test
+	if err := client.SendStrip(1, ledsgo.Strip{{1, 2, 3, 0xff}}); err != nil {
+		t.Fatal("could not reconnect:", err)
+	}
+	select {
+	case <-frames:
+	case <-time.After(5 * time.Second):
+		t.Error("timeout waiting for frame")
+	}
+}
+
+// testDisplay is a 2x1 display that only remembers the pixel at (1, 0).
+type testDisplay struct {
+	pixel  color.RGBA
+	frames int
+}
+
+func (d *testDisplay) Size() (int16, int16) {
+	return 2, 1
+}
+
+func (d *testDisplay) SetPixel(x, y int16, c color.RGBA) {
+	if x == 1 && y == 0 {
+		d.pixel = c
+	}
+}
+
+func (d *testDisplay) Display() error {
+	d.frames++
+	return nil
+}
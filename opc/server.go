@@ -0,0 +1,160 @@
+package opc
+
+import (
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/aykevl/ledsgo"
+)
+
+// Server receives OPC messages from any number of clients and copies the
+// pixel colors into strips.
+type Server struct {
+	// Channels maps OPC channels (1-255) to the strips they control. A
+	// message for channel 0 is applied to all strips.
+	Channels map[uint8]ledsgo.Strip
+
+	// OnFrame is called after a set pixel colors message has been copied into
+	// the strip(s) of the given channel.
+	OnFrame func(channel uint8)
+
+	// OnSystemExclusive is called for every system exclusive message, for
+	// example to handle Fadecandy firmware configuration messages (see
+	// ParseFirmwareConfig).
+	OnSystemExclusive func(msg Message)
+
+	// The callbacks and strip updates are protected by this lock, so that
+	// multiple clients don't modify the strips at the same time.
+	lock     sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+	closed   bool
+	wg       sync.WaitGroup
+}
+
+// Listen creates a new server listening on the given TCP address, for example
+// ":7890".
+func Listen(address string, channels map[uint8]ledsgo.Strip) (*Server, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		Channels: channels,
+		listener: listener,
+	}, nil
+}
+
+// Addr returns the address the server is listening on.
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Serve accepts connections until the server is closed, handling each
+// connection in a separate goroutine. It returns nil after Close is called.
+func (s *Server) Serve() error {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				s.wg.Wait()
+				return nil
+			}
+			return err
+		}
+		s.lock.Lock()
+		if s.closed {
+			// Close was called after this connection was accepted, and won't
+			// close it anymore.
+			s.lock.Unlock()
+			conn.Close()
+			continue
+		}
+		if s.conns == nil {
+			s.conns = make(map[net.Conn]struct{})
+		}
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.lock.Unlock()
+		go func() {
+			defer s.wg.Done()
+			s.ServeConn(conn)
+			s.lock.Lock()
+			delete(s.conns, conn)
+			s.lock.Unlock()
+			conn.Close()
+		}()
+	}
+}
+
+// Close stops the server and closes all client connections.
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.lock.Lock()
+	s.closed = true
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.lock.Unlock()
+	return err
+}
+
+// ServeConn reads messages from a single connection until it is closed or an
+// error occurs. It can also be used directly, for example with a serial port.
+// It returns nil when the connection is closed by the client.
+func (s *Server) ServeConn(conn io.Reader) error {
+	var buf []byte
+	for {
+		var msg Message
+		var err error
+		msg, buf, err = ReadMessage(conn, buf)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		s.HandleMessage(msg)
+	}
+}
+
+// HandleMessage processes a single OPC message. It is safe to call from
+// multiple goroutines.
+func (s *Server) HandleMessage(msg Message) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	switch msg.Command {
+	case CmdSetPixelColors:
+		if msg.Channel == 0 {
+			for _, strip := range s.Channels {
+				setPixelColors(strip, msg.Data)
+			}
+		} else {
+			strip, ok := s.Channels[msg.Channel]
+			if !ok {
+				return
+			}
+			setPixelColors(strip, msg.Data)
+		}
+		if s.OnFrame != nil {
+			s.OnFrame(msg.Channel)
+		}
+	case CmdSystemExclusive:
+		if s.OnSystemExclusive != nil {
+			s.OnSystemExclusive(msg)
+		}
+	}
+}
+
+// Copy the RGB data into the strip. Extra data is ignored, and if there is too
+// little data only the first LEDs are updated.
+func setPixelColors(strip ledsgo.Strip, data []byte) {
+	for i := range strip {
+		if i*3+3 > len(data) {
+			break
+		}
+		strip[i] = ledsgo.RGB.Get(data[i*3:])
+	}
+}
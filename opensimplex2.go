@@ -0,0 +1,470 @@
+package ledsgo
+
+// This file implements OpenSimplex2 noise, a noise function similar to simplex
+// noise that was designed to avoid the (now expired) simplex noise patent. The
+// 3D variant uses a rotated body-centered cubic lattice, which has less
+// directional artifacts than the simplex lattice.
+//
+// Both the fast OpenSimplex2 variant and the smoother OpenSimplex2S variant
+// are implemented in 2D, 3D and 4D. The lattices, kernel radii and gradient
+// sets follow the reference implementation. The hash function is different:
+// like the simplex noise functions in this package it uses the permutation
+// table so that it can be calculated with 8-bit operations. Therefore the
+// output is not identical to the reference implementation, but it has the
+// same characteristics.
+//
+// Original author: KdotJPG, converted to fixed-point by the ledsgo authors.
+// https://github.com/KdotJPG/OpenSimplex2
+//
+// The reference implementation has been released under the CC0 license. See
+// noise.go for an explanation of the fixed-point notation used in this file.
+
+//go:generate go run genopensimplex.go
+
+const (
+	openSimplexG2      = 13849     // .16: (3-sqrt(3))/6 = 0.21132486540518713
+	openSimplexR2      = 1 << 27   // .28: 0.5
+	openSimplexR2S     = 178956971 // .28: 2/3
+	openSimplexR3      = 161061274 // .28: 0.6
+	openSimplexR3S     = 201326592 // .28: 0.75
+	openSimplexR4      = 161061274 // .28: 0.6
+	openSimplexR4S     = 214748365 // .28: 0.8
+	openSimplexUnskew4 = 5063      // .14: (sqrt(5)-1)/4 = 0.309016994374947
+)
+
+// Gradients for 2D OpenSimplex2 noise: 24 unit vectors.
+var openSimplexGrad2 = [24][2]int16{ // .14
+	{6270, 15137}, {15137, 6270}, {15137, -6270}, {6270, -15137},
+	{-6270, -15137}, {-15137, -6270}, {-15137, 6270}, {-6270, 15137},
+	{2139, 16244}, {9974, 12998}, {12998, 9974}, {16244, 2139},
+	{16244, -2139}, {12998, -9974}, {9974, -12998}, {2139, -16244},
+	{-2139, -16244}, {-9974, -12998}, {-12998, -9974}, {-16244, -2139},
+	{-16244, 2139}, {-12998, 9974}, {-9974, 12998}, {-2139, 16244},
+}
+
+// Gradients for 3D OpenSimplex2 noise: 48 unit vectors.
+var openSimplexGrad3 = [48][3]int16{ // .14
+	{4963, 11041, 11041}, {-4963, 11041, 11041}, {4963, 11041, -11041}, {-4963, 11041, -11041},
+	{4963, -11041, 11041}, {-4963, -11041, 11041}, {4963, -11041, -11041}, {-4963, -11041, -11041},
+	{11041, 4963, 11041}, {11041, -4963, 11041}, {11041, 4963, -11041}, {11041, -4963, -11041},
+	{-11041, 4963, 11041}, {-11041, -4963, 11041}, {-11041, 4963, -11041}, {-11041, -4963, -11041},
+	{11041, 11041, 4963}, {11041, 11041, -4963}, {11041, -11041, 4963}, {11041, -11041, -4963},
+	{-11041, 11041, 4963}, {-11041, 11041, -4963}, {-11041, -11041, 4963}, {-11041, -11041, -4963},
+	{15317, 5817, 0}, {15317, -5817, 0}, {-15317, 5817, 0}, {-15317, -5817, 0},
+	{15317, 0, 5817}, {15317, 0, -5817}, {-15317, 0, 5817}, {-15317, 0, -5817},
+	{5817, 15317, 0}, {-5817, 15317, 0}, {5817, -15317, 0}, {-5817, -15317, 0},
+	{0, 15317, 5817}, {0, 15317, -5817}, {0, -15317, 5817}, {0, -15317, -5817},
+	{5817, 0, 15317}, {-5817, 0, 15317}, {5817, 0, -15317}, {-5817, 0, -15317},
+	{0, 5817, 15317}, {0, -5817, 15317}, {0, 5817, -15317}, {0, -5817, -15317},
+}
+
+func openSimplexHash2(i, j uint32) uint8 {
+	return perm[(i+uint32(perm[j&0xff]))&0xff]
+}
+
+func openSimplexHash3(i, j, k uint32, seed uint8) uint8 {
+	return perm[(i+uint32(perm[(j+uint32(perm[(k+uint32(seed))&0xff]))&0xff]))&0xff]
+}
+
+func openSimplexHash4(i, j, k, l uint32, seed uint8) uint8 {
+	return perm[(i+uint32(perm[(j+uint32(perm[(k+uint32(perm[(l+uint32(seed))&0xff]))&0xff]))&0xff]))&0xff]
+}
+
+// Calculate (r2 - d²)⁴ from the squared kernel radius r2 (.28) and the squared
+// distance d2 (.28). The result is a .20 fixed-point value, or 0 if the point
+// is outside the kernel radius.
+func openSimplexFalloff(r2, d2 int32) uint32 {
+	if d2 >= r2 {
+		return 0
+	}
+	a := uint32(r2-d2) >> 12 // .16
+	a = (a * a) >> 16        // .16
+	return (a * a) >> 12     // .20
+}
+
+// Contribution of a single 2D lattice point at the given offset (.14).
+func openSimplexContrib2(r2, dx, dy int32, hash uint8) int32 {
+	if dx >= 1<<14 || dx <= -1<<14 || dy >= 1<<14 || dy <= -1<<14 {
+		return 0 // outside the kernel radius, avoid overflow
+	}
+	a := openSimplexFalloff(r2, dx*dx+dy*dy)
+	if a == 0 {
+		return 0
+	}
+	g := &openSimplexGrad2[(uint32(hash)*24)>>8]
+	return int32(a) * ((int32(g[0])*dx + int32(g[1])*dy + 1<<13) >> 14) // .20 * .14 = .34
+}
+
+// Contribution of a single 3D lattice point at the given offset (.14).
+func openSimplexContrib3(r2, dx, dy, dz int32, hash uint8) int32 {
+	if dx >= 1<<14 || dx <= -1<<14 || dy >= 1<<14 || dy <= -1<<14 || dz >= 1<<14 || dz <= -1<<14 {
+		return 0 // outside the kernel radius, avoid overflow
+	}
+	a := openSimplexFalloff(r2, dx*dx+dy*dy+dz*dz)
+	if a == 0 {
+		return 0
+	}
+	g := &openSimplexGrad3[(uint32(hash)*48)>>8]
+	return int32(a) * ((int32(g[0])*dx + int32(g[1])*dy + int32(g[2])*dz + 1<<13) >> 14) // .20 * .14 = .34
+}
+
+// Contribution of a single 4D lattice point at the given offset (.14).
+func openSimplexContrib4(r2, dx, dy, dz, dw int32, hash uint8) int32 {
+	if dx >= 1<<14 || dx <= -1<<14 || dy >= 1<<14 || dy <= -1<<14 || dz >= 1<<14 || dz <= -1<<14 || dw >= 1<<14 || dw <= -1<<14 {
+		return 0 // outside the kernel radius, avoid overflow
+	}
+	a := openSimplexFalloff(r2, dx*dx+dy*dy+dz*dz+dw*dw)
+	if a == 0 {
+		return 0
+	}
+	g := &openSimplexGrad4[(uint32(hash)*160)>>8]
+	return int32(a) * ((int32(g[0])*dx + int32(g[1])*dy + int32(g[2])*dz + int32(g[3])*dw + 1<<13) >> 14) // .20 * .14 = .34
+}
+
+// Scale the .34 noise value n with the given factor (.8) and convert it to a
+// uint16, clamping values that are out of range.
+func openSimplexScale(n, factor int32) uint16 {
+	n = ((n >> 12) * factor) >> 15
+	if n > 0x7fff {
+		n = 0x7fff
+	} else if n < -0x8000 {
+		n = -0x8000
+	}
+	return uint16(n) + 0x8000
+}
+
+// Skew the 2D input coordinates to the simplex lattice. The returned
+// coordinates are the cell coordinates and the skewed offsets from the cell
+// origin (.14).
+func openSimplexSkew2(x, y uint32) (i, j uint32, xi, yi int32) {
+	const F2 = 1572067139 // .32: 0.5*(sqrt(3.0)-1.0)
+
+	// Calculate with 14 fractional bits (wrapping around) for better
+	// precision.
+	s := uint32(((uint64(x) + uint64(y)) * F2) >> 30) // .14
+	xs := x<<2 + s                                    // .14
+	ys := y<<2 + s                                    // .14
+	return xs >> 14, ys >> 14, int32(xs & 0x3fff), int32(ys & 0x3fff)
+}
+
+// Offset of the point from the lattice point (a, b), in unskewed coordinates.
+// The xi and yi parameters are the skewed offsets from the cell origin.
+func openSimplexOffset2(xi, yi int32, a, b int32) (dx, dy int32) {
+	sx := xi - a<<14                       // .14
+	sy := yi - b<<14                       // .14
+	t := ((sx + sy) * openSimplexG2) >> 16 // .14: unskew back to (x,y) space
+	return sx - t, sy - t
+}
+
+// 2D OpenSimplex2 noise.
+//
+// The x and y inputs are 20.12 fixed-point values. The result covers the full
+// range of a uint16, averaging around 32768.
+func OpenSimplex2(x, y uint32) uint16 {
+	i, j, xi, yi := openSimplexSkew2(x, y)
+
+	dx, dy := openSimplexOffset2(xi, yi, 0, 0)
+	n := openSimplexContrib2(openSimplexR2, dx, dy, openSimplexHash2(i, j))
+	dx, dy = openSimplexOffset2(xi, yi, 1, 1)
+	n += openSimplexContrib2(openSimplexR2, dx, dy, openSimplexHash2(i+1, j+1))
+	if yi > xi {
+		dx, dy = openSimplexOffset2(xi, yi, 0, 1)
+		n += openSimplexContrib2(openSimplexR2, dx, dy, openSimplexHash2(i, j+1))
+	} else {
+		dx, dy = openSimplexOffset2(xi, yi, 1, 0)
+		n += openSimplexContrib2(openSimplexR2, dx, dy, openSimplexHash2(i+1, j))
+	}
+
+	return openSimplexScale(n, 25558) // 256 / 0.01001634121365712
+}
+
+// 2D OpenSimplex2S noise. This is a smoother but slower version of
+// OpenSimplex2.
+//
+// The x and y inputs are 20.12 fixed-point values. The result covers the full
+// range of a uint16, averaging around 32768.
+func OpenSimplexSmooth2(x, y uint32) uint16 {
+	i, j, xi, yi := openSimplexSkew2(x, y)
+
+	const r2 = openSimplexR2S
+	dx, dy := openSimplexOffset2(xi, yi, 0, 0)
+	n := openSimplexContrib2(r2, dx, dy, openSimplexHash2(i, j))
+	dx, dy = openSimplexOffset2(xi, yi, 1, 1)
+	n += openSimplexContrib2(r2, dx, dy, openSimplexHash2(i+1, j+1))
+
+	// Pick the two remaining lattice points that may be within range.
+	var a2, b2, a3, b3 int32
+	if xi+yi > 1<<14 {
+		if 2*xi-yi > 1<<14 {
+			a2, b2 = 2, 1
+		} else {
+			a2, b2 = 0, 1
+		}
+		if 2*yi-xi > 1<<14 {
+			a3, b3 = 1, 2
+		} else {
+			a3, b3 = 1, 0
+		}
+	} else {
+		if 2*xi-yi < 0 {
+			a2, b2 = -1, 0
+		} else {
+			a2, b2 = 1, 0
+		}
+		if 2*yi-xi < 0 {
+			a3, b3 = 0, -1
+		} else {
+			a3, b3 = 0, 1
+		}
+	}
+	dx, dy = openSimplexOffset2(xi, yi, a2, b2)
+	n += openSimplexContrib2(r2, dx, dy, openSimplexHash2(i+uint32(a2), j+uint32(b2)))
+	dx, dy = openSimplexOffset2(xi, yi, a3, b3)
+	n += openSimplexContrib2(r2, dx, dy, openSimplexHash2(i+uint32(a3), j+uint32(b3)))
+
+	return openSimplexScale(n, 4670) // 256 / 0.05481866495625118
+}
+
+// Rotate the 3D input coordinates to the lattice orientation that works best
+// for 3D noise: the main diagonal of the lattice is aligned with the z axis.
+// The returned coordinates are .14 fixed-point values.
+func openSimplexRotate3(x, y, z uint32) (xr, yr, zr uint32) {
+	const R3 = 2863311531 // .32: 2/3
+
+	// Calculate with 14 fractional bits (wrapping around) for better
+	// precision.
+	r := uint32(((uint64(x) + uint64(y) + uint64(z)) * R3) >> 30) // .14
+	return r - x<<2, r - y<<2, r - z<<2
+}
+
+// Calculate the contribution of one of the two cubic lattices that together
+// form the body-centered cubic lattice, as used by the fast OpenSimplex2
+// variant. Only the closest point and the next closest point are used.
+func openSimplexLattice3(xr, yr, zr uint32, seed uint8) int32 {
+	// Round to the nearest lattice point.
+	xr += 1 << 13
+	yr += 1 << 13
+	zr += 1 << 13
+	i := xr >> 14
+	j := yr >> 14
+	k := zr >> 14
+	dx := int32(xr&0x3fff) - 1<<13 // .14
+	dy := int32(yr&0x3fff) - 1<<13 // .14
+	dz := int32(zr&0x3fff) - 1<<13 // .14
+
+	n := openSimplexContrib3(openSimplexR3, dx, dy, dz, openSimplexHash3(i, j, k, seed))
+
+	// Add the contribution of the neighbor along the axis where the point is
+	// the furthest away from the closest lattice point.
+	ax, ay, az := dx, dy, dz
+	if ax < 0 {
+		ax = -ax
+	}
+	if ay < 0 {
+		ay = -ay
+	}
+	if az < 0 {
+		az = -az
+	}
+	if ax >= ay && ax >= az {
+		if dx >= 0 {
+			i, dx = i+1, dx-1<<14
+		} else {
+			i, dx = i-1, dx+1<<14
+		}
+	} else if ay > ax && ay >= az {
+		if dy >= 0 {
+			j, dy = j+1, dy-1<<14
+		} else {
+			j, dy = j-1, dy+1<<14
+		}
+	} else {
+		if dz >= 0 {
+			k, dz = k+1, dz-1<<14
+		} else {
+			k, dz = k-1, dz+1<<14
+		}
+	}
+	return n + openSimplexContrib3(openSimplexR3, dx, dy, dz, openSimplexHash3(i, j, k, seed))
+}
+
+// Calculate the contribution of all points of one of the two cubic lattices
+// that together form the body-centered cubic lattice, as used by the smooth
+// OpenSimplex2S variant. All eight corners of the cube around the point are
+// checked, which includes all points within the kernel radius.
+func openSimplexLatticeSmooth3(xr, yr, zr uint32, seed uint8) int32 {
+	i := xr >> 14
+	j := yr >> 14
+	k := zr >> 14
+	dx := int32(xr & 0x3fff) // .14
+	dy := int32(yr & 0x3fff) // .14
+	dz := int32(zr & 0x3fff) // .14
+
+	var n int32
+	for c := uint32(0); c < 8; c++ {
+		ci, cj, ck := c&1, (c>>1)&1, c>>2
+		n += openSimplexContrib3(openSimplexR3S, dx-int32(ci)<<14, dy-int32(cj)<<14, dz-int32(ck)<<14, openSimplexHash3(i+ci, j+cj, k+ck, seed))
+	}
+	return n
+}
+
+// 3D OpenSimplex2 noise.
+//
+// The x, y and z inputs are 20.12 fixed-point values. The result covers the
+// full range of a uint16, averaging around 32768.
+func OpenSimplex3(x, y, z uint32) uint16 {
+	xr, yr, zr := openSimplexRotate3(x, y, z)
+
+	// The second lattice is offset by (0.5, 0.5, 0.5).
+	n := openSimplexLattice3(xr, yr, zr, 0)
+	n += openSimplexLattice3(xr+1<<13, yr+1<<13, zr+1<<13, 0x80)
+
+	return openSimplexScale(n, 10449) // 256 / 0.0245, determined experimentally
+}
+
+// 3D OpenSimplex2S noise. This is a smoother but slower version of
+// OpenSimplex2.
+//
+// The x, y and z inputs are 20.12 fixed-point values. The result covers the
+// full range of a uint16, averaging around 32768.
+func OpenSimplexSmooth3(x, y, z uint32) uint16 {
+	xr, yr, zr := openSimplexRotate3(x, y, z)
+
+	// The second lattice is offset by (0.5, 0.5, 0.5).
+	n := openSimplexLatticeSmooth3(xr, yr, zr, 0)
+	n += openSimplexLatticeSmooth3(xr+1<<13, yr+1<<13, zr+1<<13, 0x80)
+
+	return openSimplexScale(n, 3038) // 256 / (0.2781926117527186 / 3.3013602477694275)
+}
+
+// 4D OpenSimplex2 noise.
+//
+// The x, y, z and w inputs are 20.12 fixed-point values. The result covers the
+// full range of a uint16, averaging around 32768.
+func OpenSimplex4(x, y, z, w uint32) uint16 {
+	const G4 = 593549882 // .32: (5.0-Math.sqrt(5.0))/20.0 = 0.1381966011250105
+
+	// Skew the input space. Unlike simplex noise, the skew factor is negative.
+	// Calculate with 14 fractional bits (wrapping around) for better
+	// precision.
+	s := uint32(((uint64(x) + uint64(y) + uint64(z) + uint64(w)) * G4) >> 30) // .14
+	xs := x<<2 - s                                                            // .14
+	ys := y<<2 - s                                                            // .14
+	zs := z<<2 - s                                                            // .14
+	ws := w<<2 - s                                                            // .14
+	xsi0 := int32(xs & 0x3fff)                                                // .14
+	ysi0 := int32(ys & 0x3fff)                                                // .14
+	zsi0 := int32(zs & 0x3fff)                                                // .14
+	wsi0 := int32(ws & 0x3fff)                                                // .14
+
+	// The lattice consists of five copies of a cubic lattice, each offset by
+	// (0.2, 0.2, 0.2, 0.2) from the previous one. Start with the lattice that
+	// is closest to the point.
+	startingLattice := ((xsi0 + ysi0 + zsi0 + wsi0) * 5) >> 16 // int(sum * 1.25)
+	lattice := startingLattice
+
+	// Lattice point that is being considered, relative to the cell origin.
+	var mx, my, mz, mw int32
+	// Cell origin, changes when wrapping around to the last lattice.
+	i, j, k, l := xs>>14, ys>>14, zs>>14, ws>>14
+
+	var n int32
+	for iter := int32(0); ; iter++ {
+		offset := ((iter - startingLattice) << 14) / 5 // .14
+		xsi := xsi0 - mx<<14 + offset                  // .14
+		ysi := ysi0 - my<<14 + offset                  // .14
+		zsi := zsi0 - mz<<14 + offset                  // .14
+		wsi := wsi0 - mw<<14 + offset                  // .14
+
+		// Pick the lattice vertex closest to the point.
+		score0 := 1<<14 - (xsi + ysi + zsi + wsi)
+		if xsi >= ysi && xsi >= zsi && xsi >= wsi && xsi >= score0 {
+			mx++
+			xsi -= 1 << 14
+		} else if ysi > xsi && ysi >= zsi && ysi >= wsi && ysi >= score0 {
+			my++
+			ysi -= 1 << 14
+		} else if zsi > xsi && zsi > ysi && zsi >= wsi && zsi >= score0 {
+			mz++
+			zsi -= 1 << 14
+		} else if wsi > xsi && wsi > ysi && wsi > zsi && wsi >= score0 {
+			mw++
+			wsi -= 1 << 14
+		}
+
+		// Unskew the offset back to (x,y,z,w) space.
+		ssi := ((xsi + ysi + zsi + wsi) * openSimplexUnskew4) >> 14 // .14
+		dx := xsi + ssi                                             // .14
+		dy := ysi + ssi                                             // .14
+		dz := zsi + ssi                                             // .14
+		dw := wsi + ssi                                             // .14
+		hash := openSimplexHash4(i+uint32(mx), j+uint32(my), k+uint32(mz), l+uint32(mw), uint8(lattice)*0x33)
+		n += openSimplexContrib4(openSimplexR4, dx, dy, dz, dw, hash)
+
+		if iter == 4 {
+			break
+		}
+
+		// Move to the next lattice. Lattice -1 is the same as lattice 4 in the
+		// previous cell.
+		lattice--
+		if lattice < 0 {
+			lattice += 5
+			i--
+			j--
+			k--
+			l--
+		}
+	}
+
+	return openSimplexScale(n, 11633) // 256 / 0.0220065933241897
+}
+
+// 4D OpenSimplex2S noise. This is a smoother but much slower version of
+// OpenSimplex4: it adds the contributions of all lattice points within a
+// larger radius, which are looked up in a table.
+//
+// The x, y, z and w inputs are 20.12 fixed-point values. The result covers the
+// full range of a uint16, averaging around 32768.
+func OpenSimplexSmooth4(x, y, z, w uint32) uint16 {
+	const F4 = 1327217885 // .32: (sqrt(5)-1)/4 = 0.309016994374947
+	const G4 = 9057       // .16: (5-sqrt(5))/20 = 0.1381966011250105
+
+	// Skew the input space to the simplex lattice, like Noise4. Calculate
+	// with 14 fractional bits (wrapping around) for better precision.
+	s := uint32(((uint64(x) + uint64(y) + uint64(z) + uint64(w)) * F4) >> 30) // .14
+	xs := x<<2 + s                                                            // .14
+	ys := y<<2 + s                                                            // .14
+	zs := z<<2 + s                                                            // .14
+	ws := w<<2 + s                                                            // .14
+	i, j, k, l := xs>>14, ys>>14, zs>>14, ws>>14
+	xsi := int32(xs & 0x3fff) // .14
+	ysi := int32(ys & 0x3fff) // .14
+	zsi := int32(zs & 0x3fff) // .14
+	wsi := int32(ws & 0x3fff) // .14
+	sum := xsi + ysi + zsi + wsi
+
+	// The lattice vertices that may be within range depend on the part of
+	// the cell the point is in (a quarter of the cell in each dimension).
+	index := xsi>>12 | (ysi>>12)<<2 | (zsi>>12)<<4 | (wsi>>12)<<6
+	var n int32
+	for _, vertex := range openSimplexVertices4[openSimplexLookup4[index]:openSimplexLookup4[index+1]] {
+		vx := int32(vertex&3) - 1
+		vy := int32(vertex>>2&3) - 1
+		vz := int32(vertex>>4&3) - 1
+		vw := int32(vertex>>6) - 1
+
+		// Unskew the offset from the lattice vertex back to (x,y,z,w) space.
+		t := ((sum - (vx+vy+vz+vw)<<14) * G4) >> 16 // .14
+		dx := xsi - vx<<14 - t                      // .14
+		dy := ysi - vy<<14 - t                      // .14
+		dz := zsi - vz<<14 - t                      // .14
+		dw := wsi - vw<<14 - t                      // .14
+		hash := openSimplexHash4(i+uint32(vx), j+uint32(vy), k+uint32(vz), l+uint32(vw), 0)
+
+		// Halve the contributions, so that the sum fits in an int32.
+		n += openSimplexContrib4(openSimplexR4S, dx, dy, dz, dw, hash) >> 1
+	}
+
+	return openSimplexScale(n, 4601) // 512 / 0.11127401889945551
+}
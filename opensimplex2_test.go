@@ -0,0 +1,506 @@
+package ledsgo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// This file contains floating point versions of the OpenSimplex2 noise
+// functions, which follow the structure of the original implementation by
+// KdotJPG (except for OpenSimplexSmooth4, which simply checks all nearby
+// lattice points instead of using a lookup table). They use the same hash
+// functions and gradient tables as the fixed-point implementations, so the
+// tests only check the precision of the fixed-point math: they can't find
+// mistakes in the hash or in the selection of gradients. The output can't be
+// compared with the original implementation because the hash is different.
+// The gradient tables are checked separately in TestOpenSimplexGrad4.
+
+func openSimplexGrad2Float(hash uint8, dx, dy float64) float64 {
+	g := openSimplexGrad2[(uint32(hash)*24)>>8]
+	return (float64(g[0])*dx + float64(g[1])*dy) / (1 << 14)
+}
+
+func openSimplexGrad3Float(hash uint8, dx, dy, dz float64) float64 {
+	g := openSimplexGrad3[(uint32(hash)*48)>>8]
+	return (float64(g[0])*dx + float64(g[1])*dy + float64(g[2])*dz) / (1 << 14)
+}
+
+func openSimplexGrad4Float(hash uint8, dx, dy, dz, dw float64) float64 {
+	g := openSimplexGrad4[(uint32(hash)*160)>>8]
+	return (float64(g[0])*dx + float64(g[1])*dy + float64(g[2])*dz + float64(g[3])*dw) / (1 << 14)
+}
+
+func openSimplex2Float(x, y float64, smooth bool) float64 {
+	const unskew = -0.21132486540518713
+	r2 := 0.5
+	if smooth {
+		r2 = 2.0 / 3.0
+	}
+	s := 0.366025403784439 * (x + y)
+	xs := x + s
+	ys := y + s
+	xsb := math.Floor(xs)
+	ysb := math.Floor(ys)
+	xi := xs - xsb
+	yi := ys - ysb
+	i := uint32(int64(xsb))
+	j := uint32(int64(ysb))
+
+	t := (xi + yi) * unskew
+	dx0 := xi + t
+	dy0 := yi + t
+
+	value := 0.0
+	contrib := func(di, dj int, dx, dy float64) {
+		a := r2 - dx*dx - dy*dy
+		if a > 0 {
+			value += (a * a) * (a * a) * openSimplexGrad2Float(openSimplexHash2(i+uint32(di), j+uint32(dj)), dx, dy)
+		}
+	}
+	contrib(0, 0, dx0, dy0)
+	contrib(1, 1, dx0-(1+2*unskew), dy0-(1+2*unskew))
+	if !smooth {
+		if dy0 > dx0 {
+			contrib(0, 1, dx0-unskew, dy0-(unskew+1))
+		} else {
+			contrib(1, 0, dx0-(unskew+1), dy0-unskew)
+		}
+		return value / 0.01001634121365712
+	}
+
+	xmyi := xi - yi
+	if t < unskew {
+		if xi+xmyi > 1 {
+			contrib(2, 1, dx0-(3*unskew+2), dy0-(3*unskew+1))
+		} else {
+			contrib(0, 1, dx0-unskew, dy0-(unskew+1))
+		}
+		if yi-xmyi > 1 {
+			contrib(1, 2, dx0-(3*unskew+1), dy0-(3*unskew+2))
+		} else {
+			contrib(1, 0, dx0-(unskew+1), dy0-unskew)
+		}
+	} else {
+		if xi+xmyi < 0 {
+			contrib(-1, 0, dx0+(1+unskew), dy0+unskew)
+		} else {
+			contrib(1, 0, dx0-(unskew+1), dy0-unskew)
+		}
+		if yi < xmyi {
+			contrib(0, -1, dx0+unskew, dy0+(unskew+1))
+		} else {
+			contrib(0, 1, dx0-unskew, dy0-(unskew+1))
+		}
+	}
+	return value / 0.05481866495625118
+}
+
+func openSimplex3Float(x, y, z float64, smooth bool) float64 {
+	r := (2.0 / 3.0) * (x + y + z)
+	xr := r - x
+	yr := r - y
+	zr := r - z
+
+	value := 0.0
+	if smooth {
+		// Evaluate all points of both lattices within the kernel radius.
+		for lattice := 0; lattice < 2; lattice++ {
+			offset := 0.5 * float64(lattice)
+			for di := -2.0; di <= 2; di++ {
+				for dj := -2.0; dj <= 2; dj++ {
+					for dk := -2.0; dk <= 2; dk++ {
+						pi := math.Floor(xr+offset) + di
+						pj := math.Floor(yr+offset) + dj
+						pk := math.Floor(zr+offset) + dk
+						dx := xr + offset - pi
+						dy := yr + offset - pj
+						dz := zr + offset - pk
+						a := 0.75 - dx*dx - dy*dy - dz*dz
+						if a > 0 {
+							hash := openSimplexHash3(uint32(int64(pi)), uint32(int64(pj)), uint32(int64(pk)), uint8(lattice*0x80))
+							value += (a * a) * (a * a) * openSimplexGrad3Float(hash, dx, dy, dz)
+						}
+					}
+				}
+			}
+		}
+		return value / (0.2781926117527186 / 3.3013602477694275)
+	}
+
+	xrb := math.Floor(xr + 0.5)
+	yrb := math.Floor(yr + 0.5)
+	zrb := math.Floor(zr + 0.5)
+	xri := xr - xrb
+	yri := yr - yrb
+	zri := zr - zrb
+	xNSign := 1.0
+	if xri >= 0 {
+		xNSign = -1
+	}
+	yNSign := 1.0
+	if yri >= 0 {
+		yNSign = -1
+	}
+	zNSign := 1.0
+	if zri >= 0 {
+		zNSign = -1
+	}
+	ax0 := xNSign * -xri
+	ay0 := yNSign * -yri
+	az0 := zNSign * -zri
+	i := uint32(int64(xrb))
+	j := uint32(int64(yrb))
+	k := uint32(int64(zrb))
+	seed := uint8(0)
+
+	a := (0.6 - xri*xri) - (yri*yri + zri*zri)
+	for l := 0; ; l++ {
+		if a > 0 {
+			value += (a * a) * (a * a) * openSimplexGrad3Float(openSimplexHash3(i, j, k, seed), xri, yri, zri)
+		}
+		if ax0 >= ay0 && ax0 >= az0 {
+			b := a + ax0 + ax0
+			if b > 1 {
+				b -= 1
+				value += (b * b) * (b * b) * openSimplexGrad3Float(openSimplexHash3(i-uint32(int32(xNSign)), j, k, seed), xri+xNSign, yri, zri)
+			}
+		} else if ay0 > ax0 && ay0 >= az0 {
+			b := a + ay0 + ay0
+			if b > 1 {
+				b -= 1
+				value += (b * b) * (b * b) * openSimplexGrad3Float(openSimplexHash3(i, j-uint32(int32(yNSign)), k, seed), xri, yri+yNSign, zri)
+			}
+		} else {
+			b := a + az0 + az0
+			if b > 1 {
+				b -= 1
+				value += (b * b) * (b * b) * openSimplexGrad3Float(openSimplexHash3(i, j, k-uint32(int32(zNSign)), seed), xri, yri, zri+zNSign)
+			}
+		}
+		if l == 1 {
+			break
+		}
+		ax0 = 0.5 - ax0
+		ay0 = 0.5 - ay0
+		az0 = 0.5 - az0
+		xri = xNSign * ax0
+		yri = yNSign * ay0
+		zri = zNSign * az0
+		a += (0.75 - ax0) - (ay0 + az0)
+		if xNSign < 0 {
+			i++
+		}
+		if yNSign < 0 {
+			j++
+		}
+		if zNSign < 0 {
+			k++
+		}
+		xNSign = -xNSign
+		yNSign = -yNSign
+		zNSign = -zNSign
+		seed ^= 0x80
+	}
+	return value / 0.0245
+}
+
+func openSimplex4Float(x, y, z, w float64) float64 {
+	const unskew = 0.309016994374947
+	const latticeStep = 0.2
+	s := -0.138196601125011 * (x + y + z + w)
+	xs := x + s
+	ys := y + s
+	zs := z + s
+	ws := w + s
+	xsb := math.Floor(xs)
+	ysb := math.Floor(ys)
+	zsb := math.Floor(zs)
+	wsb := math.Floor(ws)
+	xsi := xs - xsb
+	ysi := ys - ysb
+	zsi := zs - zsb
+	wsi := ws - wsb
+	i := uint32(int64(xsb))
+	j := uint32(int64(ysb))
+	k := uint32(int64(zsb))
+	l := uint32(int64(wsb))
+
+	siSum := (xsi + ysi) + (zsi + wsi)
+	startingLattice := int(siSum * 1.25)
+	seed := startingLattice
+	startingLatticeOffset := float64(startingLattice) * -latticeStep
+	xsi += startingLatticeOffset
+	ysi += startingLatticeOffset
+	zsi += startingLatticeOffset
+	wsi += startingLatticeOffset
+	ssi := (siSum + startingLatticeOffset*4) * unskew
+
+	value := 0.0
+	for n := 0; ; n++ {
+		score0 := 1.0 + ssi*(-1.0/unskew)
+		if xsi >= ysi && xsi >= zsi && xsi >= wsi && xsi >= score0 {
+			i++
+			xsi -= 1
+			ssi -= unskew
+		} else if ysi > xsi && ysi >= zsi && ysi >= wsi && ysi >= score0 {
+			j++
+			ysi -= 1
+			ssi -= unskew
+		} else if zsi > xsi && zsi > ysi && zsi >= wsi && zsi >= score0 {
+			k++
+			zsi -= 1
+			ssi -= unskew
+		} else if wsi > xsi && wsi > ysi && wsi > zsi && wsi >= score0 {
+			l++
+			wsi -= 1
+			ssi -= unskew
+		}
+
+		dx := xsi + ssi
+		dy := ysi + ssi
+		dz := zsi + ssi
+		dw := wsi + ssi
+		a := (dx*dx + dy*dy) + (dz*dz + dw*dw)
+		if a < 0.6 {
+			a -= 0.6
+			a *= a
+			hash := openSimplexHash4(i, j, k, l, uint8(seed)*0x33)
+			value += a * a * openSimplexGrad4Float(hash, dx, dy, dz, dw)
+		}
+
+		if n == 4 {
+			break
+		}
+
+		xsi += latticeStep
+		ysi += latticeStep
+		zsi += latticeStep
+		wsi += latticeStep
+		ssi += latticeStep * 4 * unskew
+		seed--
+		if n == startingLattice {
+			i--
+			j--
+			k--
+			l--
+			seed += 5
+		}
+	}
+	return value / 0.0220065933241897
+}
+
+func openSimplexSmooth4Float(x, y, z, w float64) float64 {
+	const unskew = -0.138196601125011
+	s := 0.309016994374947 * (x + y + z + w)
+	xsb := math.Floor(x + s)
+	ysb := math.Floor(y + s)
+	zsb := math.Floor(z + s)
+	wsb := math.Floor(w + s)
+
+	// Check all lattice points around the cell. Points that are further away
+	// are never within the kernel radius.
+	value := 0.0
+	for vx := xsb - 1; vx <= xsb+2; vx++ {
+		for vy := ysb - 1; vy <= ysb+2; vy++ {
+			for vz := zsb - 1; vz <= zsb+2; vz++ {
+				for vw := wsb - 1; vw <= wsb+2; vw++ {
+					t := (vx + vy + vz + vw) * unskew
+					dx := x - (vx + t)
+					dy := y - (vy + t)
+					dz := z - (vz + t)
+					dw := w - (vw + t)
+					a := 0.8 - (dx*dx + dy*dy) - (dz*dz + dw*dw)
+					if a > 0 {
+						hash := openSimplexHash4(uint32(int64(vx)), uint32(int64(vy)), uint32(int64(vz)), uint32(int64(vw)), 0)
+						value += (a * a) * (a * a) * openSimplexGrad4Float(hash, dx, dy, dz, dw)
+					}
+				}
+			}
+		}
+	}
+	return value / 0.11127401889945551
+}
+
+// Compare a fixed-point noise function against its floating point version at
+// random points, to check the precision of the fixed-point math.
+func testOpenSimplex(t *testing.T, dims, numTests int, maxAvg, maxDiff float64, float func(pos []float64) float64, noise func(pos []uint32) uint16) {
+	r := rand.NewSource(0)
+	pos := make([]uint32, dims)
+	posf := make([]float64, dims)
+	rangemax := 0.0
+	rangemin := 0.0
+	rangesum := 0.0
+	diffsum := 0.0
+	diffmax := 0.0
+	diffmin := 0.0
+	for n := 0; n < numTests; n++ {
+		for i := range pos {
+			pos[i] = uint32(r.Int63())
+			posf[i] = float64(pos[i]) / 0x1000
+		}
+		n1 := float(posf)
+		if n1 > 1.0001 || n1 < -1.0001 {
+			t.Fatalf("floating point output %f at %v is out of range, the normalizer is wrong", n1, posf)
+		}
+		n2 := float64(int16(noise(pos)-0x8000)) / 0x8000
+		rangesum += n2
+		if n2 > rangemax {
+			rangemax = n2
+		}
+		if n2 < rangemin {
+			rangemin = n2
+		}
+		diff := n1 - n2
+		diffsum += math.Abs(diff)
+		if diff > diffmax {
+			diffmax = diff
+		}
+		if diff < diffmin {
+			diffmin = diff
+		}
+	}
+	rangeavg := rangesum / float64(numTests)
+	diffavg := diffsum / float64(numTests)
+	t.Logf("number of tests: %d", numTests)
+	t.Logf("range: avg %+2.6f max %+2.6f min %+2.6f", rangeavg, rangemax, rangemin)
+	t.Logf("diff:  avg %+2.6f max %+2.6f min %+2.6f", diffavg, diffmax, diffmin)
+	if diffavg >= maxAvg {
+		t.Errorf("diff avg between float and fixed-point is too big: %f", diffavg)
+	}
+	if diffmax > maxDiff {
+		t.Errorf("diff max is too high: %f", diffmax)
+	}
+	if diffmin < -maxDiff {
+		t.Errorf("diff min is too low: %f", diffmin)
+	}
+	if rangemax < 0.5 || rangemin > -0.5 {
+		t.Errorf("output does not cover enough of the range: %f..%f", rangemin, rangemax)
+	}
+}
+
+func TestOpenSimplex2(t *testing.T) {
+	testOpenSimplex(t, 2, 4000000, 0.0008, 0.005, func(pos []float64) float64 {
+		return openSimplex2Float(pos[0], pos[1], false)
+	}, func(pos []uint32) uint16 {
+		return OpenSimplex2(pos[0], pos[1])
+	})
+}
+
+func TestOpenSimplexSmooth2(t *testing.T) {
+	testOpenSimplex(t, 2, 4000000, 0.0008, 0.005, func(pos []float64) float64 {
+		return openSimplex2Float(pos[0], pos[1], true)
+	}, func(pos []uint32) uint16 {
+		return OpenSimplexSmooth2(pos[0], pos[1])
+	})
+}
+
+func TestOpenSimplex3(t *testing.T) {
+	testOpenSimplex(t, 3, 4000000, 0.0008, 0.008, func(pos []float64) float64 {
+		return openSimplex3Float(pos[0], pos[1], pos[2], false)
+	}, func(pos []uint32) uint16 {
+		return OpenSimplex3(pos[0], pos[1], pos[2])
+	})
+}
+
+func TestOpenSimplexSmooth3(t *testing.T) {
+	testOpenSimplex(t, 3, 1000000, 0.0008, 0.008, func(pos []float64) float64 {
+		return openSimplex3Float(pos[0], pos[1], pos[2], true)
+	}, func(pos []uint32) uint16 {
+		return OpenSimplexSmooth3(pos[0], pos[1], pos[2])
+	})
+}
+
+func TestOpenSimplex4(t *testing.T) {
+	testOpenSimplex(t, 4, 2000000, 0.0008, 0.008, func(pos []float64) float64 {
+		return openSimplex4Float(pos[0], pos[1], pos[2], pos[3])
+	}, func(pos []uint32) uint16 {
+		return OpenSimplex4(pos[0], pos[1], pos[2], pos[3])
+	})
+}
+
+func TestOpenSimplexSmooth4(t *testing.T) {
+	testOpenSimplex(t, 4, 200000, 0.0008, 0.008, func(pos []float64) float64 {
+		return openSimplexSmooth4Float(pos[0], pos[1], pos[2], pos[3])
+	}, func(pos []uint32) uint16 {
+		return OpenSimplexSmooth4(pos[0], pos[1], pos[2], pos[3])
+	})
+}
+
+func TestOpenSimplexGrad4(t *testing.T) {
+	// The first gradients of the reference implementation (OpenSimplex2.java).
+	reference := [][4]float64{
+		{-0.6740059517812944, -0.3239847771997537, -0.3239847771997537, 0.5794684678643381},
+		{-0.7504883828755602, -0.4004672082940195, 0.15296486218853164, 0.5029860367700724},
+		{-0.7504883828755602, 0.15296486218853164, -0.4004672082940195, 0.5029860367700724},
+		{-0.8828161875373585, 0.08164729285680945, 0.08164729285680945, 0.4553054119602712},
+		{-0.4553054119602712, -0.08164729285680945, -0.08164729285680945, 0.8828161875373585},
+		{-0.5029860367700724, -0.15296486218853164, 0.4004672082940195, 0.7504883828755602},
+		{-0.5029860367700724, 0.4004672082940195, -0.15296486218853164, 0.7504883828755602},
+		{-0.5794684678643381, 0.3239847771997537, 0.3239847771997537, 0.6740059517812944},
+	}
+	for _, r := range reference {
+		found := false
+		for _, g := range openSimplexGrad4 {
+			diff := 0.0
+			for i := range g {
+				diff = math.Max(diff, math.Abs(float64(g[i])/(1<<14)-r[i]))
+			}
+			found = found || diff < 1.0/(1<<14)
+		}
+		if !found {
+			t.Errorf("gradient %v of the reference implementation not found", r)
+		}
+	}
+
+	// All gradients are unique unit vectors, and the negation of every
+	// gradient is also a gradient.
+	for i, g := range openSimplexGrad4 {
+		length := math.Sqrt(float64(g[0])*float64(g[0])+float64(g[1])*float64(g[1])+float64(g[2])*float64(g[2])+float64(g[3])*float64(g[3])) / (1 << 14)
+		if math.Abs(length-1) > 0.0002 {
+			t.Errorf("gradient %d has length %f", i, length)
+		}
+		negated := false
+		for j, g2 := range openSimplexGrad4 {
+			if i != j && g2 == g {
+				t.Errorf("gradient %d is the same as gradient %d", i, j)
+			}
+			negated = negated || g2 == [4]int16{-g[0], -g[1], -g[2], -g[3]}
+		}
+		if !negated {
+			t.Errorf("negation of gradient %d not found", i)
+		}
+	}
+}
+
+func BenchmarkOpenSimplex2(b *testing.B) {
+	var r uint16
+	for n := 0; n < b.N; n++ {
+		r = OpenSimplex2(uint32(n), uint32(n))
+	}
+	resultUint16 = r
+}
+
+func BenchmarkOpenSimplex3(b *testing.B) {
+	var r uint16
+	for n := 0; n < b.N; n++ {
+		r = OpenSimplex3(uint32(n), uint32(n), uint32(n))
+	}
+	resultUint16 = r
+}
+
+func BenchmarkOpenSimplex4(b *testing.B) {
+	var r uint16
+	for n := 0; n < b.N; n++ {
+		r = OpenSimplex4(uint32(n), uint32(n), uint32(n), uint32(n))
+	}
+	resultUint16 = r
+}
+
+func BenchmarkOpenSimplexSmooth4(b *testing.B) {
+	var r uint16
+	for n := 0; n < b.N; n++ {
+		r = OpenSimplexSmooth4(uint32(n), uint32(n), uint32(n), uint32(n))
+	}
+	resultUint16 = r
+}
@@ -0,0 +1,315 @@
+// Code generated by genopensimplex.go; DO NOT EDIT.
+
+package ledsgo
+
+// Gradients for 4D OpenSimplex2 noise: 160 unit vectors.
+var openSimplexGrad4 = [160][4]int16{ // .14
+	{-12815, -7080, -7080, 1987}, {-11043, -5308, -5308, 9494}, {-12815, -7080, 1987, -7080}, {-8241, -2506, 6561, 12296},
+	{-11043, -5308, 9494, -5308}, {-8241, -2506, 12296, 6561}, {-12815, 1987, -7080, -7080}, {-8241, 6561, -2506, 12296},
+	{-8241, 6561, 12296, -2506}, {-11043, 9494, -5308, -5308}, {-8241, 12296, -2506, 6561}, {-8241, 12296, 6561, -2506},
+	{-7080, -12815, -7080, 1987}, {-5308, -11043, -5308, 9494}, {-7080, -12815, 1987, -7080}, {-2506, -8241, 6561, 12296},
+	{-5308, -11043, 9494, -5308}, {-2506, -8241, 12296, 6561}, {-7080, -7080, -12815, 1987}, {-5308, -5308, -11043, 9494},
+	{-7080, -7080, 1987, -12815}, {-734, -734, 8333, 14068}, {-5308, -5308, 9494, -11043}, {-734, -734, 14068, 8333},
+	{-7080, 1987, -12815, -7080}, {-2506, 6561, -8241, 12296}, {-7080, 1987, -7080, -12815}, {-734, 8333, -734, 14068},
+	{-2506, 6561, 12296, -8241}, {-734, 8333, 14068, -734}, {-5308, 9494, -11043, -5308}, {-2506, 12296, -8241, 6561},
+	{-5308, 9494, -5308, -11043}, {-734, 14068, -734, 8333}, {-2506, 12296, 6561, -8241}, {-734, 14068, 8333, -734},
+	{1987, -12815, -7080, -7080}, {6561, -8241, -2506, 12296}, {6561, -8241, 12296, -2506}, {1987, -7080, -12815, -7080},
+	{6561, -2506, -8241, 12296}, {1987, -7080, -7080, -12815}, {8333, -734, -734, 14068}, {6561, -2506, 12296, -8241},
+	{8333, -734, 14068, -734}, {6561, 12296, -8241, -2506}, {6561, 12296, -2506, -8241}, {8333, 14068, -734, -734},
+	{9494, -11043, -5308, -5308}, {12296, -8241, -2506, 6561}, {12296, -8241, 6561, -2506}, {9494, -5308, -11043, -5308},
+	{12296, -2506, -8241, 6561}, {9494, -5308, -5308, -11043}, {14068, -734, -734, 8333}, {12296, -2506, 6561, -8241},
+	{14068, -734, 8333, -734}, {12296, 6561, -8241, -2506}, {12296, 6561, -2506, -8241}, {14068, 8333, -734, -734},
+	{-14068, -8333, 734, 734}, {-12296, -6561, 2506, 8241}, {-12296, -6561, 8241, 2506}, {-14068, 734, -8333, 734},
+	{-12296, 2506, -6561, 8241}, {-14068, 734, 734, -8333}, {-9494, 5308, 5308, 11043}, {-12296, 2506, 8241, -6561},
+	{-9494, 5308, 11043, 5308}, {-12296, 8241, -6561, 2506}, {-12296, 8241, 2506, -6561}, {-9494, 11043, 5308, 5308},
+	{-8333, -14068, 734, 734}, {-6561, -12296, 2506, 8241}, {-6561, -12296, 8241, 2506}, {-8333, 734, -14068, 734},
+	{-6561, 2506, -12296, 8241}, {-8333, 734, 734, -14068}, {-1987, 7080, 7080, 12815}, {-6561, 2506, 8241, -12296},
+	{-1987, 7080, 12815, 7080}, {-6561, 8241, -12296, 2506}, {-6561, 8241, 2506, -12296}, {-1987, 12815, 7080, 7080},
+	{734, -14068, -8333, 734}, {2506, -12296, -6561, 8241}, {734, -14068, 734, -8333}, {5308, -9494, 5308, 11043},
+	{2506, -12296, 8241, -6561}, {5308, -9494, 11043, 5308}, {734, -8333, -14068, 734}, {2506, -6561, -12296, 8241},
+	{734, -8333, 734, -14068}, {7080, -1987, 7080, 12815}, {2506, -6561, 8241, -12296}, {7080, -1987, 12815, 7080},
+	{734, 734, -14068, -8333}, {5308, 5308, -9494, 11043}, {734, 734, -8333, -14068}, {7080, 7080, -1987, 12815},
+	{5308, 5308, 11043, -9494}, {7080, 7080, 12815, -1987}, {2506, 8241, -12296, -6561}, {5308, 11043, -9494, 5308},
+	{2506, 8241, -6561, -12296}, {7080, 12815, -1987, 7080}, {5308, 11043, 5308, -9494}, {7080, 12815, 7080, -1987},
+	{8241, -12296, -6561, 2506}, {8241, -12296, 2506, -6561}, {11043, -9494, 5308, 5308}, {8241, -6561, -12296, 2506},
+	{8241, -6561, 2506, -12296}, {12815, -1987, 7080, 7080}, {8241, 2506, -12296, -6561}, {11043, 5308, -9494, 5308},
+	{8241, 2506, -6561, -12296}, {12815, 7080, -1987, 7080}, {11043, 5308, 5308, -9494}, {12815, 7080, 7080, -1987},
+	{-16356, -554, -554, -554}, {-14464, 1338, 1338, 7460}, {-14464, 1338, 7460, 1338}, {-14464, 7460, 1338, 1338},
+	{-554, -16356, -554, -554}, {1338, -14464, 1338, 7460}, {1338, -14464, 7460, 1338}, {-554, -554, -16356, -554},
+	{1338, 1338, -14464, 7460}, {-554, -554, -554, -16356}, {6221, 6221, 6221, 12343}, {1338, 1338, 7460, -14464},
+	{6221, 6221, 12343, 6221}, {1338, 7460, -14464, 1338}, {1338, 7460, 1338, -14464}, {6221, 12343, 6221, 6221},
+	{7460, -14464, 1338, 1338}, {7460, 1338, -14464, 1338}, {7460, 1338, 1338, -14464}, {12343, 6221, 6221, 6221},
+	{-12343, -6221, -6221, -6221}, {-7460, -1338, -1338, 14464}, {-7460, -1338, 14464, -1338}, {-7460, 14464, -1338, -1338},
+	{-6221, -12343, -6221, -6221}, {-1338, -7460, -1338, 14464}, {-1338, -7460, 14464, -1338}, {-6221, -6221, -12343, -6221},
+	{-1338, -1338, -7460, 14464}, {-6221, -6221, -6221, -12343}, {554, 554, 554, 16356}, {-1338, -1338, 14464, -7460},
+	{554, 554, 16356, 554}, {-1338, 14464, -7460, -1338}, {-1338, 14464, -1338, -7460}, {554, 16356, 554, 554},
+	{14464, -7460, -1338, -1338}, {14464, -1338, -7460, -1338}, {14464, -1338, -1338, -7460}, {16356, 554, 554, 554},
+}
+
+// Start of the list of lattice vertices for each sub-cell of
+// OpenSimplexSmooth4 in openSimplexVertices4.
+var openSimplexLookup4 = [257]uint16{
+	0, 21, 40, 60, 78, 97, 113, 127, 146, 166, 180, 190, 208, 226, 245, 263,
+	280, 299, 315, 329, 348, 364, 378, 392, 405, 419, 433, 444, 456, 475, 488, 500,
+	518, 538, 552, 562, 580, 594, 608, 619, 631, 641, 652, 662, 675, 693, 705, 718,
+	737, 755, 774, 792, 809, 828, 841, 853, 871, 889, 901, 914, 933, 950, 968, 987,
+	1005, 1024, 1040, 1054, 1073, 1089, 1103, 1117, 1130, 1144, 1158, 1169, 1181, 1200, 1213, 1225,
+	1243, 1259, 1273, 1287, 1300, 1314, 1334, 1354, 1364, 1378, 1398, 1417, 1428, 1441, 1451, 1462,
+	1472, 1486, 1500, 1511, 1523, 1537, 1557, 1576, 1587, 1598, 1617, 1637, 1651, 1663, 1674, 1688,
+	1702, 1721, 1734, 1746, 1764, 1777, 1787, 1798, 1808, 1820, 1831, 1845, 1859, 1877, 1887, 1901,
+	1921, 1941, 1955, 1965, 1983, 1997, 2011, 2022, 2034, 2044, 2055, 2065, 2078, 2096, 2108, 2121,
+	2140, 2154, 2168, 2179, 2191, 2205, 2225, 2244, 2255, 2266, 2285, 2305, 2319, 2331, 2342, 2356,
+	2370, 2380, 2391, 2401, 2414, 2425, 2444, 2464, 2478, 2488, 2508, 2528, 2542, 2555, 2569, 2583,
+	2599, 2617, 2629, 2642, 2661, 2673, 2684, 2698, 2712, 2725, 2739, 2753, 2769, 2788, 2802, 2818,
+	2837, 2855, 2874, 2892, 2909, 2928, 2941, 2953, 2971, 2989, 3001, 3014, 3033, 3050, 3068, 3087,
+	3105, 3124, 3137, 3149, 3167, 3180, 3190, 3201, 3211, 3223, 3234, 3248, 3262, 3280, 3290, 3304,
+	3324, 3342, 3354, 3367, 3386, 3398, 3409, 3423, 3437, 3450, 3464, 3478, 3494, 3513, 3527, 3543,
+	3562, 3579, 3597, 3616, 3634, 3652, 3662, 3676, 3696, 3715, 3729, 3745, 3764, 3782, 3802, 3821,
+	3842,
+}
+
+// Lattice vertices relative to the cell origin, with 2 bits per axis
+// (x in the lowest bits) storing the offset plus one.
+var openSimplexVertices4 = [3842]uint8{
+	0x00, 0x15, 0x45, 0x51, 0x54, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x95, 0x96, 0x99,
+	0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0x01, 0x05, 0x11, 0x15, 0x41, 0x45, 0x51, 0x55, 0x56, 0x59, 0x5a,
+	0x65, 0x66, 0x6a, 0x95, 0x96, 0x9a, 0xa6, 0xaa, 0x01, 0x05, 0x11, 0x15, 0x41, 0x45, 0x51, 0x55,
+	0x56, 0x59, 0x5a, 0x65, 0x66, 0x6a, 0x95, 0x96, 0x9a, 0xa6, 0xaa, 0xab, 0x01, 0x15, 0x16, 0x45,
+	0x46, 0x51, 0x52, 0x55, 0x56, 0x57, 0x5a, 0x66, 0x6a, 0x96, 0x9a, 0xa6, 0xaa, 0xab, 0x04, 0x05,
+	0x14, 0x15, 0x44, 0x45, 0x54, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x69, 0x6a, 0x95, 0x99, 0x9a, 0xa9,
+	0xaa, 0x05, 0x15, 0x45, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x95, 0x96, 0x99, 0x9a,
+	0xaa, 0x05, 0x15, 0x45, 0x55, 0x56, 0x59, 0x5a, 0x66, 0x6a, 0x96, 0x9a, 0xa6, 0xaa, 0xab, 0x05,
+	0x15, 0x16, 0x45, 0x46, 0x55, 0x56, 0x59, 0x5a, 0x5b, 0x66, 0x6a, 0x6b, 0x96, 0x9a, 0x9b, 0xa6,
+	0xaa, 0xab, 0x04, 0x05, 0x14, 0x15, 0x44, 0x45, 0x54, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x69, 0x6a,
+	0x95, 0x99, 0x9a, 0xa9, 0xaa, 0xae, 0x05, 0x15, 0x45, 0x55, 0x56, 0x59, 0x5a, 0x69, 0x6a, 0x99,
+	0x9a, 0xa9, 0xaa, 0xae, 0x05, 0x15, 0x45, 0x55, 0x56, 0x59, 0x5a, 0x6a, 0x9a, 0xaa, 0x05, 0x15,
+	0x16, 0x45, 0x46, 0x55, 0x56, 0x59, 0x5a, 0x5b, 0x66, 0x6a, 0x6b, 0x96, 0x9a, 0x9b, 0xaa, 0xab,
+	0x04, 0x15, 0x19, 0x45, 0x49, 0x54, 0x55, 0x58, 0x59, 0x5a, 0x5d, 0x69, 0x6a, 0x99, 0x9a, 0xa9,
+	0xaa, 0xae, 0x05, 0x15, 0x19, 0x45, 0x49, 0x55, 0x56, 0x59, 0x5a, 0x5e, 0x69, 0x6a, 0x6e, 0x99,
+	0x9a, 0x9e, 0xa9, 0xaa, 0xae, 0x05, 0x15, 0x19, 0x45, 0x49, 0x55, 0x56, 0x59, 0x5a, 0x5e, 0x69,
+	0x6a, 0x6e, 0x99, 0x9a, 0x9e, 0xaa, 0xae, 0x05, 0x15, 0x1a, 0x45, 0x4a, 0x55, 0x56, 0x59, 0x5a,
+	0x5b, 0x5e, 0x6a, 0x9a, 0xaa, 0xab, 0xae, 0xaf, 0x10, 0x11, 0x14, 0x15, 0x50, 0x51, 0x54, 0x55,
+	0x56, 0x59, 0x65, 0x66, 0x69, 0x6a, 0x95, 0xa5, 0xa6, 0xa9, 0xaa, 0x11, 0x15, 0x51, 0x55, 0x56,
+	0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x95, 0x96, 0xa5, 0xa6, 0xaa, 0x11, 0x15, 0x51, 0x55, 0x56,
+	0x5a, 0x65, 0x66, 0x6a, 0x96, 0x9a, 0xa6, 0xaa, 0xab, 0x11, 0x15, 0x16, 0x51, 0x52, 0x55, 0x56,
+	0x5a, 0x65, 0x66, 0x67, 0x6a, 0x6b, 0x96, 0x9a, 0xa6, 0xa7, 0xaa, 0xab, 0x14, 0x15, 0x54, 0x55,
+	0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x95, 0x99, 0xa5, 0xa9, 0xaa, 0x15, 0x55, 0x56, 0x59,
+	0x5a, 0x65, 0x66, 0x69, 0x6a, 0x95, 0x9a, 0xa6, 0xa9, 0xaa, 0x15, 0x55, 0x56, 0x59, 0x5a, 0x65,
+	0x66, 0x69, 0x6a, 0x96, 0x9a, 0xa6, 0xaa, 0xab, 0x15, 0x16, 0x55, 0x56, 0x5a, 0x66, 0x6a, 0x6b,
+	0x96, 0x9a, 0xa6, 0xaa, 0xab, 0x14, 0x15, 0x54, 0x55, 0x59, 0x5a, 0x65, 0x69, 0x6a, 0x99, 0x9a,
+	0xa9, 0xaa, 0xae, 0x15, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x99, 0x9a, 0xa9, 0xaa,
+	0xae, 0x15, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x9a, 0xaa, 0x15, 0x16, 0x55, 0x56,
+	0x59, 0x5a, 0x66, 0x6a, 0x6b, 0x9a, 0xaa, 0xab, 0x14, 0x15, 0x19, 0x54, 0x55, 0x58, 0x59, 0x5a,
+	0x65, 0x69, 0x6a, 0x6d, 0x6e, 0x99, 0x9a, 0xa9, 0xaa, 0xad, 0xae, 0x15, 0x19, 0x55, 0x59, 0x5a,
+	0x69, 0x6a, 0x6e, 0x99, 0x9a, 0xa9, 0xaa, 0xae, 0x15, 0x19, 0x55, 0x56, 0x59, 0x5a, 0x69, 0x6a,
+	0x6e, 0x9a, 0xaa, 0xae, 0x15, 0x16, 0x19, 0x1a, 0x55, 0x56, 0x59, 0x5a, 0x66, 0x69, 0x6a, 0x6b,
+	0x6e, 0x9a, 0xaa, 0xab, 0xae, 0xaf, 0x10, 0x11, 0x14, 0x15, 0x50, 0x51, 0x54, 0x55, 0x56, 0x59,
+	0x65, 0x66, 0x69, 0x6a, 0x95, 0xa5, 0xa6, 0xa9, 0xaa, 0xba, 0x11, 0x15, 0x51, 0x55, 0x56, 0x65,
+	0x66, 0x69, 0x6a, 0xa5, 0xa6, 0xa9, 0xaa, 0xba, 0x11, 0x15, 0x51, 0x55, 0x56, 0x65, 0x66, 0x6a,
+	0xa6, 0xaa, 0x11, 0x15, 0x16, 0x51, 0x52, 0x55, 0x56, 0x5a, 0x65, 0x66, 0x67, 0x6a, 0x6b, 0x96,
+	0xa6, 0xa7, 0xaa, 0xab, 0x14, 0x15, 0x54, 0x55, 0x59, 0x65, 0x66, 0x69, 0x6a, 0xa5, 0xa6, 0xa9,
+	0xaa, 0xba, 0x15, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0xa5, 0xa6, 0xa9, 0xaa, 0xba,
+	0x15, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0xa6, 0xaa, 0x15, 0x16, 0x55, 0x56, 0x5a,
+	0x65, 0x66, 0x6a, 0x6b, 0xa6, 0xaa, 0xab, 0x14, 0x15, 0x54, 0x55, 0x59, 0x65, 0x69, 0x6a, 0xa9,
+	0xaa, 0x15, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0xa9, 0xaa, 0x15, 0x55, 0x56, 0x59,
+	0x5a, 0x65, 0x66, 0x69, 0x6a, 0xaa, 0x15, 0x16, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a,
+	0x6b, 0xaa, 0xab, 0x14, 0x15, 0x19, 0x54, 0x55, 0x58, 0x59, 0x5a, 0x65, 0x69, 0x6a, 0x6d, 0x6e,
+	0x99, 0xa9, 0xaa, 0xad, 0xae, 0x15, 0x19, 0x55, 0x59, 0x5a, 0x65, 0x69, 0x6a, 0x6e, 0xa9, 0xaa,
+	0xae, 0x15, 0x19, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x6e, 0xaa, 0xae, 0x15, 0x16,
+	0x19, 0x1a, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x6b, 0x6e, 0x9a, 0xaa, 0xab, 0xae,
+	0xaf, 0x10, 0x15, 0x25, 0x51, 0x54, 0x55, 0x61, 0x64, 0x65, 0x66, 0x69, 0x6a, 0x75, 0xa5, 0xa6,
+	0xa9, 0xaa, 0xba, 0x11, 0x15, 0x25, 0x51, 0x55, 0x56, 0x61, 0x65, 0x66, 0x69, 0x6a, 0x76, 0x7a,
+	0xa5, 0xa6, 0xa9, 0xaa, 0xb6, 0xba, 0x11, 0x15, 0x25, 0x51, 0x55, 0x56, 0x61, 0x65, 0x66, 0x69,
+	0x6a, 0x76, 0x7a, 0xa5, 0xa6, 0xaa, 0xb6, 0xba, 0x11, 0x15, 0x26, 0x51, 0x55, 0x56, 0x62, 0x65,
+	0x66, 0x67, 0x6a, 0x76, 0xa6, 0xaa, 0xab, 0xba, 0xbb, 0x14, 0x15, 0x25, 0x54, 0x55, 0x59, 0x64,
+	0x65, 0x66, 0x69, 0x6a, 0x79, 0x7a, 0xa5, 0xa6, 0xa9, 0xaa, 0xb9, 0xba, 0x15, 0x25, 0x55, 0x65,
+	0x66, 0x69, 0x6a, 0x7a, 0xa5, 0xa6, 0xa9, 0xaa, 0xba, 0x15, 0x25, 0x55, 0x56, 0x65, 0x66, 0x69,
+	0x6a, 0x7a, 0xa6, 0xaa, 0xba, 0x15, 0x16, 0x25, 0x26, 0x55, 0x56, 0x5a, 0x65, 0x66, 0x69, 0x6a,
+	0x6b, 0x7a, 0xa6, 0xaa, 0xab, 0xba, 0xbb, 0x14, 0x15, 0x25, 0x54, 0x55, 0x59, 0x64, 0x65, 0x66,
+	0x69, 0x6a, 0x79, 0x7a, 0xa5, 0xa9, 0xaa, 0xb9, 0xba, 0x15, 0x25, 0x55, 0x59, 0x65, 0x66, 0x69,
+	0x6a, 0x7a, 0xa9, 0xaa, 0xba, 0x15, 0x25, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x7a,
+	0xaa, 0xba, 0x15, 0x16, 0x25, 0x26, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x6b, 0x7a,
+	0xa6, 0xaa, 0xab, 0xba, 0xbb, 0x14, 0x15, 0x29, 0x54, 0x55, 0x59, 0x65, 0x68, 0x69, 0x6a, 0x6d,
+	0x79, 0xa9, 0xaa, 0xae, 0xba, 0xbe, 0x15, 0x19, 0x25, 0x29, 0x55, 0x59, 0x5a, 0x65, 0x66, 0x69,
+	0x6a, 0x6e, 0x7a, 0xa9, 0xaa, 0xae, 0xba, 0xbe, 0x15, 0x19, 0x25, 0x29, 0x55, 0x56, 0x59, 0x5a,
+	0x65, 0x66, 0x69, 0x6a, 0x6e, 0x7a, 0xa9, 0xaa, 0xae, 0xba, 0xbe, 0x15, 0x2a, 0x55, 0x56, 0x59,
+	0x5a, 0x65, 0x66, 0x69, 0x6a, 0x6b, 0x6e, 0x7a, 0xaa, 0xab, 0xae, 0xba, 0xbf, 0x40, 0x41, 0x44,
+	0x45, 0x50, 0x51, 0x54, 0x55, 0x56, 0x59, 0x65, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa,
+	0x41, 0x45, 0x51, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xaa,
+	0x41, 0x45, 0x51, 0x55, 0x56, 0x5a, 0x66, 0x6a, 0x95, 0x96, 0x9a, 0xa6, 0xaa, 0xab, 0x41, 0x45,
+	0x46, 0x51, 0x52, 0x55, 0x56, 0x5a, 0x66, 0x6a, 0x95, 0x96, 0x97, 0x9a, 0x9b, 0xa6, 0xa7, 0xaa,
+	0xab, 0x44, 0x45, 0x54, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x69, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa9,
+	0xaa, 0x45, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x6a, 0x95, 0x96, 0x99, 0x9a, 0xa6, 0xa9, 0xaa, 0x45,
+	0x55, 0x56, 0x59, 0x5a, 0x66, 0x6a, 0x95, 0x96, 0x99, 0x9a, 0xa6, 0xaa, 0xab, 0x45, 0x46, 0x55,
+	0x56, 0x5a, 0x66, 0x6a, 0x96, 0x9a, 0x9b, 0xa6, 0xaa, 0xab, 0x44, 0x45, 0x54, 0x55, 0x59, 0x5a,
+	0x69, 0x6a, 0x95, 0x99, 0x9a, 0xa9, 0xaa, 0xae, 0x45, 0x55, 0x56, 0x59, 0x5a, 0x69, 0x6a, 0x95,
+	0x96, 0x99, 0x9a, 0xa9, 0xaa, 0xae, 0x45, 0x55, 0x56, 0x59, 0x5a, 0x6a, 0x95, 0x96, 0x99, 0x9a,
+	0xaa, 0x45, 0x46, 0x55, 0x56, 0x59, 0x5a, 0x6a, 0x96, 0x9a, 0x9b, 0xaa, 0xab, 0x44, 0x45, 0x49,
+	0x54, 0x55, 0x58, 0x59, 0x5a, 0x69, 0x6a, 0x95, 0x99, 0x9a, 0x9d, 0x9e, 0xa9, 0xaa, 0xad, 0xae,
+	0x45, 0x49, 0x55, 0x59, 0x5a, 0x69, 0x6a, 0x99, 0x9a, 0x9e, 0xa9, 0xaa, 0xae, 0x45, 0x49, 0x55,
+	0x56, 0x59, 0x5a, 0x6a, 0x99, 0x9a, 0x9e, 0xaa, 0xae, 0x45, 0x46, 0x49, 0x4a, 0x55, 0x56, 0x59,
+	0x5a, 0x6a, 0x96, 0x99, 0x9a, 0x9b, 0x9e, 0xaa, 0xab, 0xae, 0xaf, 0x50, 0x51, 0x54, 0x55, 0x56,
+	0x59, 0x65, 0x66, 0x69, 0x95, 0x96, 0x99, 0xa5, 0xa6, 0xa9, 0xaa, 0x51, 0x55, 0x56, 0x59, 0x65,
+	0x66, 0x6a, 0x95, 0x96, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0x51, 0x55, 0x56, 0x5a, 0x65, 0x66, 0x6a,
+	0x95, 0x96, 0x9a, 0xa5, 0xa6, 0xaa, 0xab, 0x51, 0x52, 0x55, 0x56, 0x5a, 0x66, 0x6a, 0x96, 0x9a,
+	0xa6, 0xa7, 0xaa, 0xab, 0x54, 0x55, 0x56, 0x59, 0x65, 0x69, 0x6a, 0x95, 0x99, 0x9a, 0xa5, 0xa6,
+	0xa9, 0xaa, 0x15, 0x45, 0x51, 0x54, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x95, 0x96,
+	0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0x15, 0x45, 0x51, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69,
+	0x6a, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xab, 0x55, 0x56, 0x5a, 0x66, 0x6a, 0x96,
+	0x9a, 0xa6, 0xaa, 0xab, 0x54, 0x55, 0x59, 0x5a, 0x65, 0x69, 0x6a, 0x95, 0x99, 0x9a, 0xa5, 0xa9,
+	0xaa, 0xae, 0x15, 0x45, 0x54, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x95, 0x96, 0x99,
+	0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xae, 0x15, 0x45, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a,
+	0x95, 0x96, 0x99, 0x9a, 0xa6, 0xa9, 0xaa, 0xab, 0xae, 0x55, 0x56, 0x59, 0x5a, 0x66, 0x6a, 0x96,
+	0x9a, 0xa6, 0xaa, 0xab, 0x54, 0x55, 0x58, 0x59, 0x5a, 0x69, 0x6a, 0x99, 0x9a, 0xa9, 0xaa, 0xad,
+	0xae, 0x55, 0x59, 0x5a, 0x69, 0x6a, 0x99, 0x9a, 0xa9, 0xaa, 0xae, 0x55, 0x56, 0x59, 0x5a, 0x69,
+	0x6a, 0x99, 0x9a, 0xa9, 0xaa, 0xae, 0x55, 0x56, 0x59, 0x5a, 0x6a, 0x9a, 0xaa, 0xab, 0xae, 0xaf,
+	0x50, 0x51, 0x54, 0x55, 0x65, 0x66, 0x69, 0x6a, 0x95, 0xa5, 0xa6, 0xa9, 0xaa, 0xba, 0x51, 0x55,
+	0x56, 0x65, 0x66, 0x69, 0x6a, 0x95, 0x96, 0xa5, 0xa6, 0xa9, 0xaa, 0xba, 0x51, 0x55, 0x56, 0x65,
+	0x66, 0x6a, 0x95, 0x96, 0xa5, 0xa6, 0xaa, 0x51, 0x52, 0x55, 0x56, 0x65, 0x66, 0x6a, 0x96, 0xa6,
+	0xa7, 0xaa, 0xab, 0x54, 0x55, 0x59, 0x65, 0x66, 0x69, 0x6a, 0x95, 0x99, 0xa5, 0xa6, 0xa9, 0xaa,
+	0xba, 0x15, 0x51, 0x54, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x95, 0x96, 0x99, 0x9a,
+	0xa5, 0xa6, 0xa9, 0xaa, 0xba, 0x15, 0x51, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x95,
+	0x96, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xab, 0xba, 0x55, 0x56, 0x5a, 0x65, 0x66, 0x6a, 0x96, 0x9a,
+	0xa6, 0xaa, 0xab, 0x54, 0x55, 0x59, 0x65, 0x69, 0x6a, 0x95, 0x99, 0xa5, 0xa9, 0xaa, 0x15, 0x54,
+	0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x95, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xae,
+	0xba, 0x15, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6,
+	0xa9, 0xaa, 0xab, 0xae, 0xba, 0x15, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x96, 0x9a,
+	0xa6, 0xaa, 0xab, 0x54, 0x55, 0x58, 0x59, 0x65, 0x69, 0x6a, 0x99, 0xa9, 0xaa, 0xad, 0xae, 0x55,
+	0x59, 0x5a, 0x65, 0x69, 0x6a, 0x99, 0x9a, 0xa9, 0xaa, 0xae, 0x15, 0x55, 0x56, 0x59, 0x5a, 0x65,
+	0x66, 0x69, 0x6a, 0x99, 0x9a, 0xa9, 0xaa, 0xae, 0x15, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69,
+	0x6a, 0x9a, 0xaa, 0xab, 0xae, 0xaf, 0x50, 0x51, 0x54, 0x55, 0x61, 0x64, 0x65, 0x66, 0x69, 0x6a,
+	0x95, 0xa5, 0xa6, 0xa9, 0xaa, 0xb5, 0xb6, 0xb9, 0xba, 0x51, 0x55, 0x61, 0x65, 0x66, 0x69, 0x6a,
+	0xa5, 0xa6, 0xa9, 0xaa, 0xb6, 0xba, 0x51, 0x55, 0x56, 0x61, 0x65, 0x66, 0x6a, 0xa5, 0xa6, 0xaa,
+	0xb6, 0xba, 0x51, 0x52, 0x55, 0x56, 0x61, 0x62, 0x65, 0x66, 0x6a, 0x96, 0xa5, 0xa6, 0xa7, 0xaa,
+	0xab, 0xb6, 0xba, 0xbb, 0x54, 0x55, 0x64, 0x65, 0x66, 0x69, 0x6a, 0xa5, 0xa6, 0xa9, 0xaa, 0xb9,
+	0xba, 0x55, 0x65, 0x66, 0x69, 0x6a, 0xa5, 0xa6, 0xa9, 0xaa, 0xba, 0x55, 0x56, 0x65, 0x66, 0x69,
+	0x6a, 0xa5, 0xa6, 0xa9, 0xaa, 0xba, 0x55, 0x56, 0x65, 0x66, 0x6a, 0xa6, 0xaa, 0xab, 0xba, 0xbb,
+	0x54, 0x55, 0x59, 0x64, 0x65, 0x69, 0x6a, 0xa5, 0xa9, 0xaa, 0xb9, 0xba, 0x55, 0x59, 0x65, 0x66,
+	0x69, 0x6a, 0xa5, 0xa6, 0xa9, 0xaa, 0xba, 0x15, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a,
+	0xa5, 0xa6, 0xa9, 0xaa, 0xba, 0x15, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0xa6, 0xaa,
+	0xab, 0xba, 0xbb, 0x54, 0x55, 0x58, 0x59, 0x64, 0x65, 0x68, 0x69, 0x6a, 0x99, 0xa5, 0xa9, 0xaa,
+	0xad, 0xae, 0xb9, 0xba, 0xbe, 0x55, 0x59, 0x65, 0x69, 0x6a, 0xa9, 0xaa, 0xae, 0xba, 0xbe, 0x15,
+	0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0xa9, 0xaa, 0xae, 0xba, 0xbe, 0x15, 0x55, 0x56,
+	0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x9a, 0xa6, 0xa9, 0xaa, 0xab, 0xae, 0xaf, 0xba, 0xbb, 0xbe,
+	0xbf, 0x40, 0x41, 0x44, 0x45, 0x50, 0x51, 0x54, 0x55, 0x56, 0x59, 0x65, 0x95, 0x96, 0x99, 0x9a,
+	0xa5, 0xa6, 0xa9, 0xaa, 0xea, 0x41, 0x45, 0x51, 0x55, 0x56, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6,
+	0xa9, 0xaa, 0xea, 0x41, 0x45, 0x51, 0x55, 0x56, 0x95, 0x96, 0x9a, 0xa6, 0xaa, 0x41, 0x45, 0x46,
+	0x51, 0x52, 0x55, 0x56, 0x5a, 0x66, 0x95, 0x96, 0x97, 0x9a, 0x9b, 0xa6, 0xa7, 0xaa, 0xab, 0x44,
+	0x45, 0x54, 0x55, 0x59, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xea, 0x45, 0x55, 0x56,
+	0x59, 0x5a, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xea, 0x45, 0x55, 0x56, 0x59, 0x5a,
+	0x95, 0x96, 0x99, 0x9a, 0xa6, 0xaa, 0x45, 0x46, 0x55, 0x56, 0x5a, 0x95, 0x96, 0x9a, 0x9b, 0xa6,
+	0xaa, 0xab, 0x44, 0x45, 0x54, 0x55, 0x59, 0x95, 0x99, 0x9a, 0xa9, 0xaa, 0x45, 0x55, 0x56, 0x59,
+	0x5a, 0x95, 0x96, 0x99, 0x9a, 0xa9, 0xaa, 0x45, 0x55, 0x56, 0x59, 0x5a, 0x95, 0x96, 0x99, 0x9a,
+	0xaa, 0x45, 0x46, 0x55, 0x56, 0x59, 0x5a, 0x95, 0x96, 0x99, 0x9a, 0x9b, 0xaa, 0xab, 0x44, 0x45,
+	0x49, 0x54, 0x55, 0x58, 0x59, 0x5a, 0x69, 0x95, 0x99, 0x9a, 0x9d, 0x9e, 0xa9, 0xaa, 0xad, 0xae,
+	0x45, 0x49, 0x55, 0x59, 0x5a, 0x95, 0x99, 0x9a, 0x9e, 0xa9, 0xaa, 0xae, 0x45, 0x49, 0x55, 0x56,
+	0x59, 0x5a, 0x95, 0x96, 0x99, 0x9a, 0x9e, 0xaa, 0xae, 0x45, 0x46, 0x49, 0x4a, 0x55, 0x56, 0x59,
+	0x5a, 0x6a, 0x95, 0x96, 0x99, 0x9a, 0x9b, 0x9e, 0xaa, 0xab, 0xae, 0xaf, 0x50, 0x51, 0x54, 0x55,
+	0x65, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xea, 0x51, 0x55, 0x56, 0x65, 0x66, 0x95,
+	0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xea, 0x51, 0x55, 0x56, 0x65, 0x66, 0x95, 0x96, 0x9a,
+	0xa5, 0xa6, 0xaa, 0x51, 0x52, 0x55, 0x56, 0x66, 0x95, 0x96, 0x9a, 0xa6, 0xa7, 0xaa, 0xab, 0x54,
+	0x55, 0x59, 0x65, 0x69, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xea, 0x45, 0x51, 0x54,
+	0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa,
+	0xea, 0x45, 0x51, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x6a, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6,
+	0xa9, 0xaa, 0xab, 0xea, 0x55, 0x56, 0x5a, 0x66, 0x6a, 0x95, 0x96, 0x9a, 0xa6, 0xaa, 0xab, 0x54,
+	0x55, 0x59, 0x65, 0x69, 0x95, 0x99, 0x9a, 0xa5, 0xa9, 0xaa, 0x45, 0x54, 0x55, 0x56, 0x59, 0x5a,
+	0x65, 0x69, 0x6a, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xae, 0xea, 0x45, 0x55, 0x56,
+	0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xab, 0xae,
+	0xea, 0x45, 0x55, 0x56, 0x59, 0x5a, 0x66, 0x6a, 0x95, 0x96, 0x99, 0x9a, 0xa6, 0xaa, 0xab, 0x54,
+	0x55, 0x58, 0x59, 0x69, 0x95, 0x99, 0x9a, 0xa9, 0xaa, 0xad, 0xae, 0x55, 0x59, 0x5a, 0x69, 0x6a,
+	0x95, 0x99, 0x9a, 0xa9, 0xaa, 0xae, 0x45, 0x55, 0x56, 0x59, 0x5a, 0x69, 0x6a, 0x95, 0x96, 0x99,
+	0x9a, 0xa9, 0xaa, 0xae, 0x45, 0x55, 0x56, 0x59, 0x5a, 0x6a, 0x95, 0x96, 0x99, 0x9a, 0xaa, 0xab,
+	0xae, 0xaf, 0x50, 0x51, 0x54, 0x55, 0x65, 0x95, 0xa5, 0xa6, 0xa9, 0xaa, 0x51, 0x55, 0x56, 0x65,
+	0x66, 0x95, 0x96, 0xa5, 0xa6, 0xa9, 0xaa, 0x51, 0x55, 0x56, 0x65, 0x66, 0x95, 0x96, 0xa5, 0xa6,
+	0xaa, 0x51, 0x52, 0x55, 0x56, 0x65, 0x66, 0x95, 0x96, 0xa5, 0xa6, 0xa7, 0xaa, 0xab, 0x54, 0x55,
+	0x59, 0x65, 0x69, 0x95, 0x99, 0xa5, 0xa6, 0xa9, 0xaa, 0x51, 0x54, 0x55, 0x56, 0x59, 0x65, 0x66,
+	0x69, 0x6a, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xba, 0xea, 0x51, 0x55, 0x56, 0x59,
+	0x5a, 0x65, 0x66, 0x69, 0x6a, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xab, 0xba, 0xea,
+	0x51, 0x55, 0x56, 0x5a, 0x65, 0x66, 0x6a, 0x95, 0x96, 0x9a, 0xa5, 0xa6, 0xaa, 0xab, 0x54, 0x55,
+	0x59, 0x65, 0x69, 0x95, 0x99, 0xa5, 0xa9, 0xaa, 0x54, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69,
+	0x6a, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xae, 0xba, 0xea, 0x55, 0x56, 0x59, 0x5a,
+	0x65, 0x66, 0x69, 0x6a, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xab, 0xae, 0xba, 0xea,
+	0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x6a, 0x95, 0x96, 0x9a, 0xa6, 0xa9, 0xaa, 0xab, 0x54, 0x55,
+	0x58, 0x59, 0x65, 0x69, 0x95, 0x99, 0xa5, 0xa9, 0xaa, 0xad, 0xae, 0x54, 0x55, 0x59, 0x5a, 0x65,
+	0x69, 0x6a, 0x95, 0x99, 0x9a, 0xa5, 0xa9, 0xaa, 0xae, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x69, 0x6a,
+	0x95, 0x99, 0x9a, 0xa6, 0xa9, 0xaa, 0xae, 0x55, 0x56, 0x59, 0x5a, 0x66, 0x69, 0x6a, 0x96, 0x99,
+	0x9a, 0xa6, 0xa9, 0xaa, 0xab, 0xae, 0xaf, 0x50, 0x51, 0x54, 0x55, 0x61, 0x64, 0x65, 0x66, 0x69,
+	0x95, 0xa5, 0xa6, 0xa9, 0xaa, 0xb5, 0xb6, 0xb9, 0xba, 0x51, 0x55, 0x61, 0x65, 0x66, 0x95, 0xa5,
+	0xa6, 0xa9, 0xaa, 0xb6, 0xba, 0x51, 0x55, 0x56, 0x61, 0x65, 0x66, 0x95, 0x96, 0xa5, 0xa6, 0xaa,
+	0xb6, 0xba, 0x51, 0x52, 0x55, 0x56, 0x61, 0x62, 0x65, 0x66, 0x6a, 0x95, 0x96, 0xa5, 0xa6, 0xa7,
+	0xaa, 0xab, 0xb6, 0xba, 0xbb, 0x54, 0x55, 0x64, 0x65, 0x69, 0x95, 0xa5, 0xa6, 0xa9, 0xaa, 0xb9,
+	0xba, 0x55, 0x65, 0x66, 0x69, 0x6a, 0x95, 0xa5, 0xa6, 0xa9, 0xaa, 0xba, 0x51, 0x55, 0x56, 0x65,
+	0x66, 0x69, 0x6a, 0x95, 0x96, 0xa5, 0xa6, 0xa9, 0xaa, 0xba, 0x51, 0x55, 0x56, 0x65, 0x66, 0x6a,
+	0x95, 0x96, 0xa5, 0xa6, 0xaa, 0xab, 0xba, 0xbb, 0x54, 0x55, 0x59, 0x64, 0x65, 0x69, 0x95, 0x99,
+	0xa5, 0xa9, 0xaa, 0xb9, 0xba, 0x54, 0x55, 0x59, 0x65, 0x66, 0x69, 0x6a, 0x95, 0x99, 0xa5, 0xa6,
+	0xa9, 0xaa, 0xba, 0x55, 0x56, 0x59, 0x65, 0x66, 0x69, 0x6a, 0x95, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa,
+	0xba, 0x55, 0x56, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x96, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xab, 0xba,
+	0xbb, 0x54, 0x55, 0x58, 0x59, 0x64, 0x65, 0x68, 0x69, 0x6a, 0x95, 0x99, 0xa5, 0xa9, 0xaa, 0xad,
+	0xae, 0xb9, 0xba, 0xbe, 0x54, 0x55, 0x59, 0x65, 0x69, 0x6a, 0x95, 0x99, 0xa5, 0xa9, 0xaa, 0xae,
+	0xba, 0xbe, 0x55, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xae,
+	0xba, 0xbe, 0x55, 0x56, 0x59, 0x5a, 0x65, 0x66, 0x69, 0x6a, 0x9a, 0xa6, 0xa9, 0xaa, 0xab, 0xae,
+	0xaf, 0xba, 0xbb, 0xbe, 0xbf, 0x40, 0x45, 0x51, 0x54, 0x55, 0x85, 0x91, 0x94, 0x95, 0x96, 0x99,
+	0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xd5, 0xea, 0x41, 0x45, 0x51, 0x55, 0x56, 0x85, 0x91, 0x95, 0x96,
+	0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xd6, 0xda, 0xe6, 0xea, 0x41, 0x45, 0x51, 0x55, 0x56, 0x85,
+	0x91, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xaa, 0xd6, 0xda, 0xe6, 0xea, 0x41, 0x45, 0x51, 0x55,
+	0x56, 0x86, 0x92, 0x95, 0x96, 0x97, 0x9a, 0xa6, 0xaa, 0xab, 0xd6, 0xea, 0xeb, 0x44, 0x45, 0x54,
+	0x55, 0x59, 0x85, 0x94, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xd9, 0xda, 0xe9, 0xea,
+	0x45, 0x55, 0x85, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xda, 0xea, 0x45, 0x55, 0x56,
+	0x85, 0x95, 0x96, 0x99, 0x9a, 0xa6, 0xaa, 0xda, 0xea, 0x45, 0x46, 0x55, 0x56, 0x5a, 0x85, 0x86,
+	0x95, 0x96, 0x99, 0x9a, 0x9b, 0xa6, 0xaa, 0xab, 0xda, 0xea, 0xeb, 0x44, 0x45, 0x54, 0x55, 0x59,
+	0x85, 0x94, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa9, 0xaa, 0xd9, 0xda, 0xe9, 0xea, 0x45, 0x55, 0x59,
+	0x85, 0x95, 0x96, 0x99, 0x9a, 0xa9, 0xaa, 0xda, 0xea, 0x45, 0x55, 0x56, 0x59, 0x5a, 0x85, 0x95,
+	0x96, 0x99, 0x9a, 0xaa, 0xda, 0xea, 0x45, 0x46, 0x55, 0x56, 0x59, 0x5a, 0x85, 0x86, 0x95, 0x96,
+	0x99, 0x9a, 0x9b, 0xa6, 0xaa, 0xab, 0xda, 0xea, 0xeb, 0x44, 0x45, 0x54, 0x55, 0x59, 0x89, 0x95,
+	0x98, 0x99, 0x9a, 0x9d, 0xa9, 0xaa, 0xae, 0xd9, 0xea, 0xee, 0x45, 0x49, 0x55, 0x59, 0x5a, 0x85,
+	0x89, 0x95, 0x96, 0x99, 0x9a, 0x9e, 0xa9, 0xaa, 0xae, 0xda, 0xea, 0xee, 0x45, 0x49, 0x55, 0x56,
+	0x59, 0x5a, 0x85, 0x89, 0x95, 0x96, 0x99, 0x9a, 0x9e, 0xa9, 0xaa, 0xae, 0xda, 0xea, 0xee, 0x45,
+	0x55, 0x56, 0x59, 0x5a, 0x8a, 0x95, 0x96, 0x99, 0x9a, 0x9b, 0x9e, 0xaa, 0xab, 0xae, 0xda, 0xea,
+	0xef, 0x50, 0x51, 0x54, 0x55, 0x65, 0x91, 0x94, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa,
+	0xe5, 0xe6, 0xe9, 0xea, 0x51, 0x55, 0x91, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xe6,
+	0xea, 0x51, 0x55, 0x56, 0x91, 0x95, 0x96, 0x9a, 0xa5, 0xa6, 0xaa, 0xe6, 0xea, 0x51, 0x52, 0x55,
+	0x56, 0x66, 0x91, 0x92, 0x95, 0x96, 0x9a, 0xa5, 0xa6, 0xa7, 0xaa, 0xab, 0xe6, 0xea, 0xeb, 0x54,
+	0x55, 0x94, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xe9, 0xea, 0x55, 0x95, 0x96, 0x99,
+	0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xea, 0x55, 0x56, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa,
+	0xea, 0x55, 0x56, 0x95, 0x96, 0x9a, 0xa6, 0xaa, 0xab, 0xea, 0xeb, 0x54, 0x55, 0x59, 0x94, 0x95,
+	0x99, 0x9a, 0xa5, 0xa9, 0xaa, 0xe9, 0xea, 0x55, 0x59, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9,
+	0xaa, 0xea, 0x45, 0x55, 0x56, 0x59, 0x5a, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xea,
+	0x45, 0x55, 0x56, 0x59, 0x5a, 0x95, 0x96, 0x99, 0x9a, 0xa6, 0xaa, 0xab, 0xea, 0xeb, 0x54, 0x55,
+	0x58, 0x59, 0x69, 0x94, 0x95, 0x98, 0x99, 0x9a, 0xa5, 0xa9, 0xaa, 0xad, 0xae, 0xe9, 0xea, 0xee,
+	0x55, 0x59, 0x95, 0x99, 0x9a, 0xa9, 0xaa, 0xae, 0xea, 0xee, 0x45, 0x55, 0x56, 0x59, 0x5a, 0x95,
+	0x96, 0x99, 0x9a, 0xa9, 0xaa, 0xae, 0xea, 0xee, 0x45, 0x55, 0x56, 0x59, 0x5a, 0x6a, 0x95, 0x96,
+	0x99, 0x9a, 0xa6, 0xa9, 0xaa, 0xab, 0xae, 0xaf, 0xea, 0xeb, 0xee, 0xef, 0x50, 0x51, 0x54, 0x55,
+	0x65, 0x91, 0x94, 0x95, 0x96, 0x99, 0xa5, 0xa6, 0xa9, 0xaa, 0xe5, 0xe6, 0xe9, 0xea, 0x51, 0x55,
+	0x65, 0x91, 0x95, 0x96, 0xa5, 0xa6, 0xa9, 0xaa, 0xe6, 0xea, 0x51, 0x55, 0x56, 0x65, 0x66, 0x91,
+	0x95, 0x96, 0xa5, 0xa6, 0xaa, 0xe6, 0xea, 0x51, 0x52, 0x55, 0x56, 0x65, 0x66, 0x91, 0x92, 0x95,
+	0x96, 0x9a, 0xa5, 0xa6, 0xa7, 0xaa, 0xab, 0xe6, 0xea, 0xeb, 0x54, 0x55, 0x65, 0x94, 0x95, 0x99,
+	0xa5, 0xa6, 0xa9, 0xaa, 0xe9, 0xea, 0x55, 0x65, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa,
+	0xea, 0x51, 0x55, 0x56, 0x65, 0x66, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xea, 0x51,
+	0x55, 0x56, 0x65, 0x66, 0x95, 0x96, 0x9a, 0xa5, 0xa6, 0xaa, 0xab, 0xea, 0xeb, 0x54, 0x55, 0x59,
+	0x65, 0x69, 0x94, 0x95, 0x99, 0xa5, 0xa9, 0xaa, 0xe9, 0xea, 0x54, 0x55, 0x59, 0x65, 0x69, 0x95,
+	0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xea, 0x55, 0x56, 0x59, 0x65, 0x6a, 0x95, 0x96, 0x99,
+	0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xea, 0x55, 0x56, 0x5a, 0x66, 0x6a, 0x95, 0x96, 0x99, 0x9a, 0xa5,
+	0xa6, 0xa9, 0xaa, 0xab, 0xea, 0xeb, 0x54, 0x55, 0x58, 0x59, 0x65, 0x69, 0x94, 0x95, 0x98, 0x99,
+	0x9a, 0xa5, 0xa9, 0xaa, 0xad, 0xae, 0xe9, 0xea, 0xee, 0x54, 0x55, 0x59, 0x65, 0x69, 0x95, 0x99,
+	0x9a, 0xa5, 0xa9, 0xaa, 0xae, 0xea, 0xee, 0x55, 0x59, 0x5a, 0x69, 0x6a, 0x95, 0x96, 0x99, 0x9a,
+	0xa5, 0xa6, 0xa9, 0xaa, 0xae, 0xea, 0xee, 0x55, 0x56, 0x59, 0x5a, 0x6a, 0x95, 0x96, 0x99, 0x9a,
+	0xa6, 0xa9, 0xaa, 0xab, 0xae, 0xaf, 0xea, 0xeb, 0xee, 0xef, 0x50, 0x51, 0x54, 0x55, 0x65, 0x95,
+	0xa1, 0xa4, 0xa5, 0xa6, 0xa9, 0xaa, 0xb5, 0xba, 0xe5, 0xea, 0xfa, 0x51, 0x55, 0x61, 0x65, 0x66,
+	0x91, 0x95, 0x96, 0xa1, 0xa5, 0xa6, 0xa9, 0xaa, 0xb6, 0xba, 0xe6, 0xea, 0xfa, 0x51, 0x55, 0x56,
+	0x61, 0x65, 0x66, 0x91, 0x95, 0x96, 0xa1, 0xa5, 0xa6, 0xa9, 0xaa, 0xb6, 0xba, 0xe6, 0xea, 0xfa,
+	0x51, 0x55, 0x56, 0x65, 0x66, 0x95, 0x96, 0xa2, 0xa5, 0xa6, 0xa7, 0xaa, 0xab, 0xb6, 0xba, 0xe6,
+	0xea, 0xfb, 0x54, 0x55, 0x64, 0x65, 0x69, 0x94, 0x95, 0x99, 0xa4, 0xa5, 0xa6, 0xa9, 0xaa, 0xb9,
+	0xba, 0xe9, 0xea, 0xfa, 0x55, 0x65, 0x95, 0xa5, 0xa6, 0xa9, 0xaa, 0xba, 0xea, 0xfa, 0x51, 0x55,
+	0x56, 0x65, 0x66, 0x95, 0x96, 0xa5, 0xa6, 0xa9, 0xaa, 0xba, 0xea, 0xfa, 0x51, 0x55, 0x56, 0x65,
+	0x66, 0x6a, 0x95, 0x96, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xab, 0xba, 0xbb, 0xea, 0xeb, 0xfa, 0xfb,
+	0x54, 0x55, 0x59, 0x64, 0x65, 0x69, 0x94, 0x95, 0x99, 0xa4, 0xa5, 0xa6, 0xa9, 0xaa, 0xb9, 0xba,
+	0xe9, 0xea, 0xfa, 0x54, 0x55, 0x59, 0x65, 0x69, 0x95, 0x99, 0xa5, 0xa6, 0xa9, 0xaa, 0xba, 0xea,
+	0xfa, 0x55, 0x65, 0x66, 0x69, 0x6a, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xba, 0xea,
+	0xfa, 0x55, 0x56, 0x65, 0x66, 0x6a, 0x95, 0x96, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xab, 0xba, 0xbb,
+	0xea, 0xeb, 0xfa, 0xfb, 0x54, 0x55, 0x59, 0x65, 0x69, 0x95, 0x99, 0xa5, 0xa8, 0xa9, 0xaa, 0xad,
+	0xae, 0xb9, 0xba, 0xe9, 0xea, 0xfe, 0x54, 0x55, 0x59, 0x65, 0x69, 0x6a, 0x95, 0x99, 0x9a, 0xa5,
+	0xa6, 0xa9, 0xaa, 0xae, 0xba, 0xbe, 0xea, 0xee, 0xfa, 0xfe, 0x55, 0x59, 0x65, 0x69, 0x6a, 0x95,
+	0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xae, 0xba, 0xbe, 0xea, 0xee, 0xfa, 0xfe, 0x55, 0x56, 0x59,
+	0x5a, 0x65, 0x66, 0x69, 0x6a, 0x95, 0x96, 0x99, 0x9a, 0xa5, 0xa6, 0xa9, 0xaa, 0xab, 0xae, 0xba,
+	0xea, 0xff,
+}
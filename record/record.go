@@ -0,0 +1,418 @@
+// Package record implements a compact file format to record the frames sent
+// to a LED strip or display, so that a show can be captured on site and
+// replayed or inspected later.
+//
+// A recording starts with a 24-byte header:
+//
+//	magic     "LEDR"
+//	version   1 byte (currently 1)
+//	layout    1 byte (LayoutStrip, LayoutMatrix or LayoutSerpentine)
+//	order     1 byte (ledsgo.ColorOrder of the stored colors)
+//	reserved  1 byte
+//	width     uint16 (number of LEDs for a strip)
+//	height    uint16 (1 for a strip, width×height is at most MaxLEDs)
+//	framerate uint16 (nominal frames per second, 0 if unknown)
+//	reserved  uint16
+//	start     int64  (wall clock time of the start, in Unix nanoseconds)
+//
+// All numbers are big endian. The header is followed by frames:
+//
+//	time      uvarint (microseconds since the previous frame)
+//	length    uvarint (length of the data)
+//	data      runs of LEDs, until all LEDs are covered
+//
+// Every run starts with an uvarint of which the lowest 2 bits are the kind of
+// run and the other bits the number of LEDs in the run:
+//
+//	0: skip: the LEDs are unchanged since the previous frame
+//	1: literal: followed by the color of every LED
+//	2: repeat: followed by a single color for all LEDs
+//
+// Colors are stored in the color order of the header, using 3 or 4 bytes per
+// LED. The frame before the first frame has all LEDs off.
+package record
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/aykevl/ledsgo"
+)
+
+const (
+	magic      = "LEDR"
+	version    = 1
+	headerSize = 24
+)
+
+// Kinds of runs in a frame.
+const (
+	runSkip    = 0
+	runLiteral = 1
+	runRepeat  = 2
+)
+
+// Minimum length of a run of the same color to be stored as a repeat.
+const minRepeat = 3
+
+// MaxLEDs is the maximum number of LEDs in a recording. This is far more than
+// any LED installation, but limits the memory that is allocated for a
+// (possibly corrupt) recording to a few megabytes.
+const MaxLEDs = 1 << 20
+
+var (
+	errBadMagic     = errors.New("record: not a recording")
+	errVersion      = errors.New("record: unsupported version")
+	errBadHeader    = errors.New("record: invalid header")
+	errTooManyLEDs  = errors.New("record: too many LEDs")
+	errBadFrame     = errors.New("record: invalid frame data")
+	errStripTooLong = errors.New("record: strip is longer than the recording")
+)
+
+// Layout is the physical layout of the LEDs in a recording.
+type Layout uint8
+
+const (
+	// LayoutStrip is a single strip of LEDs.
+	LayoutStrip Layout = iota
+
+	// LayoutMatrix is a matrix with LEDs ordered row by row, starting at the
+	// top left.
+	LayoutMatrix
+
+	// LayoutSerpentine is a matrix where every other row runs from right to
+	// left.
+	LayoutSerpentine
+)
+
+// Header describes a recording.
+type Header struct {
+	Layout        Layout
+	Width, Height int // the number of LEDs is Width×Height
+	Order         ledsgo.ColorOrder
+	FrameRate     int       // nominal frames per second, or 0 if unknown
+	Start         time.Time // wall clock time of the start of the recording
+}
+
+// LEDs returns the number of LEDs in the recording.
+func (h *Header) LEDs() int {
+	return h.Width * h.Height
+}
+
+// Position returns the x and y position of LED i in the layout.
+func (h *Header) Position(i int) (x, y int) {
+	if h.Layout == LayoutStrip || h.Width == 0 {
+		return i, 0
+	}
+	x, y = i%h.Width, i/h.Width
+	if h.Layout == LayoutSerpentine && y%2 == 1 {
+		x = h.Width - 1 - x
+	}
+	return x, y
+}
+
+// Append the encoded header to buf.
+func (h *Header) append(buf []byte) []byte {
+	buf = append(buf, magic...)
+	buf = append(buf, version, byte(h.Layout), byte(h.Order), 0)
+	buf = append(buf, byte(h.Width>>8), byte(h.Width), byte(h.Height>>8), byte(h.Height))
+	buf = append(buf, byte(h.FrameRate>>8), byte(h.FrameRate), 0, 0)
+	var start [8]byte
+	if !h.Start.IsZero() {
+		binary.BigEndian.PutUint64(start[:], uint64(h.Start.UnixNano()))
+	}
+	return append(buf, start[:]...)
+}
+
+// Parse an encoded header.
+func parseHeader(buf []byte) (Header, error) {
+	if string(buf[:4]) != magic {
+		return Header{}, errBadMagic
+	}
+	if buf[4] != version {
+		return Header{}, errVersion
+	}
+	h := Header{
+		Layout:    Layout(buf[5]),
+		Order:     ledsgo.ColorOrder(buf[6]),
+		Width:     int(binary.BigEndian.Uint16(buf[8:10])),
+		Height:    int(binary.BigEndian.Uint16(buf[10:12])),
+		FrameRate: int(binary.BigEndian.Uint16(buf[12:14])),
+	}
+	if start := int64(binary.BigEndian.Uint64(buf[16:24])); start != 0 {
+		h.Start = time.Unix(0, start)
+	}
+	if err := h.validate(); err != nil {
+		return Header{}, err
+	}
+	return h, nil
+}
+
+// Check whether the header can be stored and used.
+func (h *Header) validate() error {
+	if h.Layout > LayoutSerpentine || !h.Order.Valid() {
+		return errBadHeader
+	}
+	if h.Width < 0 || h.Height < 0 || h.Width > 0xffff || h.Height > 0xffff || h.FrameRate < 0 || h.FrameRate > 0xffff {
+		return errBadHeader
+	}
+	if h.Layout == LayoutStrip && h.Height != 1 {
+		return errBadHeader
+	}
+	if h.LEDs() > MaxLEDs {
+		return errTooManyLEDs
+	}
+	return nil
+}
+
+// Writer writes a recording.
+type Writer struct {
+	w        io.Writer
+	header   Header
+	channels int
+	prev     []byte // previous frame, in the color order of the header
+	cur      []byte
+	last     time.Duration
+	buf      []byte
+}
+
+// NewWriter writes the header to w and returns a writer for the frames.
+func NewWriter(w io.Writer, header Header) (*Writer, error) {
+	if err := header.validate(); err != nil {
+		return nil, err
+	}
+	channels := header.Order.Channels()
+	if _, err := w.Write(header.append(nil)); err != nil {
+		return nil, err
+	}
+	return &Writer{
+		w:        w,
+		header:   header,
+		channels: channels,
+		prev:     make([]byte, header.LEDs()*channels),
+		cur:      make([]byte, header.LEDs()*channels),
+	}, nil
+}
+
+// Header returns the header of the recording.
+func (w *Writer) Header() Header {
+	return w.header
+}
+
+// WriteFrame writes the colors of the strip as a frame at the given time since
+// the start of the recording. Every frame is written with a single Write call,
+// so that a recording that is cut off (for example by a power failure) only
+// misses the last frame. Times before the previous frame are stored as the
+// time of the previous frame. LEDs beyond the end of the strip are off.
+func (w *Writer) WriteFrame(strip ledsgo.Strip, t time.Duration) error {
+	for i := range w.cur {
+		w.cur[i] = 0
+	}
+	if len(strip) > w.header.LEDs() {
+		strip = strip[:w.header.LEDs()]
+	}
+	for i, c := range strip {
+		w.header.Order.Put(w.cur[i*w.channels:], c)
+	}
+	data := w.encode(w.buf[:0])
+	if t < w.last {
+		t = w.last
+	}
+	delta := (t - w.last) / time.Microsecond
+	w.last += delta * time.Microsecond // keep the rounding error for the next frame
+
+	frame := make([]byte, 0, len(data)+2*binary.MaxVarintLen64)
+	frame = appendUvarint(frame, uint64(delta))
+	frame = appendUvarint(frame, uint64(len(data)))
+	frame = append(frame, data...)
+	w.buf = data
+	w.prev, w.cur = w.cur, w.prev
+	_, err := w.w.Write(frame)
+	return err
+}
+
+// Encode the current frame as runs relative to the previous frame.
+func (w *Writer) encode(buf []byte) []byte {
+	n := w.header.LEDs()
+	for i := 0; i < n; {
+		if w.unchanged(i) {
+			j := i + 1
+			for j < n && w.unchanged(j) {
+				j++
+			}
+			buf = appendUvarint(buf, uint64(j-i)<<2|runSkip)
+			i = j
+			continue
+		}
+		if repeat := w.repeat(i); repeat >= minRepeat {
+			buf = appendUvarint(buf, uint64(repeat)<<2|runRepeat)
+			buf = append(buf, w.led(w.cur, i)...)
+			i += repeat
+			continue
+		}
+		// Literal run, until the next unchanged LED or repeat.
+		j := i + 1
+		for j < n && !w.unchanged(j) && w.repeat(j) < minRepeat {
+			j++
+		}
+		buf = appendUvarint(buf, uint64(j-i)<<2|runLiteral)
+		buf = append(buf, w.cur[i*w.channels:j*w.channels]...)
+		i = j
+	}
+	return buf
+}
+
+// Return the bytes of LED i in the frame.
+func (w *Writer) led(frame []byte, i int) []byte {
+	return frame[i*w.channels : (i+1)*w.channels]
+}
+
+// Return whether LED i is unchanged since the previous frame.
+func (w *Writer) unchanged(i int) bool {
+	return bytes.Equal(w.led(w.cur, i), w.led(w.prev, i))
+}
+
+// Return the number of LEDs starting at i with the same color.
+func (w *Writer) repeat(i int) int {
+	c := w.led(w.cur, i)
+	j := i + 1
+	for j < w.header.LEDs() && bytes.Equal(w.led(w.cur, j), c) {
+		j++
+	}
+	return j - i
+}
+
+func appendUvarint(buf []byte, v uint64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	return append(buf, tmp[:n]...)
+}
+
+// Reader reads a recording.
+type Reader struct {
+	r        *bufio.Reader
+	header   Header
+	channels int
+	frame    []byte
+	data     []byte
+	time     time.Duration
+}
+
+// NewReader reads the header of a recording and returns a reader for the
+// frames.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	buf := make([]byte, headerSize)
+	if _, err := io.ReadFull(br, buf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	header, err := parseHeader(buf)
+	if err != nil {
+		return nil, err
+	}
+	channels := header.Order.Channels()
+	return &Reader{
+		r:        br,
+		header:   header,
+		channels: channels,
+		frame:    make([]byte, header.LEDs()*channels),
+	}, nil
+}
+
+// Header returns the header of the recording.
+func (r *Reader) Header() Header {
+	return r.header
+}
+
+// ReadFrame reads the next frame into the strip, and returns the time of the
+// frame since the start of the recording. The strip may be shorter than the
+// recording, in which case the remaining LEDs are not stored. It returns
+// io.EOF at the end of the recording, and io.ErrUnexpectedEOF if the last
+// frame was cut off.
+func (r *Reader) ReadFrame(strip ledsgo.Strip) (time.Duration, error) {
+	if len(strip) > r.header.LEDs() {
+		return 0, errStripTooLong
+	}
+	delta, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return 0, err // io.EOF at a frame boundary
+	}
+	length, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	if length > uint64(len(r.frame))*2+16 {
+		// Even a frame of only single LED runs can't be this long.
+		return 0, errBadFrame
+	}
+	if cap(r.data) < int(length) {
+		r.data = make([]byte, length)
+	}
+	data := r.data[:length]
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	if err := r.decode(data); err != nil {
+		return 0, err
+	}
+	r.time += time.Duration(delta) * time.Microsecond
+	for i := range strip {
+		strip[i] = r.header.Order.Get(r.frame[i*r.channels:])
+	}
+	return r.time, nil
+}
+
+// Apply the runs of a frame to the previous frame.
+func (r *Reader) decode(data []byte) error {
+	n := r.header.LEDs()
+	for i := 0; i < n; {
+		v, size := binary.Uvarint(data)
+		if size <= 0 {
+			return errBadFrame
+		}
+		data = data[size:]
+		count := int(v >> 2)
+		if count <= 0 || count > n-i {
+			return errBadFrame
+		}
+		switch v & 3 {
+		case runSkip:
+		case runLiteral:
+			size := count * r.channels
+			if len(data) < size {
+				return errBadFrame
+			}
+			copy(r.frame[i*r.channels:], data[:size])
+			data = data[size:]
+		case runRepeat:
+			if len(data) < r.channels {
+				return errBadFrame
+			}
+			for j := i; j < i+count; j++ {
+				copy(r.frame[j*r.channels:], data[:r.channels])
+			}
+			data = data[r.channels:]
+		default:
+			return errBadFrame
+		}
+		i += count
+	}
+	if len(data) != 0 {
+		return errBadFrame
+	}
+	return nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
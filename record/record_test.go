@@ -0,0 +1,269 @@
+package record
+
+import (
+	"bytes"
+	"image/color"
+	"io"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/aykevl/ledsgo"
+)
+
+func TestRoundTrip(t *testing.T) {
+	for _, order := range []ledsgo.ColorOrder{ledsgo.RGB, ledsgo.GRB, ledsgo.GRBW} {
+		header := Header{
+			Layout:    LayoutSerpentine,
+			Width:     10,
+			Height:    7,
+			Order:     order,
+			FrameRate: 60,
+			Start:     time.Unix(1600000000, 123),
+		}
+		buf := &bytes.Buffer{}
+		w, err := NewWriter(buf, header)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Frames with a mix of unchanged LEDs, single changes and solid
+		// colors.
+		rnd := rand.New(rand.NewSource(int64(order)))
+		strip := make(ledsgo.Strip, header.LEDs())
+		var frames []ledsgo.Strip
+		var times []time.Duration
+		for i := 0; i < 20; i++ {
+			switch i % 4 {
+			case 0:
+				strip.FillSolid(color.RGBA{uint8(i), 0x80, 0, 255})
+			case 1:
+				for j := 0; j < 5; j++ {
+					strip[rnd.Intn(len(strip))] = color.RGBA{uint8(rnd.Uint32()), uint8(rnd.Uint32()), uint8(rnd.Uint32()), 255}
+				}
+			case 2:
+				for j := 10; j < 40; j++ {
+					strip[j] = color.RGBA{1, 2, 3, 255}
+				}
+			}
+			frame := append(ledsgo.Strip(nil), strip...)
+			if order == ledsgo.GRBW {
+				// The white channel is extracted and added back, which is
+				// lossless.
+				for j, c := range frame {
+					frame[j] = order.Get(appendColor(order, c))
+				}
+			}
+			frames = append(frames, frame)
+			times = append(times, time.Duration(i)*16666*time.Microsecond+time.Duration(i%3)*time.Millisecond)
+			if err := w.WriteFrame(strip, times[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		r, err := NewReader(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := r.Header(); got.Layout != header.Layout || got.Width != 10 || got.Height != 7 || got.Order != order || got.FrameRate != 60 || !got.Start.Equal(header.Start) {
+			t.Errorf("%s: unexpected header: %+v", order, got)
+		}
+		got := make(ledsgo.Strip, header.LEDs())
+		for i := range frames {
+			tm, err := r.ReadFrame(got)
+			if err != nil {
+				t.Fatalf("%s: frame %d: %v", order, i, err)
+			}
+			if tm != times[i] {
+				t.Errorf("%s: frame %d: expected time %v, got %v", order, i, times[i], tm)
+			}
+			for j := range got {
+				if got[j] != frames[i][j] {
+					t.Errorf("%s: frame %d, LED %d: expected %v, got %v", order, i, j, frames[i][j], got[j])
+					break
+				}
+			}
+		}
+		if _, err := r.ReadFrame(got); err != io.EOF {
+			t.Errorf("%s: expected io.EOF at the end, got %v", order, err)
+		}
+	}
+}
+
+func appendColor(order ledsgo.ColorOrder, c color.RGBA) []byte {
+	buf := make([]byte, order.Channels())
+	order.Put(buf, c)
+	return buf
+}
+
+func TestCompression(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(buf, Header{Width: 300, Height: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	strip := make(ledsgo.Strip, 300)
+	strip.FillSolid(color.RGBA{255, 0, 0, 255})
+	w.WriteFrame(strip, 0)
+	size := buf.Len()
+	// Solid color: time, length, a single repeat run and the color.
+	if size != headerSize+1+1+2+3 {
+		t.Errorf("unexpected size of a solid frame: %d bytes", size-headerSize)
+	}
+	strip[150] = color.RGBA{0, 0, 255, 255}
+	w.WriteFrame(strip, 10*time.Millisecond)
+	// Single change: time (2 bytes), length, skip, literal with 1 color and
+	// skip.
+	if n := buf.Len() - size; n != 2+1+2+1+3+2 {
+		t.Errorf("unexpected size of a frame with a single change: %d bytes", n)
+	}
+	// Strips shorter than the recording are padded with black LEDs.
+	size = buf.Len()
+	w.WriteFrame(strip[:1], 20*time.Millisecond)
+	if n := buf.Len() - size; n != 2+1+1+2+3 {
+		t.Errorf("unexpected size of a frame with a short strip: %d bytes", n)
+	}
+
+	r, _ := NewReader(bytes.NewReader(buf.Bytes()))
+	got := make(ledsgo.Strip, 300)
+	for i := 0; i < 3; i++ {
+		r.ReadFrame(got)
+	}
+	if got[0] != (color.RGBA{255, 0, 0, 255}) || got[1] != (color.RGBA{0, 0, 0, 255}) || got[299] != (color.RGBA{0, 0, 0, 255}) {
+		t.Errorf("unexpected colors after a short strip: %v %v %v", got[0], got[1], got[299])
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	if _, err := NewWriter(io.Discard, Header{Width: 10, Height: 2}); err == nil {
+		t.Error("expected an error for a strip layout with a height of 2")
+	}
+	if _, err := NewReader(bytes.NewReader([]byte("LEDX\x01"))); err == nil {
+		t.Error("expected an error for a short header")
+	}
+	if _, err := NewReader(bytes.NewReader(make([]byte, headerSize))); err != errBadMagic {
+		t.Errorf("expected errBadMagic, got %v", err)
+	}
+
+	// Headers that would allocate gigabytes of memory.
+	if _, err := NewWriter(io.Discard, Header{Layout: LayoutMatrix, Width: 2048, Height: 1024}); err != errTooManyLEDs {
+		t.Errorf("expected errTooManyLEDs, got %v", err)
+	}
+	huge := []byte("LEDR\x01\x01\x00\x00\xff\xff\xff\xff\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00")
+	if _, err := NewReader(bytes.NewReader(huge)); err != errTooManyLEDs {
+		t.Errorf("expected errTooManyLEDs, got %v", err)
+	}
+	if _, err := NewWriter(io.Discard, Header{Layout: LayoutMatrix, Width: 1024, Height: 1024}); err != nil {
+		t.Errorf("could not create a recording of MaxLEDs LEDs: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	w, _ := NewWriter(buf, Header{Width: 4, Height: 1})
+	w.WriteFrame(ledsgo.Strip{{1, 2, 3, 255}, {4, 5, 6, 255}}, time.Second)
+	data := buf.Bytes()
+
+	// A recording that was cut off in the middle of a frame.
+	r, _ := NewReader(bytes.NewReader(data[:len(data)-1]))
+	if _, err := r.ReadFrame(make(ledsgo.Strip, 4)); err != io.ErrUnexpectedEOF {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+
+	// A run that goes beyond the last LED.
+	corrupt := append([]byte(nil), data...)
+	corrupt[len(corrupt)-1-6-1] = 5<<2 | runLiteral
+	r, _ = NewReader(bytes.NewReader(corrupt))
+	if _, err := r.ReadFrame(make(ledsgo.Strip, 4)); err != errBadFrame {
+		t.Errorf("expected errBadFrame, got %v", err)
+	}
+
+	r, _ = NewReader(bytes.NewReader(data))
+	if _, err := r.ReadFrame(make(ledsgo.Strip, 5)); err != errStripTooLong {
+		t.Errorf("expected errStripTooLong, got %v", err)
+	}
+}
+
+func TestPosition(t *testing.T) {
+	h := Header{Layout: LayoutSerpentine, Width: 3, Height: 2}
+	for i, p := range [][2]int{{0, 0}, {1, 0}, {2, 0}, {2, 1}, {1, 1}, {0, 1}} {
+		if x, y := h.Position(i); x != p[0] || y != p[1] {
+			t.Errorf("LED %d: expected position %v, got (%d, %d)", i, p, x, y)
+		}
+	}
+	h.Layout = LayoutMatrix
+	if x, y := h.Position(4); x != 1 || y != 1 {
+		t.Errorf("matrix: unexpected position (%d, %d)", x, y)
+	}
+}
+
+// A display without a Display method.
+type testDisplay struct {
+	pixels map[[2]int16]color.RGBA
+}
+
+func (d *testDisplay) Size() (int16, int16) {
+	return 3, 2
+}
+
+func (d *testDisplay) SetPixel(x, y int16, c color.RGBA) {
+	d.pixels[[2]int16{x, y}] = c
+}
+
+func TestRecordAndPlay(t *testing.T) {
+	buf := &bytes.Buffer{}
+	inner := &testDisplay{pixels: make(map[[2]int16]color.RGBA)}
+	display, err := NewDisplay(inner, buf, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		display.SetPixel(int16(i), 1, color.RGBA{uint8(i + 1), 0, 0, 255})
+		display.SetPixel(5, 5, color.RGBA{255, 255, 255, 255}) // out of bounds
+		if err := display.Display(); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if inner.pixels[[2]int16{2, 1}] != (color.RGBA{3, 0, 0, 255}) {
+		t.Error("pixels were not passed to the wrapped display")
+	}
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h := r.Header(); h.Layout != LayoutMatrix || h.Width != 3 || h.Height != 2 || h.FrameRate != 100 {
+		t.Errorf("unexpected header: %+v", h)
+	}
+	strip := make(ledsgo.Strip, 6)
+	var shown []ledsgo.Strip
+	start := time.Now()
+	err = Play(r, strip, func() error {
+		shown = append(shown, append(ledsgo.Strip(nil), strip...))
+		return nil
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("playback was too fast: %v", elapsed)
+	}
+	if len(shown) != 3 {
+		t.Fatalf("expected 3 frames, got %d", len(shown))
+	}
+	if shown[0][3] != (color.RGBA{1, 0, 0, 255}) || shown[0][4] != (color.RGBA{0, 0, 0, 255}) || shown[2][5] != (color.RGBA{3, 0, 0, 255}) {
+		t.Errorf("unexpected frames: %v", shown)
+	}
+
+	// Stop playback with the done channel.
+	r, _ = NewReader(bytes.NewReader(buf.Bytes()))
+	done := make(chan struct{})
+	frames := 0
+	Play(r, strip, func() error {
+		frames++
+		close(done)
+		return nil
+	}, done)
+	if frames != 1 {
+		t.Errorf("expected playback to stop after 1 frame, got %d frames", frames)
+	}
+}
@@ -0,0 +1,141 @@
+package record
+
+import (
+	"image/color"
+	"io"
+	"time"
+
+	"github.com/aykevl/ledsgo"
+	"github.com/aykevl/ledsgo/demos"
+)
+
+// Recorder records the frames sent to a strip, with the time at which they
+// were sent.
+type Recorder struct {
+	writer *Writer
+	start  time.Time
+}
+
+// NewRecorder writes the header to w and returns a recorder. If the start time
+// in the header is zero, the current time is used.
+func NewRecorder(w io.Writer, header Header) (*Recorder, error) {
+	if header.Start.IsZero() {
+		header.Start = time.Now()
+	}
+	writer, err := NewWriter(w, header)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{
+		writer: writer,
+		start:  header.Start,
+	}, nil
+}
+
+// Header returns the header of the recording.
+func (r *Recorder) Header() Header {
+	return r.writer.Header()
+}
+
+// Record records the strip as a frame at the current time. It should be called
+// right after the strip has been sent to the LEDs.
+func (r *Recorder) Record(strip ledsgo.Strip) error {
+	return r.RecordAt(strip, time.Now())
+}
+
+// RecordAt records the strip as a frame at the given time.
+func (r *Recorder) RecordAt(strip ledsgo.Strip, now time.Time) error {
+	return r.writer.WriteFrame(strip, now.Sub(r.start))
+}
+
+// Display wraps a display, to record every frame that is shown on it. Pixels
+// are passed to the display and also kept in a strip of Width×Height LEDs, which
+// is recorded on every call to Display.
+type Display struct {
+	demos.Displayer
+	Recorder *Recorder
+	strip    ledsgo.Strip
+}
+
+// NewDisplay returns a display that records all frames shown on the given
+// display to w, as a matrix of the size of the display.
+func NewDisplay(display demos.Displayer, w io.Writer, frameRate int) (*Display, error) {
+	width, height := display.Size()
+	recorder, err := NewRecorder(w, Header{
+		Layout:    LayoutMatrix,
+		Width:     int(width),
+		Height:    int(height),
+		FrameRate: frameRate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Display{
+		Displayer: display,
+		Recorder:  recorder,
+		strip:     make(ledsgo.Strip, int(width)*int(height)),
+	}, nil
+}
+
+// SetPixel sets the pixel on the wrapped display, and remembers it for the
+// recording.
+func (d *Display) SetPixel(x, y int16, c color.RGBA) {
+	d.Displayer.SetPixel(x, y, c)
+	header := d.Recorder.Header()
+	if x < 0 || y < 0 || int(x) >= header.Width || int(y) >= header.Height {
+		return
+	}
+	d.strip[int(y)*header.Width+int(x)] = c
+}
+
+// Display shows the frame on the wrapped display, if it has a Display method,
+// and records the frame.
+func (d *Display) Display() error {
+	if display, ok := d.Displayer.(interface{ Display() error }); ok {
+		if err := display.Display(); err != nil {
+			return err
+		}
+	}
+	return d.Recorder.Record(d.strip)
+}
+
+// Play replays the recording into the strip, and calls show after every frame
+// at the time the frame was recorded. It returns nil at the end of the
+// recording or when the done channel is closed. The strip may be shorter than
+// the recording.
+func Play(r *Reader, strip ledsgo.Strip, show func() error, done <-chan struct{}) error {
+	start := time.Now()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	<-timer.C
+	frame := make(ledsgo.Strip, len(strip))
+	for {
+		t, err := r.ReadFrame(frame)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if wait := time.Until(start.Add(t)); wait > 0 {
+			timer.Reset(wait)
+			select {
+			case <-timer.C:
+			case <-done:
+				return nil
+			}
+		} else {
+			select {
+			case <-done:
+				return nil
+			default:
+			}
+		}
+		copy(strip, frame)
+		if show != nil {
+			if err := show(); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,268 @@
+// Package serial implements the framings that are commonly used to send LED
+// data over a (USB) serial port, for example by Hyperion or Prismatik: the
+// Adalight protocol and TPM2. It also contains the TPM2.net packet format,
+// which uses the same framing over UDP.
+//
+// The package only depends on the io package for the actual communication, so
+// it can be used with a serial port on a PC as well as with the USB-CDC port
+// of a microcontroller running TinyGo.
+package serial
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	"github.com/aykevl/ledsgo"
+)
+
+// Format is a framing of LED data on a serial port.
+type Format uint8
+
+const (
+	// Adalight frames start with "Ada", followed by the number of LEDs minus
+	// one (big endian) and a checksum of these two bytes. The RGB data
+	// follows directly after the header.
+	Adalight Format = iota
+
+	// TPM2 frames start with 0xc9, a packet type and the size of the data
+	// (big endian), followed by the data and an end byte of 0x36.
+	TPM2
+)
+
+// Bytes used in the Adalight and TPM2 framings.
+const (
+	adalightChecksumKey = 0x55
+	tpm2Start           = 0xc9
+	tpm2NetStart        = 0x9c
+	tpm2End             = 0x36
+	tpm2TypeData        = 0xda
+	tpm2TypeCommand     = 0xc0
+	tpm2TypeResponse    = 0xaa
+)
+
+// Header sizes of the framings.
+const (
+	adalightHeaderSize = 6
+	tpm2HeaderSize     = 4
+)
+
+// MaxLEDs returns the maximum number of LEDs in a single frame: 65536 for
+// Adalight and 21845 (65535 bytes of data) for TPM2.
+func (f Format) MaxLEDs() int {
+	switch f {
+	case Adalight:
+		return 0x10000
+	case TPM2:
+		return 0xffff / 3
+	default:
+		panic("serial: unknown format")
+	}
+}
+
+// Append appends the strip as a single frame to buf and returns the extended
+// buffer. Colors are sent in RGB order. Nothing is appended for an empty strip
+// in the Adalight format, as it can't encode zero LEDs. It panics if the strip
+// is longer than MaxLEDs, as the length can't be encoded.
+func (f Format) Append(buf []byte, strip ledsgo.Strip) []byte {
+	if len(strip) > f.MaxLEDs() {
+		panic("serial: too many LEDs for a single frame")
+	}
+	switch f {
+	case Adalight:
+		if len(strip) == 0 {
+			return buf
+		}
+		count := len(strip) - 1
+		hi := uint8(count >> 8)
+		lo := uint8(count)
+		buf = append(buf, 'A', 'd', 'a', hi, lo, hi^lo^adalightChecksumKey)
+		return strip.AppendBytes(buf, ledsgo.RGB)
+	case TPM2:
+		size := len(strip) * 3
+		buf = append(buf, tpm2Start, tpm2TypeData, uint8(size>>8), uint8(size))
+		buf = strip.AppendBytes(buf, ledsgo.RGB)
+		return append(buf, tpm2End)
+	default:
+		panic("serial: unknown format")
+	}
+}
+
+// Stats contains statistics of a Decoder.
+type Stats struct {
+	Frames         int // frames decoded into the strip
+	Errors         int // corrupt headers and frames
+	SkippedBytes   int // bytes skipped while looking for the start of a frame
+	SizeMismatches int // frames with a different number of LEDs than the strip
+}
+
+// Decoder reads frames from a stream (usually a serial port) into a strip.
+//
+// The decoder resynchronizes after corrupt data by looking for the next valid
+// header. Because Adalight frames don't have a checksum over the data, a
+// corrupt Adalight frame can only be detected when it corrupts the header of
+// the next frame. TPM2 frames are only used when they end in the correct end
+// byte.
+//
+// Frames with more LEDs than the strip are cut off, frames with fewer LEDs
+// only update the first LEDs of the strip.
+type Decoder struct {
+	r      *bufio.Reader
+	format Format
+	strip  ledsgo.Strip
+	frame  []byte
+	stats  Stats
+}
+
+// NewDecoder creates a new decoder that reads frames in the given format from
+// r into strip.
+func NewDecoder(r io.Reader, format Format, strip ledsgo.Strip) *Decoder {
+	return &Decoder{
+		r:      bufio.NewReader(r),
+		format: format,
+		strip:  strip,
+		frame:  make([]byte, len(strip)*3),
+	}
+}
+
+// Stats returns the statistics since the decoder was created.
+func (d *Decoder) Stats() Stats {
+	return d.stats
+}
+
+// Next reads the next frame into the strip. It returns the error of the
+// underlying reader, for example io.EOF when the stream ends before the start
+// of the next frame or io.ErrUnexpectedEOF when it ends within a frame.
+func (d *Decoder) Next() error {
+	for {
+		var done bool
+		var err error
+		switch d.format {
+		case Adalight:
+			done, err = d.nextAdalight()
+		case TPM2:
+			done, err = d.nextTPM2()
+		default:
+			return errors.New("serial: unknown format")
+		}
+		if err != nil || done {
+			return err
+		}
+	}
+}
+
+// Look for the start byte of a header, and peek at the complete header.
+func (d *Decoder) peekHeader(start byte, size int) ([]byte, error) {
+	for {
+		header, err := d.r.Peek(size)
+		if len(header) > 0 && header[0] != start {
+			d.r.Discard(1)
+			d.stats.SkippedBytes++
+			continue
+		}
+		if err == io.EOF && len(header) != 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		return header, err
+	}
+}
+
+// Skip an invalid header: the start byte was found but the rest of the header
+// is not valid.
+func (d *Decoder) skipHeader() {
+	d.r.Discard(1)
+	d.stats.SkippedBytes++
+	d.stats.Errors++
+}
+
+// Read a frame of the given size into the frame buffer, discarding the data
+// that doesn't fit in the strip. It returns the number of bytes read.
+func (d *Decoder) readFrame(size int) (int, error) {
+	n := size
+	if n > len(d.frame) {
+		n = len(d.frame)
+	}
+	if _, err := io.ReadFull(d.r, d.frame[:n]); err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	if _, err := d.r.Discard(size - n); err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	return n, nil
+}
+
+// Copy a frame of n bytes from the frame buffer to the strip.
+func (d *Decoder) apply(size, n int) {
+	if size != len(d.frame) {
+		d.stats.SizeMismatches++
+	}
+	for i := 0; i+3 <= n; i += 3 {
+		d.strip[i/3] = ledsgo.RGB.Get(d.frame[i:])
+	}
+	d.stats.Frames++
+}
+
+func (d *Decoder) nextAdalight() (bool, error) {
+	header, err := d.peekHeader('A', adalightHeaderSize)
+	if err != nil {
+		return false, err
+	}
+	if header[1] != 'd' || header[2] != 'a' || header[3]^header[4]^adalightChecksumKey != header[5] {
+		d.skipHeader()
+		return false, nil
+	}
+	size := (int(header[3])<<8 | int(header[4]) + 1) * 3
+	d.r.Discard(adalightHeaderSize)
+	n, err := d.readFrame(size)
+	if err != nil {
+		return false, err
+	}
+	d.apply(size, n)
+	return true, nil
+}
+
+func (d *Decoder) nextTPM2() (bool, error) {
+	header, err := d.peekHeader(tpm2Start, tpm2HeaderSize)
+	if err != nil {
+		return false, err
+	}
+	packetType := header[1]
+	if packetType != tpm2TypeData && packetType != tpm2TypeCommand && packetType != tpm2TypeResponse {
+		d.skipHeader()
+		return false, nil
+	}
+	size := int(header[2])<<8 | int(header[3])
+	d.r.Discard(tpm2HeaderSize)
+	var n int
+	if packetType == tpm2TypeData {
+		n, err = d.readFrame(size)
+	} else {
+		// Commands and responses are not supported, skip them.
+		_, err = d.r.Discard(size)
+		err = unexpectedEOF(err)
+	}
+	if err != nil {
+		return false, err
+	}
+	end, err := d.r.ReadByte()
+	if err != nil {
+		return false, unexpectedEOF(err)
+	}
+	if end != tpm2End {
+		d.stats.Errors++
+		return false, nil
+	}
+	if packetType != tpm2TypeData {
+		return false, nil
+	}
+	d.apply(size, n)
+	return true, nil
+}
+
+// Convert io.EOF to io.ErrUnexpectedEOF, for errors within a frame.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,156 @@
+package serial
+
+import (
+	"bytes"
+	"image/color"
+	"io"
+	"testing"
+
+	"github.com/aykevl/ledsgo"
+)
+
+var testStrip = ledsgo.Strip{{1, 2, 3, 0xff}, {4, 5, 6, 0xff}}
+
+func TestAppend(t *testing.T) {
+	buf := Adalight.Append(nil, testStrip)
+	expected := []byte{'A', 'd', 'a', 0x00, 0x01, 0x54, 1, 2, 3, 4, 5, 6}
+	if !bytes.Equal(buf, expected) {
+		t.Errorf("unexpected Adalight frame\nexpected: % x\nactual:   % x", expected, buf)
+	}
+	if buf := Adalight.Append(nil, make(ledsgo.Strip, 300)); !bytes.Equal(buf[:6], []byte{'A', 'd', 'a', 0x01, 0x2b, 0x7f}) {
+		t.Errorf("unexpected Adalight header for 300 LEDs: % x", buf[:6])
+	}
+	if buf := Adalight.Append(nil, nil); len(buf) != 0 {
+		t.Errorf("expected no data for empty strip, got % x", buf)
+	}
+
+	buf = TPM2.Append(nil, testStrip)
+	expected = []byte{0xc9, 0xda, 0x00, 0x06, 1, 2, 3, 4, 5, 6, 0x36}
+	if !bytes.Equal(buf, expected) {
+		t.Errorf("unexpected TPM2 frame\nexpected: % x\nactual:   % x", expected, buf)
+	}
+
+	// The longest strips that can be encoded, and one LED more.
+	if buf := Adalight.Append(nil, make(ledsgo.Strip, 65536)); !bytes.Equal(buf[:6], []byte{'A', 'd', 'a', 0xff, 0xff, 0x55}) {
+		t.Errorf("unexpected Adalight header for 65536 LEDs: % x", buf[:6])
+	}
+	if buf := TPM2.Append(nil, make(ledsgo.Strip, 21845)); !bytes.Equal(buf[:4], []byte{0xc9, 0xda, 0xff, 0xff}) {
+		t.Errorf("unexpected TPM2 header for 21845 LEDs: % x", buf[:4])
+	}
+	for _, format := range []Format{Adalight, TPM2} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("format %d: expected a panic for too many LEDs", format)
+				}
+			}()
+			format.Append(nil, make(ledsgo.Strip, format.MaxLEDs()+1))
+		}()
+	}
+}
+
+func TestTPM2Net(t *testing.T) {
+	p := &TPM2NetPacket{Number: 1, NumPackets: 2, Data: []byte{1, 2, 3}}
+	buf := p.Append(nil)
+	expected := []byte{0x9c, 0xda, 0x00, 0x03, 0x01, 0x02, 1, 2, 3, 0x36}
+	if !bytes.Equal(buf, expected) {
+		t.Errorf("unexpected TPM2.net packet\nexpected: % x\nactual:   % x", expected, buf)
+	}
+	p2, err := ParseTPM2Net(buf)
+	if err != nil {
+		t.Fatal("could not parse packet:", err)
+	}
+	if p2.Number != 1 || p2.NumPackets != 2 || !bytes.Equal(p2.Data, p.Data) {
+		t.Errorf("packet changed after round trip: %+v", p2)
+	}
+	buf[len(buf)-1] = 0
+	if _, err := ParseTPM2Net(buf); err == nil {
+		t.Error("expected error for missing end byte")
+	}
+	if _, err := ParseTPM2Net(buf[:8]); err == nil {
+		t.Error("expected error for short packet")
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	for _, format := range []Format{Adalight, TPM2} {
+		// Some garbage, a valid frame, a frame with a different size, a
+		// corrupt header followed by a valid frame.
+		var stream []byte
+		stream = append(stream, 0x00, 'A', 0x36, 0xc9, 0x12)
+		stream = format.Append(stream, testStrip)
+		stream = format.Append(stream, ledsgo.Strip{{7, 8, 9, 0xff}, {10, 11, 12, 0xff}, {13, 14, 15, 0xff}})
+		corrupt := format.Append(nil, testStrip)
+		corrupt[2] ^= 0x01 // change 'a' (Adalight) or the size (TPM2)
+		if format == TPM2 {
+			corrupt[1] = 0x00 // invalid packet type
+		}
+		stream = append(stream, corrupt[:4]...)
+		stream = format.Append(stream, ledsgo.Strip{{16, 17, 18, 0xff}})
+
+		r, w := io.Pipe()
+		go func() {
+			// Write in small pieces, like a serial port.
+			for i := 0; i < len(stream); i += 5 {
+				end := i + 5
+				if end > len(stream) {
+					end = len(stream)
+				}
+				w.Write(stream[i:end])
+			}
+			w.Close()
+		}()
+
+		strip := make(ledsgo.Strip, 2)
+		d := NewDecoder(r, format, strip)
+		expected := []ledsgo.Strip{
+			testStrip,
+			{{7, 8, 9, 0xff}, {10, 11, 12, 0xff}},
+			{{16, 17, 18, 0xff}, {10, 11, 12, 0xff}},
+		}
+		for i, frame := range expected {
+			if err := d.Next(); err != nil {
+				t.Fatalf("format %d, frame %d: unexpected error: %v", format, i, err)
+			}
+			if strip[0] != frame[0] || strip[1] != frame[1] {
+				t.Errorf("format %d, frame %d: expected %v, got %v", format, i, frame, strip)
+			}
+		}
+		if err := d.Next(); err != io.EOF {
+			t.Errorf("format %d: expected io.EOF, got %v", format, err)
+		}
+		stats := d.Stats()
+		if stats.Frames != 3 || stats.SizeMismatches != 2 || stats.Errors < 1 || stats.SkippedBytes < 5 {
+			t.Errorf("format %d: unexpected stats: %+v", format, stats)
+		}
+		t.Logf("format %d: %+v", format, stats)
+	}
+}
+
+func TestDecoderTPM2(t *testing.T) {
+	var stream []byte
+	// Command packets are skipped.
+	stream = append(stream, 0xc9, 0xc0, 0x00, 0x02, 0xc9, 0xc9, 0x36)
+	// A frame with a wrong end byte is dropped.
+	bad := TPM2.Append(nil, ledsgo.Strip{{0xff, 0xff, 0xff, 0xff}})
+	bad[len(bad)-1] = 0x00
+	stream = append(stream, bad...)
+	stream = TPM2.Append(stream, ledsgo.Strip{{1, 2, 3, 0xff}})
+	// A truncated frame.
+	stream = append(stream, 0xc9, 0xda, 0x00, 0x03, 1)
+
+	strip := make(ledsgo.Strip, 1)
+	d := NewDecoder(bytes.NewReader(stream), TPM2, strip)
+	if err := d.Next(); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if strip[0] != (color.RGBA{1, 2, 3, 0xff}) {
+		t.Errorf("unexpected strip: %v", strip)
+	}
+	if err := d.Next(); err != io.ErrUnexpectedEOF {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+	if stats := d.Stats(); stats.Frames != 1 || stats.Errors != 1 || stats.SkippedBytes != 0 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
@@ -0,0 +1,57 @@
+package serial
+
+import "errors"
+
+// TPM2NetPort is the UDP port used by TPM2.net.
+const TPM2NetPort = 65506
+
+// TPM2NetMaxDataSize is the maximum size of the data in a TPM2.net packet, so
+// that a packet fits in a single Ethernet frame.
+const TPM2NetMaxDataSize = 1490
+
+const tpm2NetHeaderSize = 6
+
+var (
+	errTPM2NetShort   = errors.New("serial: TPM2.net packet too short")
+	errTPM2NetInvalid = errors.New("serial: not a TPM2.net packet")
+)
+
+// TPM2NetPacket is a single TPM2.net packet. Large frames are split over
+// multiple packets, which are numbered starting at 1.
+type TPM2NetPacket struct {
+	Number     uint8 // packet number, 1 for the first packet of a frame
+	NumPackets uint8 // number of packets in a frame
+	Data       []byte
+}
+
+// ParseTPM2Net parses a TPM2.net data packet. The data of the packet refers to
+// buf, so buf must not be modified while the packet is in use.
+func ParseTPM2Net(buf []byte) (*TPM2NetPacket, error) {
+	if len(buf) < tpm2NetHeaderSize+1 {
+		return nil, errTPM2NetShort
+	}
+	if buf[0] != tpm2NetStart || buf[1] != tpm2TypeData {
+		return nil, errTPM2NetInvalid
+	}
+	size := int(buf[2])<<8 | int(buf[3])
+	if tpm2NetHeaderSize+size+1 > len(buf) {
+		return nil, errTPM2NetShort
+	}
+	if buf[tpm2NetHeaderSize+size] != tpm2End {
+		return nil, errTPM2NetInvalid
+	}
+	return &TPM2NetPacket{
+		Number:     buf[4],
+		NumPackets: buf[5],
+		Data:       buf[tpm2NetHeaderSize : tpm2NetHeaderSize+size],
+	}, nil
+}
+
+// Append appends the binary encoding of the packet to buf and returns the
+// extended buffer. The data must not be longer than TPM2NetMaxDataSize.
+func (p *TPM2NetPacket) Append(buf []byte) []byte {
+	size := len(p.Data)
+	buf = append(buf, tpm2NetStart, tpm2TypeData, uint8(size>>8), uint8(size), p.Number, p.NumPackets)
+	buf = append(buf, p.Data...)
+	return append(buf, tpm2End)
+}
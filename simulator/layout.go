@@ -0,0 +1,75 @@
+package simulator
+
+import "math"
+
+// Point is the position of a LED. The unit is arbitrary but is usually the
+// distance between two LEDs on a strip. Z is only used for 3D layouts, which
+// can be rotated in the viewer.
+type Point struct {
+	X, Y, Z float64
+}
+
+// Layout is the position of every LED of a strip, in strip order.
+type Layout []Point
+
+// Line returns a layout of a straight strip of n LEDs, from left to right.
+func Line(n int) Layout {
+	layout := make(Layout, n)
+	for i := range layout {
+		layout[i] = Point{X: float64(i)}
+	}
+	return layout
+}
+
+// Matrix returns the layout of a LED matrix, with LEDs ordered row by row
+// starting at the top left. In a serpentine matrix, every other row runs from
+// right to left, as is common in matrices made from a single strip.
+func Matrix(width, height int, serpentine bool) Layout {
+	layout := make(Layout, 0, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			lx := x
+			if serpentine && y%2 == 1 {
+				lx = width - 1 - x
+			}
+			layout = append(layout, Point{X: float64(lx), Y: float64(y)})
+		}
+	}
+	return layout
+}
+
+// Ring returns the layout of a ring of n LEDs at a distance of 1 from each
+// other. The first LED is at the right (3 o'clock) and the following LEDs go
+// clockwise, like the angles of draw.Arc.
+func Ring(n int) Layout {
+	radius := float64(n) / (2 * math.Pi)
+	layout := make(Layout, n)
+	for i := range layout {
+		angle := 2 * math.Pi * float64(i) / float64(n)
+		layout[i] = Point{
+			X: radius + radius*math.Cos(angle),
+			Y: radius + radius*math.Sin(angle),
+		}
+	}
+	return layout
+}
+
+// Return the (rounded) bounding box of the layout in the X and Y dimensions.
+func (l Layout) bounds() (minX, minY, maxX, maxY int) {
+	for i, p := range l {
+		x, y := int(math.Round(p.X)), int(math.Round(p.Y))
+		if i == 0 || x < minX {
+			minX = x
+		}
+		if i == 0 || y < minY {
+			minY = y
+		}
+		if i == 0 || x > maxX {
+			maxX = x
+		}
+		if i == 0 || y > maxY {
+			maxY = y
+		}
+	}
+	return
+}
@@ -0,0 +1,373 @@
+// Package simulator shows LED animations in a web browser, for any layout of
+// LEDs such as matrices, rings or 3D sculptures. It runs a local HTTP server
+// with a viewer page that draws every LED at its position with a glow effect.
+// Frames are streamed to the page over a WebSocket, and the page can change
+// parameters (such as the brightness or animation speed) of the simulator.
+//
+// The viewer is embedded in the package and doesn't need any external assets
+// or network access. A simulator can be used in three ways:
+//
+//   - As a ledsgo.Strip: draw into the Strip field and call Display.
+//   - As a demos.Displayer: SetPixel sets all LEDs at that (rounded) position,
+//     followed by a call to Display.
+//   - With Run, which draws an animation at a fixed frame rate.
+package simulator
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"image/color"
+	"math"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aykevl/ledsgo"
+	"github.com/aykevl/ledsgo/demos"
+	"github.com/aykevl/ledsgo/internal/gamma"
+)
+
+//go:embed viewer.html
+var viewerHTML []byte
+
+// Lookup table to convert linear LED colors to the sRGB colors of the
+// browser.
+var gammaTable = gamma.Table(gamma.Default)
+
+// Param is a parameter that can be changed from the viewer page, such as the
+// speed of an animation. Its value can be read from any goroutine.
+type Param struct {
+	Name           string
+	Min, Max, Step float64
+
+	value uint64 // float64 bits, accessed atomically
+}
+
+// Value returns the current value of the parameter.
+func (p *Param) Value() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&p.value))
+}
+
+// Set changes the value of the parameter, clamped to the range of the
+// parameter.
+func (p *Param) Set(value float64) {
+	if value < p.Min || math.IsNaN(value) {
+		value = p.Min
+	}
+	if value > p.Max {
+		value = p.Max
+	}
+	atomic.StoreUint64(&p.value, math.Float64bits(value))
+}
+
+// Server is a simulator for a single strip of LEDs. It implements http.Handler
+// for the viewer page at / and the WebSocket frame stream at /ws.
+type Server struct {
+	// Strip contains the LED colors, in the same order as the layout.
+	Strip ledsgo.Strip
+
+	// Brightness (0-255) is applied to the LED colors before they are sent
+	// to the viewer. Speed is a multiplier for the animation time in Run.
+	Brightness *Param
+	Speed      *Param
+
+	// OnParam is called when a parameter was changed from the viewer page,
+	// after the new value has been set. It is called from the goroutine of the
+	// WebSocket connection.
+	OnParam func(p *Param)
+
+	layout        Layout
+	width, height int16
+	minX, minY    int
+	cells         [][]int // LED indices for every (x, y) position
+
+	lock    sync.Mutex
+	params  []*Param
+	clients map[*client]struct{}
+	frame   []byte // last frame sent to the clients
+	server  *http.Server
+}
+
+// A connected viewer page.
+type client struct {
+	conn   *wsConn
+	frames chan []byte
+}
+
+// Message sent to the viewer page when it connects.
+type configJSON struct {
+	LEDs   [][3]float64 `json:"leds"`
+	Params []paramJSON  `json:"params"`
+}
+
+type paramJSON struct {
+	Name  string  `json:"name"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Step  float64 `json:"step"`
+	Value float64 `json:"value"`
+}
+
+// Maximum width and height of a layout, in units of the layout.
+const maxLayoutSize = math.MaxInt16
+
+var (
+	errLayoutTooLarge   = errors.New("simulator: layout is larger than 32767 units")
+	errInvalidFrameRate = errors.New("simulator: frame rate must be positive")
+)
+
+// NewServer returns a new simulator for the given layout. Its strip has as
+// many LEDs as there are points in the layout. The layout may be at most 32767
+// units wide and high, so that its size fits in the int16 coordinates of a
+// demos.Displayer.
+func NewServer(layout Layout) (*Server, error) {
+	for _, p := range layout {
+		// This also rejects NaN and infinite coordinates.
+		if !(math.Abs(p.X) <= maxLayoutSize && math.Abs(p.Y) <= maxLayoutSize) {
+			return nil, errLayoutTooLarge
+		}
+	}
+	minX, minY, maxX, maxY := layout.bounds()
+	if maxX-minX+1 > maxLayoutSize || maxY-minY+1 > maxLayoutSize {
+		return nil, errLayoutTooLarge
+	}
+
+	s := &Server{
+		Strip:   make(ledsgo.Strip, len(layout)),
+		layout:  layout,
+		clients: make(map[*client]struct{}),
+	}
+	s.Brightness = s.AddParam("brightness", 0, 255, 1, 255)
+	s.Speed = s.AddParam("speed", 0, 4, 0.1, 1)
+
+	// Map every position to the LEDs at that position, for SetPixel.
+	s.minX, s.minY = minX, minY
+	if len(layout) != 0 {
+		s.width = int16(maxX - minX + 1)
+		s.height = int16(maxY - minY + 1)
+	}
+	s.cells = make([][]int, int(s.width)*int(s.height))
+	for i, p := range layout {
+		x := int(math.Round(p.X)) - minX
+		y := int(math.Round(p.Y)) - minY
+		cell := y*int(s.width) + x
+		s.cells[cell] = append(s.cells[cell], i)
+	}
+	return s, nil
+}
+
+// AddParam adds a parameter that is shown in the viewer page as a slider. It
+// must be called before the server is used.
+func (s *Server) AddParam(name string, low, high, step, value float64) *Param {
+	p := &Param{Name: name, Min: low, Max: high, Step: step}
+	p.Set(value)
+	s.params = append(s.params, p)
+	return p
+}
+
+// Size returns the size of the bounding box of the layout, rounded to whole
+// LEDs.
+func (s *Server) Size() (width, height int16) {
+	return s.width, s.height
+}
+
+// SetPixel sets the color of all LEDs at the given position, rounded to whole
+// units of the layout.
+func (s *Server) SetPixel(x, y int16, c color.RGBA) {
+	if x < 0 || y < 0 || x >= s.width || y >= s.height {
+		return
+	}
+	for _, i := range s.cells[int(y)*int(s.width)+int(x)] {
+		s.Strip[i] = c
+	}
+}
+
+// Display sends the current colors of the strip to all connected viewers.
+// Viewers that can't keep up skip frames.
+func (s *Server) Display() error {
+	brightness := uint8(s.Brightness.Value())
+	frame := make([]byte, 0, len(s.Strip)*3)
+	for _, c := range s.Strip {
+		c = ledsgo.ApplyAlpha(c, brightness)
+		frame = append(frame, gammaTable[c.R], gammaTable[c.G], gammaTable[c.B])
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.frame = frame
+	for c := range s.clients {
+		select {
+		case <-c.frames:
+			// Drop the previous frame that wasn't sent yet.
+		default:
+		}
+		c.frames <- frame
+	}
+	return nil
+}
+
+// Run draws the animation at the given frame rate and sends it to the viewers,
+// until the done channel is closed. The time passed to the animation runs at
+// the rate of the Speed parameter. The frame rate must be positive.
+func (s *Server) Run(animation func(demos.Displayer, time.Time), frameRate int, done <-chan struct{}) error {
+	if frameRate <= 0 {
+		return errInvalidFrameRate
+	}
+	ticker := time.NewTicker(time.Second / time.Duration(frameRate))
+	defer ticker.Stop()
+	last := time.Now()
+	now := last
+	for {
+		animation(s, now)
+		if err := s.Display(); err != nil {
+			return err
+		}
+		select {
+		case <-done:
+			return nil
+		case t := <-ticker.C:
+			now = now.Add(time.Duration(float64(t.Sub(last)) * s.Speed.Value()))
+			last = t
+		}
+	}
+}
+
+// ServeHTTP serves the viewer page at / and the WebSocket at /ws.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(viewerHTML)
+	case "/ws":
+		conn, err := upgrade(w, r)
+		if err != nil {
+			return
+		}
+		s.serveClient(conn)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// ListenAndServe starts the HTTP server at the given address, such as
+// "localhost:8080". It returns nil after Close is called.
+func (s *Server) ListenAndServe(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	return s.Serve(listener)
+}
+
+// Serve serves HTTP requests on the listener. It returns nil after Close is
+// called.
+func (s *Server) Serve(listener net.Listener) error {
+	s.lock.Lock()
+	s.server = &http.Server{Handler: s}
+	server := s.server
+	s.lock.Unlock()
+	err := server.Serve(listener)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// Close stops the HTTP server and disconnects all viewers.
+func (s *Server) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for c := range s.clients {
+		c.conn.close()
+	}
+	if s.server == nil {
+		return nil
+	}
+	return s.server.Close()
+}
+
+// Send the layout, parameters and frames to a connected viewer, and handle
+// parameter changes from it.
+func (s *Server) serveClient(conn *wsConn) {
+	defer conn.close()
+	config := configJSON{LEDs: make([][3]float64, len(s.layout))}
+	for i, p := range s.layout {
+		config.LEDs[i] = [3]float64{p.X, p.Y, p.Z}
+	}
+	for _, p := range s.params {
+		config.Params = append(config.Params, paramJSON{p.Name, p.Min, p.Max, p.Step, p.Value()})
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return
+	}
+	if err := conn.writeMessage(opText, data); err != nil {
+		return
+	}
+
+	c := &client{
+		conn:   conn,
+		frames: make(chan []byte, 1),
+	}
+	s.lock.Lock()
+	if s.frame != nil {
+		c.frames <- s.frame
+	}
+	s.clients[c] = struct{}{}
+	s.lock.Unlock()
+	defer func() {
+		s.lock.Lock()
+		delete(s.clients, c)
+		s.lock.Unlock()
+	}()
+
+	// Send frames from a separate goroutine, until the connection is closed.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case frame := <-c.frames:
+				if err := conn.writeMessage(opBinary, frame); err != nil {
+					conn.close()
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for {
+		opcode, data, err := conn.readMessage()
+		if err != nil {
+			return
+		}
+		if opcode == opText {
+			s.handleMessage(data)
+		}
+	}
+}
+
+// Handle a parameter change from the viewer, such as {"name":"speed","value":2}.
+// Invalid messages are ignored.
+func (s *Server) handleMessage(data []byte) {
+	var msg struct {
+		Name  string  `json:"name"`
+		Value float64 `json:"value"`
+	}
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return
+	}
+	for _, p := range s.params {
+		if p.Name == msg.Name {
+			p.Set(msg.Value)
+			if s.OnParam != nil {
+				s.OnParam(p)
+			}
+			return
+		}
+	}
+}
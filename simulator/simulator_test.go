@@ -0,0 +1,256 @@
+package simulator
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"image/color"
+	"io"
+	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aykevl/ledsgo/demos"
+)
+
+func TestLayouts(t *testing.T) {
+	m := Matrix(3, 2, true)
+	if len(m) != 6 || m[2] != (Point{X: 2}) || m[3] != (Point{X: 2, Y: 1}) || m[5] != (Point{Y: 1}) {
+		t.Errorf("unexpected serpentine matrix: %v", m)
+	}
+	if l := Line(3); l[2] != (Point{X: 2}) {
+		t.Errorf("unexpected line: %v", l)
+	}
+	r := Ring(24)
+	for i := range r {
+		next := r[(i+1)%len(r)]
+		if d := math.Hypot(next.X-r[i].X, next.Y-r[i].Y); d < 0.99 || d > 1.01 {
+			t.Errorf("ring: distance between LED %d and the next is %f", i, d)
+		}
+	}
+	if r[6].Y < r[0].Y || r[6].X > r[0].X {
+		t.Errorf("ring doesn't go clockwise: %v %v", r[0], r[6])
+	}
+}
+
+func TestDisplayer(t *testing.T) {
+	s, err := NewServer(Layout{{X: -1, Y: 2}, {X: 1.2, Y: 2}, {X: 0.9, Y: 3.4}, {X: 3, Y: 5, Z: 7}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if width, height := s.Size(); width != 5 || height != 4 {
+		t.Errorf("unexpected size: %dx%d", width, height)
+	}
+	red := color.RGBA{255, 0, 0, 255}
+	s.SetPixel(2, 0, red) // LEDs 1 and 2 are both at (1, 3) after rounding
+	s.SetPixel(5, 5, red) // out of bounds
+	s.SetPixel(2, 1, red)
+	if s.Strip[0] != (color.RGBA{}) || s.Strip[1] != red || s.Strip[2] != red || s.Strip[3] != (color.RGBA{}) {
+		t.Errorf("unexpected strip: %v", s.Strip)
+	}
+}
+
+func TestLayoutTooLarge(t *testing.T) {
+	for _, layout := range []Layout{
+		Line(32768),
+		{{X: -20000}, {X: 20000}},
+		{{X: 0, Y: math.Inf(1)}},
+		{{X: math.NaN()}},
+	} {
+		if _, err := NewServer(layout); err != errLayoutTooLarge {
+			t.Errorf("expected errLayoutTooLarge for a layout of %d LEDs, got %v", len(layout), err)
+		}
+	}
+	s, err := NewServer(Line(32767))
+	if err != nil {
+		t.Fatal("could not create the largest line:", err)
+	}
+	if width, height := s.Size(); width != 32767 || height != 1 {
+		t.Errorf("unexpected size: %dx%d", width, height)
+	}
+}
+
+func TestWebSocketAccept(t *testing.T) {
+	// Example from RFC 6455 section 1.3.
+	if got := websocketAccept("dGhlIHNhbXBsZSBub25jZQ=="); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("unexpected accept value: %s", got)
+	}
+}
+
+// Read a single (unmasked) frame from the server.
+func readTestFrame(t *testing.T, r *bufio.Reader) (byte, []byte) {
+	t.Helper()
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		t.Fatal("could not read frame:", err)
+	}
+	if header[1]&0x80 != 0 {
+		t.Fatal("frame from the server is masked")
+	}
+	length := int(header[1] & 0x7f)
+	if length == 126 {
+		var ext [2]byte
+		io.ReadFull(r, ext[:])
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		t.Fatal("could not read frame payload:", err)
+	}
+	return header[0], payload
+}
+
+// Write a masked frame, as sent by a browser.
+func writeTestFrame(t *testing.T, conn net.Conn, header byte, payload []byte) {
+	t.Helper()
+	mask := []byte{0x12, 0x34, 0x56, 0x78}
+	buf := []byte{header, 0x80 | byte(len(payload))}
+	buf = append(buf, mask...)
+	for i, b := range payload {
+		buf = append(buf, b^mask[i%4])
+	}
+	if _, err := conn.Write(buf); err != nil {
+		t.Fatal("could not write frame:", err)
+	}
+}
+
+func TestServer(t *testing.T) {
+	s, err := NewServer(Line(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed := make(chan *Param, 1)
+	s.OnParam = func(p *Param) {
+		changed <- p
+	}
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	// The viewer page.
+	resp, err := http.Get(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(page), "<canvas") {
+		t.Errorf("unexpected viewer page (status %d)", resp.StatusCode)
+	}
+
+	// A plain request to the WebSocket must fail.
+	resp, err = http.Get(server.URL + "/ws")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status 400 for a plain request, got %d", resp.StatusCode)
+	}
+
+	// Send a frame before connecting: it is sent right after the layout.
+	s.Strip[0] = color.RGBA{255, 0, 0, 255}
+	s.Display()
+
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	io.WriteString(conn, "GET /ws HTTP/1.1\r\nHost: localhost\r\nUpgrade: websocket\r\nConnection: keep-alive, Upgrade\r\n"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n")
+	r := bufio.NewReader(conn)
+	resp, err = http.ReadResponse(r, nil)
+	if err != nil {
+		t.Fatal("could not read handshake response:", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-WebSocket-Accept") != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("unexpected handshake response: %d %v", resp.StatusCode, resp.Header)
+	}
+
+	header, payload := readTestFrame(t, r)
+	if header != 0x80|opText {
+		t.Errorf("expected a text frame, got header %#x", header)
+	}
+	var config configJSON
+	if err := json.Unmarshal(payload, &config); err != nil {
+		t.Fatal("could not parse config:", err)
+	}
+	if len(config.LEDs) != 3 || config.LEDs[2] != [3]float64{2, 0, 0} || len(config.Params) != 2 || config.Params[0].Name != "brightness" {
+		t.Errorf("unexpected config: %s", payload)
+	}
+	header, payload = readTestFrame(t, r)
+	if header != 0x80|opBinary || string(payload) != "\xff\x00\x00\x00\x00\x00\x00\x00\x00" {
+		t.Errorf("unexpected first frame: %#x %v", header, payload)
+	}
+
+	// Change the brightness from the page. The gamma encoded value of 128 is
+	// 186.
+	writeTestFrame(t, conn, 0x80|opText, []byte(`{"name":"brightness","value":128}`))
+	select {
+	case p := <-changed:
+		if p != s.Brightness || p.Value() != 128 {
+			t.Errorf("unexpected parameter change: %s = %f", p.Name, p.Value())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("parameter wasn't changed")
+	}
+	s.Display()
+	_, payload = readTestFrame(t, r)
+	if payload[0] != 186 {
+		t.Errorf("brightness not applied: %v", payload)
+	}
+
+	// Values are clamped, and a fragmented message is reassembled.
+	writeTestFrame(t, conn, opText, []byte(`{"name":"speed",`))
+	writeTestFrame(t, conn, 0x80|opContinuation, []byte(`"value":100}`))
+	<-changed
+	if v := s.Speed.Value(); v != 4 {
+		t.Errorf("expected the speed to be clamped to 4, got %f", v)
+	}
+
+	// Ping and close.
+	writeTestFrame(t, conn, 0x80|opPing, []byte("hi"))
+	if header, payload := readTestFrame(t, r); header != 0x80|opPong || string(payload) != "hi" {
+		t.Errorf("unexpected reply to ping: %#x %q", header, payload)
+	}
+	writeTestFrame(t, conn, 0x80|opClose, []byte{0x03, 0xe8})
+	if header, _ := readTestFrame(t, r); header != 0x80|opClose {
+		t.Errorf("unexpected reply to close: %#x", header)
+	}
+}
+
+func TestRun(t *testing.T) {
+	s, err := NewServer(Matrix(2, 2, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Speed.Set(0) // time stands still
+	done := make(chan struct{})
+	var times []time.Time
+	animation := func(display demos.Displayer, now time.Time) {
+		display.SetPixel(1, 1, color.RGBA{0, 0, 255, 255})
+		times = append(times, now)
+		if len(times) == 3 {
+			close(done)
+		}
+	}
+	if err := s.Run(animation, 1000, done); err != nil {
+		t.Fatal(err)
+	}
+	if len(times) != 3 || !times[2].Equal(times[0]) {
+		t.Errorf("unexpected animation times: %v", times)
+	}
+	if s.Strip[3] != (color.RGBA{0, 0, 255, 255}) {
+		t.Errorf("animation wasn't drawn: %v", s.Strip)
+	}
+
+	if err := s.Run(animation, 0, done); err != errInvalidFrameRate {
+		t.Errorf("expected errInvalidFrameRate, got %v", err)
+	}
+}
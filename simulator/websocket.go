@@ -0,0 +1,199 @@
+package simulator
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// This is a minimal WebSocket (RFC 6455) implementation, so that the simulator
+// doesn't need any dependencies. It only supports what the viewer uses:
+// unfragmented binary and text messages from the server, and small messages
+// from the browser.
+
+// Opcodes of WebSocket frames.
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+// Maximum size of a message received from the browser.
+const maxMessageSize = 1 << 16
+
+// GUID appended to the key in the handshake, see RFC 6455 section 1.3.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+var (
+	errNotWebSocket    = errors.New("simulator: not a websocket handshake")
+	errMessageTooLarge = errors.New("simulator: websocket message too large")
+	errUnmasked        = errors.New("simulator: unmasked websocket frame from client")
+	errBadFrame        = errors.New("simulator: invalid websocket frame")
+)
+
+// A WebSocket connection on the server side.
+type wsConn struct {
+	conn      net.Conn
+	r         *bufio.Reader
+	writeLock sync.Mutex
+}
+
+// Return the Sec-WebSocket-Accept value for the given key.
+func websocketAccept(key string) string {
+	hash := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// Return whether the comma separated header contains the token, ignoring case.
+func headerContains(header http.Header, name, token string) bool {
+	for _, value := range header[http.CanonicalHeaderKey(name)] {
+		for _, t := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Upgrade the HTTP request to a WebSocket connection. An error response is
+// sent if this isn't a valid WebSocket handshake.
+func upgrade(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != http.MethodGet || key == "" ||
+		!headerContains(r.Header, "Connection", "upgrade") ||
+		!headerContains(r.Header, "Upgrade", "websocket") ||
+		r.Header.Get("Sec-WebSocket-Version") != "13" {
+		http.Error(w, "expected a websocket handshake", http.StatusBadRequest)
+		return nil, errNotWebSocket
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket not supported", http.StatusInternalServerError)
+		return nil, errNotWebSocket
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	_, err = io.WriteString(conn, "HTTP/1.1 101 Switching Protocols\r\n"+
+		"Upgrade: websocket\r\n"+
+		"Connection: Upgrade\r\n"+
+		"Sec-WebSocket-Accept: "+websocketAccept(key)+"\r\n\r\n")
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &wsConn{conn: conn, r: rw.Reader}, nil
+}
+
+// Read the next text or binary message. Ping and close frames are answered.
+// It returns io.EOF when the connection was closed by the browser.
+func (c *wsConn) readMessage() (opcode byte, data []byte, err error) {
+	for {
+		var header [2]byte
+		if _, err := io.ReadFull(c.r, header[:]); err != nil {
+			return 0, nil, err
+		}
+		fin := header[0]&0x80 != 0
+		op := header[0] & 0x0f
+		if header[1]&0x80 == 0 {
+			return 0, nil, errUnmasked
+		}
+		length := uint64(header[1] & 0x7f)
+		switch length {
+		case 126:
+			var ext [2]byte
+			if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+				return 0, nil, err
+			}
+			length = uint64(binary.BigEndian.Uint16(ext[:]))
+		case 127:
+			var ext [8]byte
+			if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+				return 0, nil, err
+			}
+			length = binary.BigEndian.Uint64(ext[:])
+		}
+		if length > maxMessageSize || uint64(len(data))+length > maxMessageSize {
+			return 0, nil, errMessageTooLarge
+		}
+		var mask [4]byte
+		if _, err := io.ReadFull(c.r, mask[:]); err != nil {
+			return 0, nil, err
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(c.r, payload); err != nil {
+			return 0, nil, err
+		}
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+
+		switch op {
+		case opClose:
+			// Echo the status code, as required by the RFC.
+			c.writeMessage(opClose, payload)
+			return 0, nil, io.EOF
+		case opPing:
+			if err := c.writeMessage(opPong, payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case opPong:
+			continue
+		case opText, opBinary:
+			if data != nil {
+				return 0, nil, errBadFrame // previous message wasn't finished
+			}
+			opcode = op
+			data = payload
+		case opContinuation:
+			if data == nil {
+				return 0, nil, errBadFrame
+			}
+			data = append(data, payload...)
+		default:
+			return 0, nil, errBadFrame
+		}
+		if fin {
+			return opcode, data, nil
+		}
+	}
+}
+
+// Write a single unfragmented message. It can be called from multiple
+// goroutines.
+func (c *wsConn) writeMessage(opcode byte, data []byte) error {
+	buf := make([]byte, 0, 10+len(data))
+	buf = append(buf, 0x80|opcode)
+	switch {
+	case len(data) < 126:
+		buf = append(buf, byte(len(data)))
+	case len(data) <= 0xffff:
+		buf = append(buf, 126, byte(len(data)>>8), byte(len(data)))
+	default:
+		buf = append(buf, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(buf[2:], uint64(len(data)))
+	}
+	buf = append(buf, data...)
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	_, err := c.conn.Write(buf)
+	return err
+}
+
+// Close the underlying connection.
+func (c *wsConn) close() error {
+	return c.conn.Close()
+}
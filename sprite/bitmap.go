@@ -0,0 +1,150 @@
+package sprite
+
+import (
+	"image"
+	"image/color"
+)
+
+// Format is the pixel format of a Bitmap.
+type Format uint8
+
+const (
+	// FormatRGB stores 3 bytes (red, green, blue) per pixel. All pixels are
+	// opaque.
+	FormatRGB Format = iota
+
+	// FormatRGBA stores 4 bytes (red, green, blue, alpha) per pixel. Colors
+	// are not premultiplied.
+	FormatRGBA
+
+	// FormatPaletted stores an index into the palette per pixel, using 1, 2,
+	// 4 or 8 bits per pixel depending on the size of the palette. Pixels are
+	// packed most significant bit first, without padding between rows.
+	FormatPaletted
+)
+
+// Bitmap is a compact image that can be stored in flash on a microcontroller.
+// It implements image.Image, so it can be drawn with Draw. Bitmaps are usually
+// generated from PNG files with the ledsgo-png2go tool.
+//
+// The palette and pixel data are strings instead of byte slices, because
+// TinyGo can keep constant strings in flash while a byte slice is always
+// copied to RAM.
+type Bitmap struct {
+	Width, Height int
+	Format        Format
+	Palette       string // 4 bytes (red, green, blue, alpha) per color
+	Data          string
+}
+
+// NewBitmap converts an image to a bitmap, using the most compact format that
+// doesn't lose any information: FormatPaletted if there are at most 256
+// colors, otherwise FormatRGB for opaque images or FormatRGBA for images with
+// transparency.
+func NewBitmap(img image.Image) *Bitmap {
+	bounds := img.Bounds()
+	b := &Bitmap{
+		Width:  bounds.Dx(),
+		Height: bounds.Dy(),
+	}
+	pixels := make([]color.NRGBA, 0, b.Width*b.Height)
+	indices := make(map[color.NRGBA]int)
+	opaque := true
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A == 0 {
+				// All fully transparent pixels are the same.
+				c = color.NRGBA{}
+			}
+			if c.A != 0xff {
+				opaque = false
+			}
+			if _, ok := indices[c]; !ok && len(indices) <= 256 {
+				indices[c] = len(indices)
+			}
+			pixels = append(pixels, c)
+		}
+	}
+
+	var palette, data []byte
+	switch {
+	case len(indices) <= 256:
+		b.Format = FormatPaletted
+		palette = make([]byte, len(indices)*4)
+		for c, i := range indices {
+			copy(palette[i*4:], []byte{c.R, c.G, c.B, c.A})
+		}
+		b.Palette = string(palette)
+		bits := b.bitsPerPixel()
+		data = make([]byte, (len(pixels)*bits+7)/8)
+		for i, c := range pixels {
+			bit := i * bits
+			data[bit/8] |= byte(indices[c]) << (8 - bits - bit%8)
+		}
+	case opaque:
+		b.Format = FormatRGB
+		data = make([]byte, 0, len(pixels)*3)
+		for _, c := range pixels {
+			data = append(data, c.R, c.G, c.B)
+		}
+	default:
+		b.Format = FormatRGBA
+		data = make([]byte, 0, len(pixels)*4)
+		for _, c := range pixels {
+			data = append(data, c.R, c.G, c.B, c.A)
+		}
+	}
+	b.Data = string(data)
+	return b
+}
+
+// Return the number of bits per palette index: the smallest of 1, 2, 4 or 8
+// that can address every color in the palette.
+func (b *Bitmap) bitsPerPixel() int {
+	colors := len(b.Palette) / 4
+	bits := 1
+	for 1<<bits < colors {
+		bits *= 2
+	}
+	return bits
+}
+
+// ColorModel returns color.NRGBAModel.
+func (b *Bitmap) ColorModel() color.Model {
+	return color.NRGBAModel
+}
+
+// Bounds returns the bitmap size, with the top left corner at (0, 0).
+func (b *Bitmap) Bounds() image.Rectangle {
+	return image.Rect(0, 0, b.Width, b.Height)
+}
+
+// At returns the color of the given pixel.
+func (b *Bitmap) At(x, y int) color.Color {
+	return b.NRGBAAt(x, y)
+}
+
+// NRGBAAt returns the color of the given pixel, or transparent black outside
+// the bitmap.
+func (b *Bitmap) NRGBAAt(x, y int) color.NRGBA {
+	if x < 0 || y < 0 || x >= b.Width || y >= b.Height {
+		return color.NRGBA{}
+	}
+	i := y*b.Width + x
+	switch b.Format {
+	case FormatRGB:
+		p := b.Data[i*3 : i*3+3]
+		return color.NRGBA{p[0], p[1], p[2], 0xff}
+	case FormatRGBA:
+		p := b.Data[i*4 : i*4+4]
+		return color.NRGBA{p[0], p[1], p[2], p[3]}
+	case FormatPaletted:
+		bits := b.bitsPerPixel()
+		bit := i * bits
+		index := int(b.Data[bit/8]>>(8-bits-bit%8)) & (1<<bits - 1)
+		p := b.Palette[index*4 : index*4+4]
+		return color.NRGBA{p[0], p[1], p[2], p[3]}
+	}
+	return color.NRGBA{}
+}
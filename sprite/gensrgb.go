@@ -0,0 +1,46 @@
+// +build none
+
+// This file is used in `go generate` to update srgbtable.go. It calculates the
+// lookup table that converts sRGB color components to linear values, so that
+// no floating point math is needed at runtime.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"math"
+	"os"
+)
+
+func main() {
+	buf := &bytes.Buffer{}
+	buf.WriteString("// Code generated by gensrgb.go; DO NOT EDIT.\n\npackage sprite\n\n")
+	buf.WriteString("// Lookup table to convert an 8-bit sRGB value to a 16-bit linear value.\n")
+	buf.WriteString("var srgbToLinear = [256]uint16{")
+	for i := 0; i < 256; i++ {
+		if i%8 == 0 {
+			buf.WriteString("\n")
+		}
+		v := float64(i) / 255
+		if v <= 0.04045 {
+			v /= 12.92
+		} else {
+			v = math.Pow((v+0.055)/1.055, 2.4)
+		}
+		fmt.Fprintf(buf, "%d, ", int(v*0xffff+0.5))
+	}
+	buf.WriteString("\n}\n")
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to format source:", err)
+		os.Exit(1)
+	}
+	err = ioutil.WriteFile("srgbtable.go", src, 0666)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to write srgbtable.go:", err)
+		os.Exit(1)
+	}
+}
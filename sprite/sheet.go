@@ -0,0 +1,96 @@
+package sprite
+
+import (
+	"image"
+	"image/color"
+	"time"
+
+	"github.com/aykevl/ledsgo/demos"
+)
+
+// Sheet is a sprite sheet: an image that contains the frames of an animation,
+// all of the same size. Frames are ordered from left to right, then from top
+// to bottom.
+type Sheet struct {
+	Image         image.Image
+	Width, Height int           // size of a single frame
+	Frames        int           // number of frames, or 0 to use all frames in the image
+	FrameDuration time.Duration // how long every frame is shown
+}
+
+// Len returns the number of frames in the sprite sheet. It is 0 if the frame
+// size is not positive.
+func (s *Sheet) Len() int {
+	if s.Image == nil || s.Width <= 0 || s.Height <= 0 {
+		return 0
+	}
+	if s.Frames != 0 {
+		return s.Frames
+	}
+	size := s.Image.Bounds().Size()
+	return (size.X / s.Width) * (size.Y / s.Height)
+}
+
+// Frame returns frame i of the sprite sheet. The returned image has bounds
+// starting at (0, 0). It returns nil if the frame size is not positive or
+// larger than the image.
+func (s *Sheet) Frame(i int) image.Image {
+	if s.Image == nil || s.Width <= 0 || s.Height <= 0 {
+		return nil
+	}
+	bounds := s.Image.Bounds()
+	perRow := bounds.Dx() / s.Width
+	if perRow == 0 {
+		return nil
+	}
+	origin := bounds.Min.Add(image.Pt(i%perRow*s.Width, i/perRow*s.Height))
+	return &frame{
+		img:  s.Image,
+		rect: image.Rectangle{origin, origin.Add(image.Pt(s.Width, s.Height))},
+	}
+}
+
+// Index returns the index of the frame to show at the given time. The
+// animation loops forever, with the first frame shown at the Unix epoch.
+func (s *Sheet) Index(now time.Time) int {
+	n := s.Len()
+	if n == 0 || s.FrameDuration <= 0 {
+		return 0
+	}
+	return int(uint64(now.UnixNano()) / uint64(s.FrameDuration) % uint64(n))
+}
+
+// FrameAt returns the frame to show at the given time.
+func (s *Sheet) FrameAt(now time.Time) image.Image {
+	return s.Frame(s.Index(now))
+}
+
+// Draw draws the frame to show at the given time, scaled to the rectangle dst
+// of the display. Nothing is drawn if there is no frame.
+func (s *Sheet) Draw(display demos.Displayer, dst image.Rectangle, now time.Time, options *Options) {
+	if img := s.FrameAt(now); img != nil {
+		Draw(display, dst, img, options)
+	}
+}
+
+// A single frame of a sprite sheet, translated so that the top left corner is
+// at (0, 0).
+type frame struct {
+	img  image.Image
+	rect image.Rectangle
+}
+
+func (f *frame) ColorModel() color.Model {
+	return f.img.ColorModel()
+}
+
+func (f *frame) Bounds() image.Rectangle {
+	return image.Rect(0, 0, f.rect.Dx(), f.rect.Dy())
+}
+
+func (f *frame) At(x, y int) color.Color {
+	if !(image.Point{x, y}.In(f.Bounds())) {
+		return color.NRGBA{}
+	}
+	return f.img.At(f.rect.Min.X+x, f.rect.Min.Y+y)
+}
@@ -0,0 +1,166 @@
+// Package sprite draws images (such as logos, icons and sprite sheets) onto any
+// demos.Displayer. Images are scaled to fit the display, either by averaging
+// all pixels that are covered by a display pixel (for photos and logos) or by
+// picking the nearest pixel (for pixel art). Transparent images are blended
+// with the content of the display.
+//
+// Images are usually stored in sRGB, while LEDs are linear: a LED at half the
+// PWM duty cycle is about half as bright. Therefore colors are converted from
+// sRGB to linear before they are drawn, unless Options.Linear is set.
+package sprite
+
+//go:generate go run gensrgb.go
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/aykevl/ledsgo"
+	"github.com/aykevl/ledsgo/demos"
+	"github.com/aykevl/ledsgo/draw"
+)
+
+// Filter selects how images are scaled.
+type Filter uint8
+
+const (
+	// Area averages all image pixels that are (partially) covered by a display
+	// pixel. This gives the best results when scaling down photos or logos.
+	Area Filter = iota
+
+	// Nearest picks the image pixel at the center of every display pixel,
+	// which keeps the hard edges of pixel art.
+	Nearest
+)
+
+// Options change how an image is drawn. The zero value uses the Area filter
+// and converts sRGB colors to linear colors.
+type Options struct {
+	Filter Filter
+
+	// Linear indicates that the image already contains linear colors, so no
+	// sRGB to linear conversion is done.
+	Linear bool
+}
+
+// SRGBToLinear converts an 8-bit sRGB color component to a linear value, as
+// can be sent to a LED.
+func SRGBToLinear(v uint8) uint8 {
+	return uint8((uint32(srgbToLinear[v]) + 0x80) / 0x101)
+}
+
+// Draw draws the image scaled to the rectangle dst of the display. Parts of the
+// rectangle outside of the display are not drawn. A nil options pointer is the
+// same as the zero value.
+func Draw(display demos.Displayer, dst image.Rectangle, src image.Image, options *Options) {
+	if options == nil {
+		options = &Options{}
+	}
+	srcBounds := src.Bounds()
+	if dst.Empty() || srcBounds.Empty() {
+		return
+	}
+	width, height := display.Size()
+	visible := dst.Intersect(image.Rect(0, 0, int(width), int(height)))
+	reader, canRead := display.(draw.Reader)
+	for y := visible.Min.Y; y < visible.Max.Y; y++ {
+		for x := visible.Min.X; x < visible.Max.X; x++ {
+			var c color.RGBA
+			if options.Filter == Nearest {
+				// Pick the pixel at the center of this display pixel.
+				sx := srcBounds.Min.X + ((x-dst.Min.X)*2+1)*srcBounds.Dx()/(dst.Dx()*2)
+				sy := srcBounds.Min.Y + ((y-dst.Min.Y)*2+1)*srcBounds.Dy()/(dst.Dy()*2)
+				c = sample(src, sx, sy, options.Linear)
+			} else {
+				c = average(src, srcBounds, dst, x, y, options.Linear)
+			}
+			switch {
+			case c.A == 0:
+				continue
+			case canRead:
+				c = ledsgo.Blend(reader.GetPixel(int16(x), int16(y)), c)
+			case c.A != 0xff:
+				// Blend with a black background.
+				c = ledsgo.ApplyAlpha(c, c.A)
+				c.A = 0xff
+			}
+			display.SetPixel(int16(x), int16(y), c)
+		}
+	}
+}
+
+// DrawAt draws the image at its original size with the top left corner at
+// (x, y).
+func DrawAt(display demos.Displayer, x, y int, src image.Image, options *Options) {
+	size := src.Bounds().Size()
+	Draw(display, image.Rect(x, y, x+size.X, y+size.Y), src, options)
+}
+
+// Return a single image pixel as a (non-premultiplied) color for the display.
+func sample(src image.Image, x, y int, linear bool) color.RGBA {
+	c := color.NRGBAModel.Convert(src.At(x, y)).(color.NRGBA)
+	if !linear {
+		c.R = SRGBToLinear(c.R)
+		c.G = SRGBToLinear(c.G)
+		c.B = SRGBToLinear(c.B)
+	}
+	return color.RGBA{c.R, c.G, c.B, c.A}
+}
+
+// Return the average color of all image pixels covered by the display pixel
+// (x, y), weighted by the area that is covered. The average is calculated on
+// linear colors, premultiplied by alpha, so that transparent pixels don't
+// darken the result.
+func average(src image.Image, srcBounds, dst image.Rectangle, x, y int, linear bool) color.RGBA {
+	// Work in units of 1/dst.Dx() image pixels horizontally and 1/dst.Dy()
+	// image pixels vertically, so that all boundaries are integers.
+	srcW, srcH := srcBounds.Dx(), srcBounds.Dy()
+	dstW, dstH := dst.Dx(), dst.Dy()
+	x0, x1 := (x-dst.Min.X)*srcW, (x-dst.Min.X+1)*srcW
+	y0, y1 := (y-dst.Min.Y)*srcH, (y-dst.Min.Y+1)*srcH
+	var r, g, b, a, total uint64
+	for sy := y0 / dstH; sy*dstH < y1; sy++ {
+		wy := overlap(sy*dstH, (sy+1)*dstH, y0, y1)
+		for sx := x0 / dstW; sx*dstW < x1; sx++ {
+			wx := overlap(sx*dstW, (sx+1)*dstW, x0, x1)
+			weight := uint64(wx * wy)
+			c := color.NRGBA64Model.Convert(src.At(srcBounds.Min.X+sx, srcBounds.Min.Y+sy)).(color.NRGBA64)
+			cr, cg, cb := uint64(c.R), uint64(c.G), uint64(c.B)
+			if !linear {
+				cr = uint64(srgbToLinear[c.R>>8])
+				cg = uint64(srgbToLinear[c.G>>8])
+				cb = uint64(srgbToLinear[c.B>>8])
+			}
+			alpha := uint64(c.A) * weight
+			r += cr * alpha
+			g += cg * alpha
+			b += cb * alpha
+			a += alpha
+			total += weight
+		}
+	}
+	if a == 0 {
+		return color.RGBA{}
+	}
+	// Divide by a instead of total to undo the premultiplication.
+	return color.RGBA{
+		R: uint8((r/a + 0x80) / 0x101),
+		G: uint8((g/a + 0x80) / 0x101),
+		B: uint8((b/a + 0x80) / 0x101),
+		A: uint8((a/total + 0x80) / 0x101),
+	}
+}
+
+// Return the length of the overlap of the ranges [a0, a1) and [b0, b1).
+func overlap(a0, a1, b0, b1 int) int {
+	if b0 > a0 {
+		a0 = b0
+	}
+	if b1 < a1 {
+		a1 = b1
+	}
+	if a1 < a0 {
+		return 0
+	}
+	return a1 - a0
+}
@@ -0,0 +1,254 @@
+package sprite
+
+import (
+	"image"
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/aykevl/ledsgo/draw"
+)
+
+// A display that can't read back its pixels.
+type testDisplay struct {
+	buf *draw.Buffer
+}
+
+func (d testDisplay) Size() (int16, int16) {
+	return d.buf.Size()
+}
+
+func (d testDisplay) SetPixel(x, y int16, c color.RGBA) {
+	d.buf.SetPixel(x, y, c)
+}
+
+func (d testDisplay) Display() error {
+	return nil
+}
+
+func TestSRGBToLinear(t *testing.T) {
+	for _, tc := range []struct {
+		in, out uint8
+	}{
+		{0, 0},
+		{10, 1},
+		{128, 55},
+		{188, 128},
+		{255, 255},
+	} {
+		if got := SRGBToLinear(tc.in); got != tc.out {
+			t.Errorf("SRGBToLinear(%d): expected %d, got %d", tc.in, tc.out, got)
+		}
+	}
+}
+
+func TestDrawArea(t *testing.T) {
+	// A 4x4 checkerboard of white and black pixels, scaled down to 2x2, is 50%
+	// gray in every pixel. In linear colors that is 127, not 55.
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			if (x+y)%2 == 0 {
+				img.Set(x, y, color.White)
+			} else {
+				img.Set(x, y, color.Black)
+			}
+		}
+	}
+	buf := draw.NewBuffer(2, 2)
+	Draw(buf, image.Rect(0, 0, 2, 2), img, nil)
+	for _, c := range buf.Pixels {
+		if c != (color.RGBA{127, 127, 127, 255}) {
+			t.Errorf("unexpected color: %v", c)
+		}
+	}
+
+	// Scaling 3 pixels to 2 pixels covers 1.5 pixels per display pixel.
+	img = image.NewNRGBA(image.Rect(0, 0, 3, 1))
+	img.Set(0, 0, color.NRGBA{255, 0, 0, 255})
+	img.Set(1, 0, color.NRGBA{0, 255, 0, 255})
+	img.Set(2, 0, color.NRGBA{0, 0, 255, 255})
+	buf = draw.NewBuffer(2, 1)
+	Draw(buf, image.Rect(0, 0, 2, 1), img, &Options{Linear: true})
+	if c := buf.Pixels[0]; c != (color.RGBA{170, 85, 0, 255}) {
+		t.Errorf("unexpected color at 0: %v", c)
+	}
+	if c := buf.Pixels[1]; c != (color.RGBA{0, 85, 170, 255}) {
+		t.Errorf("unexpected color at 1: %v", c)
+	}
+}
+
+func TestDrawTransparent(t *testing.T) {
+	// Fully transparent pixels must not darken the average.
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.NRGBA{200, 100, 0, 255})
+	buf := draw.NewBuffer(1, 1)
+	buf.Clear(color.RGBA{0, 0, 100, 255})
+	Draw(buf, image.Rect(0, 0, 1, 1), img, &Options{Linear: true})
+	// Half coverage: the color is blended at alpha 127 over the background.
+	if c := buf.Pixels[0]; c != (color.RGBA{100, 50, 50, 255}) {
+		t.Errorf("unexpected blended color: %v", c)
+	}
+
+	// Without read-back, transparent images are blended with black.
+	display := testDisplay{draw.NewBuffer(1, 1)}
+	Draw(display, image.Rect(0, 0, 1, 1), img, &Options{Linear: true})
+	if c := display.buf.Pixels[0]; c != (color.RGBA{99, 49, 0, 255}) {
+		t.Errorf("unexpected color without read-back: %v", c)
+	}
+
+	// Fully transparent pixels are not drawn at all.
+	display.buf.Clear(color.RGBA{1, 2, 3, 255})
+	DrawAt(display, 0, 0, image.NewNRGBA(image.Rect(0, 0, 1, 1)), nil)
+	if c := display.buf.Pixels[0]; c != (color.RGBA{1, 2, 3, 255}) {
+		t.Errorf("transparent pixel was drawn: %v", c)
+	}
+}
+
+func TestDrawNearest(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.NRGBA{255, 0, 0, 255})
+	img.Set(1, 0, color.NRGBA{0, 255, 0, 255})
+	img.Set(0, 1, color.NRGBA{0, 0, 255, 255})
+	img.Set(1, 1, color.NRGBA{128, 128, 128, 255})
+	buf := draw.NewBuffer(6, 6)
+	// Scale up 2x, partially outside of the display.
+	Draw(buf, image.Rect(2, 2, 6, 6), img, &Options{Filter: Nearest})
+	expected := map[image.Point]color.RGBA{
+		{1, 1}: {},
+		{2, 2}: {255, 0, 0, 255},
+		{3, 3}: {255, 0, 0, 255},
+		{4, 2}: {0, 255, 0, 255},
+		{2, 5}: {0, 0, 255, 255},
+		{5, 5}: {55, 55, 55, 255},
+	}
+	for p, c := range expected {
+		if got := buf.GetPixel(int16(p.X), int16(p.Y)); got != c {
+			t.Errorf("pixel %v: expected %v, got %v", p, c, got)
+		}
+	}
+
+	// Clipping must not panic.
+	Draw(testDisplay{buf}, image.Rect(-3, -3, 9, 9), img, &Options{Filter: Nearest})
+	Draw(testDisplay{buf}, image.Rect(-3, -3, 9, 9), img, nil)
+}
+
+func TestBitmap(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		colors []color.NRGBA // nil for (more than 256) unique colors
+		opaque bool
+		format Format
+		size   int
+	}{
+		{"two colors", []color.NRGBA{{255, 0, 0, 255}, {0, 0, 0, 0}}, false, FormatPaletted, (300 + 7) / 8},
+		{"three colors", []color.NRGBA{{255, 0, 0, 255}, {0, 255, 0, 255}, {0, 0, 255, 128}}, false, FormatPaletted, 300 * 2 / 8},
+		{"opaque", nil, true, FormatRGB, 300 * 3},
+		{"transparent", nil, false, FormatRGBA, 300 * 4},
+	} {
+		img := image.NewNRGBA(image.Rect(0, 0, 20, 15))
+		for y := 0; y < 15; y++ {
+			for x := 0; x < 20; x++ {
+				c := color.NRGBA{uint8(x * 10), uint8(y * 10), uint8(x * y), uint8(x + y*20)}
+				if tc.opaque {
+					c.A = 255
+				}
+				if tc.colors != nil {
+					c = tc.colors[(x*y+x)%len(tc.colors)]
+				}
+				img.SetNRGBA(x, y, c)
+			}
+		}
+		b := NewBitmap(img)
+		if b.Format != tc.format {
+			t.Errorf("%s: expected format %d, got %d", tc.name, tc.format, b.Format)
+		}
+		if len(b.Data) != tc.size {
+			t.Errorf("%s: expected %d bytes of data, got %d", tc.name, tc.size, len(b.Data))
+		}
+		if b.Bounds() != img.Bounds() {
+			t.Errorf("%s: unexpected bounds %v", tc.name, b.Bounds())
+		}
+		for y := 0; y < 15; y++ {
+			for x := 0; x < 20; x++ {
+				want := img.NRGBAAt(x, y)
+				if want.A == 0 {
+					want = color.NRGBA{}
+				}
+				if got := b.NRGBAAt(x, y); got != want {
+					t.Errorf("%s: pixel (%d, %d): expected %v, got %v", tc.name, x, y, want, got)
+				}
+			}
+		}
+	}
+}
+
+func TestSheet(t *testing.T) {
+	// Three frames of 2x1 pixels on a 4x2 image, numbered by their red value.
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			img.Set(x, y, color.NRGBA{uint8(y*2 + x/2), uint8(x % 2), 0, 255})
+		}
+	}
+	sheet := &Sheet{
+		Image:         img,
+		Width:         2,
+		Height:        1,
+		Frames:        3,
+		FrameDuration: 100 * time.Millisecond,
+	}
+	if n := sheet.Len(); n != 3 {
+		t.Errorf("expected 3 frames, got %d", n)
+	}
+	sheet.Frames = 0
+	if n := sheet.Len(); n != 4 {
+		t.Errorf("expected 4 frames in the image, got %d", n)
+	}
+	sheet.Frames = 3
+	for _, tc := range []struct {
+		ms    int64
+		index int
+	}{
+		{0, 0},
+		{99, 0},
+		{100, 1},
+		{250, 2},
+		{300, 0},
+		{1234, 0},
+	} {
+		now := time.Unix(0, tc.ms*int64(time.Millisecond))
+		if i := sheet.Index(now); i != tc.index {
+			t.Errorf("at %dms: expected frame %d, got %d", tc.ms, tc.index, i)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		frame := sheet.Frame(i)
+		if frame.Bounds() != image.Rect(0, 0, 2, 1) {
+			t.Errorf("frame %d: unexpected bounds %v", i, frame.Bounds())
+		}
+		for x := 0; x < 2; x++ {
+			c := color.NRGBAModel.Convert(frame.At(x, 0)).(color.NRGBA)
+			if c.R != uint8(i) || c.G != uint8(x) {
+				t.Errorf("frame %d: unexpected color at %d: %v", i, x, c)
+			}
+		}
+	}
+	buf := draw.NewBuffer(2, 1)
+	sheet.Draw(buf, image.Rect(0, 0, 2, 1), time.Unix(0, int64(200*time.Millisecond)), &Options{Filter: Nearest, Linear: true})
+	if c := buf.Pixels[1]; c != (color.RGBA{2, 1, 0, 255}) {
+		t.Errorf("unexpected color of drawn frame: %v", c)
+	}
+
+	// Sheets without a valid frame size have no frames.
+	for _, sheet := range []*Sheet{
+		{},
+		{Image: img, Width: 2},
+		{Image: img, Width: 5, Height: 1, Frames: 2},
+	} {
+		if sheet.Frame(0) != nil || (sheet.Width <= 0 || sheet.Height <= 0) && sheet.Len() != 0 {
+			t.Errorf("expected no frames for sheet %dx%d", sheet.Width, sheet.Height)
+		}
+		sheet.Draw(buf, image.Rect(0, 0, 2, 1), time.Now(), nil)
+	}
+}
@@ -0,0 +1,208 @@
+// Package terminal shows animations in a terminal, to preview them on a
+// computer without flashing a microcontroller. Colors are drawn with 24-bit
+// (truecolor) ANSI escape codes, which are supported by most modern terminal
+// emulators.
+package terminal
+
+import (
+	"errors"
+	"image/color"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/aykevl/ledsgo"
+	"github.com/aykevl/ledsgo/demos"
+	"github.com/aykevl/ledsgo/internal/gamma"
+)
+
+// ANSI escape codes used by the displays.
+const (
+	hideCursor  = "\x1b[?25l"
+	showCursor  = "\x1b[?25h"
+	clearScreen = "\x1b[2J"
+	cursorHome  = "\x1b[H"
+	resetColor  = "\x1b[0m"
+)
+
+var errInvalidFrameRate = errors.New("terminal: frame rate must be positive")
+
+// Lookup table to convert linear LED colors to the sRGB colors expected by a
+// terminal.
+var gammaTable = gamma.Table(gamma.Default)
+
+// Display is a demos.Displayer that draws in a terminal. Every character cell
+// shows two pixels above each other using the upper half block character (▀),
+// so a 32x32 display uses 32 columns and 16 lines.
+type Display struct {
+	w             io.Writer
+	width, height int16
+	pixels        []color.RGBA
+	buf           []byte
+	started       bool
+}
+
+// NewDisplay returns a new terminal display of the given size that writes to
+// w, which is usually os.Stdout.
+func NewDisplay(w io.Writer, width, height int16) *Display {
+	return &Display{
+		w:      w,
+		width:  width,
+		height: height,
+		pixels: make([]color.RGBA, int(width)*int(height)),
+	}
+}
+
+// Size returns the size of the display in pixels.
+func (d *Display) Size() (width, height int16) {
+	return d.width, d.height
+}
+
+// SetPixel sets a pixel in the buffer. It is shown on the next call to
+// Display.
+func (d *Display) SetPixel(x, y int16, c color.RGBA) {
+	if x < 0 || y < 0 || x >= d.width || y >= d.height {
+		return
+	}
+	d.pixels[int(y)*int(d.width)+int(x)] = c
+}
+
+// Display draws the buffer in the terminal, overwriting the previous frame.
+// The screen is cleared and the cursor hidden on the first call.
+func (d *Display) Display() error {
+	buf := d.buf[:0]
+	if !d.started {
+		buf = append(buf, hideCursor+clearScreen...)
+		d.started = true
+	}
+	buf = append(buf, cursorHome...)
+	for y := int16(0); y < d.height; y += 2 {
+		var fg, bg color.RGBA
+		first := true
+		for x := int16(0); x < d.width; x++ {
+			top := d.pixels[int(y)*int(d.width)+int(x)]
+			// Only send colors that changed since the previous cell.
+			if first || top != fg {
+				buf = appendColor(buf, 38, top)
+				fg = top
+			}
+			// The last line of a display with an odd height only has a top
+			// pixel, and keeps the default background of the terminal.
+			if y+1 < d.height {
+				bottom := d.pixels[int(y+1)*int(d.width)+int(x)]
+				if first || bottom != bg {
+					buf = appendColor(buf, 48, bottom)
+					bg = bottom
+				}
+			}
+			first = false
+			buf = append(buf, "▀"...)
+		}
+		buf = append(buf, resetColor+"\n"...)
+	}
+	d.buf = buf
+	_, err := d.w.Write(buf)
+	return err
+}
+
+// Close resets the colors and shows the cursor again. The display can still
+// be used afterwards.
+func (d *Display) Close() error {
+	d.started = false
+	_, err := io.WriteString(d.w, resetColor+showCursor)
+	return err
+}
+
+// StripDisplay is a demos.Displayer of one pixel high that draws a LED strip
+// as a line of blocks in the terminal, one block per LED.
+type StripDisplay struct {
+	Strip ledsgo.Strip
+	w     io.Writer
+	buf   []byte
+}
+
+// NewStripDisplay returns a display for the strip that writes to w, which is
+// usually os.Stdout.
+func NewStripDisplay(w io.Writer, strip ledsgo.Strip) *StripDisplay {
+	return &StripDisplay{
+		Strip: strip,
+		w:     w,
+	}
+}
+
+// Size returns the length of the strip and a height of 1.
+func (d *StripDisplay) Size() (width, height int16) {
+	return int16(len(d.Strip)), 1
+}
+
+// SetPixel sets the color of LED x. The y coordinate must be 0.
+func (d *StripDisplay) SetPixel(x, y int16, c color.RGBA) {
+	if x < 0 || y != 0 || int(x) >= len(d.Strip) {
+		return
+	}
+	d.Strip[x] = c
+}
+
+// Display draws the strip on the current line of the terminal, overwriting the
+// previous frame.
+func (d *StripDisplay) Display() error {
+	buf := append(d.buf[:0], hideCursor+"\r"...)
+	for i, c := range d.Strip {
+		if i == 0 || c != d.Strip[i-1] {
+			buf = appendColor(buf, 38, c)
+		}
+		buf = append(buf, "█"...)
+	}
+	buf = append(buf, resetColor...)
+	d.buf = buf
+	_, err := d.w.Write(buf)
+	return err
+}
+
+// Close resets the colors, shows the cursor again and moves to the next line.
+func (d *StripDisplay) Close() error {
+	_, err := io.WriteString(d.w, resetColor+showCursor+"\n")
+	return err
+}
+
+// Append the escape code to set the foreground (38) or background (48) color.
+func appendColor(buf []byte, kind int, c color.RGBA) []byte {
+	buf = append(buf, "\x1b["...)
+	buf = strconv.AppendInt(buf, int64(kind), 10)
+	buf = append(buf, ";2;"...)
+	buf = strconv.AppendInt(buf, int64(gammaTable[c.R]), 10)
+	buf = append(buf, ';')
+	buf = strconv.AppendInt(buf, int64(gammaTable[c.G]), 10)
+	buf = append(buf, ';')
+	buf = strconv.AppendInt(buf, int64(gammaTable[c.B]), 10)
+	return append(buf, 'm')
+}
+
+// Displayer is a demos.Displayer that can show the drawn frame, such as
+// Display and StripDisplay.
+type Displayer interface {
+	demos.Displayer
+	Display() error
+}
+
+// Loop draws the animation at the given frame rate until the done channel is
+// closed or the display returns an error. The frame rate must be positive.
+func Loop(display Displayer, animation func(demos.Displayer, time.Time), frameRate int, done <-chan struct{}) error {
+	if frameRate <= 0 {
+		return errInvalidFrameRate
+	}
+	ticker := time.NewTicker(time.Second / time.Duration(frameRate))
+	defer ticker.Stop()
+	now := time.Now()
+	for {
+		animation(display, now)
+		if err := display.Display(); err != nil {
+			return err
+		}
+		select {
+		case <-done:
+			return nil
+		case now = <-ticker.C:
+		}
+	}
+}
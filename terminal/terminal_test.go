@@ -0,0 +1,88 @@
+package terminal
+
+import (
+	"bytes"
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/aykevl/ledsgo"
+	"github.com/aykevl/ledsgo/demos"
+)
+
+func TestDisplay(t *testing.T) {
+	buf := &bytes.Buffer{}
+	display := NewDisplay(buf, 2, 3)
+	display.SetPixel(0, 0, color.RGBA{255, 0, 0, 255})
+	display.SetPixel(1, 0, color.RGBA{255, 0, 0, 255})
+	display.SetPixel(0, 1, color.RGBA{0, 55, 0, 255})
+	display.SetPixel(1, 1, color.RGBA{0, 0, 255, 255})
+	display.SetPixel(0, 2, color.RGBA{1, 1, 1, 255})
+	display.SetPixel(5, 5, color.RGBA{255, 255, 255, 255}) // ignored
+	if err := display.Display(); err != nil {
+		t.Fatal(err)
+	}
+	expected := "\x1b[?25l\x1b[2J\x1b[H" +
+		"\x1b[38;2;255;0;0m\x1b[48;2;0;127;0m▀\x1b[48;2;0;0;255m▀\x1b[0m\n" +
+		"\x1b[38;2;21;21;21m▀\x1b[38;2;0;0;0m▀\x1b[0m\n"
+	if got := buf.String(); got != expected {
+		t.Errorf("unexpected output:\nexpected: %q\ngot:      %q", expected, got)
+	}
+
+	// The next frame only moves the cursor back to the top.
+	buf.Reset()
+	display.Display()
+	if got := buf.String(); got[:3] != "\x1b[H" {
+		t.Errorf("unexpected start of second frame: %q", got)
+	}
+
+	buf.Reset()
+	display.Close()
+	if got := buf.String(); got != "\x1b[0m\x1b[?25h" {
+		t.Errorf("unexpected output of Close: %q", got)
+	}
+}
+
+func TestStripDisplay(t *testing.T) {
+	buf := &bytes.Buffer{}
+	display := NewStripDisplay(buf, make(ledsgo.Strip, 3))
+	if width, height := display.Size(); width != 3 || height != 1 {
+		t.Errorf("unexpected size: %dx%d", width, height)
+	}
+	display.SetPixel(0, 0, color.RGBA{255, 255, 255, 255})
+	display.SetPixel(1, 0, color.RGBA{255, 255, 255, 255})
+	display.SetPixel(2, 1, color.RGBA{255, 0, 0, 255}) // ignored
+	if err := display.Display(); err != nil {
+		t.Fatal(err)
+	}
+	expected := "\x1b[?25l\r\x1b[38;2;255;255;255m██\x1b[38;2;0;0;0m█\x1b[0m"
+	if got := buf.String(); got != expected {
+		t.Errorf("unexpected output:\nexpected: %q\ngot:      %q", expected, got)
+	}
+}
+
+func TestLoop(t *testing.T) {
+	buf := &bytes.Buffer{}
+	display := NewStripDisplay(buf, make(ledsgo.Strip, 1))
+	done := make(chan struct{})
+	frames := 0
+	animation := func(display demos.Displayer, now time.Time) {
+		frames++
+		if frames == 3 {
+			close(done)
+		}
+	}
+	if err := Loop(display, animation, 1000, done); err != nil {
+		t.Fatal(err)
+	}
+	if frames != 3 {
+		t.Errorf("expected 3 frames, got %d", frames)
+	}
+	if n := bytes.Count(buf.Bytes(), []byte("\r")); n != 3 {
+		t.Errorf("expected 3 displayed frames, got %d", n)
+	}
+
+	if err := Loop(display, animation, 0, done); err != errInvalidFrameRate {
+		t.Errorf("expected errInvalidFrameRate, got %v", err)
+	}
+}
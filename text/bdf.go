@@ -0,0 +1,193 @@
+package text
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var (
+	errBDFSyntax  = errors.New("text: invalid BDF font")
+	errBDFTooHigh = errors.New("text: BDF font is higher than 255 pixels")
+)
+
+// ParseBDF parses a font in the Glyph Bitmap Distribution Format (BDF), which
+// is used by X11 and many pixel font editors. Only the glyphs for the runes
+// first up to and including last are loaded.
+//
+// Glyphs are placed on the baseline of the font, so the glyphs of the
+// resulting font all have the same height. Pixels of a glyph outside of its
+// advance width (DWIDTH) are cut off.
+//
+// This is meant for host-side code generation: see genfonts.go for an
+// example that converts BDF fonts into Go source code.
+func ParseBDF(r io.Reader, first, last rune) (*Font, error) {
+	var (
+		font          = &Font{First: first}
+		ascent        = -1
+		descent       = -1
+		bboxHeight    = 0
+		bboxOffset    = 0
+		glyphs        = make(map[rune]*bdfGlyph)
+		current       *bdfGlyph
+		inBitmap      bool
+		maxRune       = first - 1
+		scanner       = bufio.NewScanner(r)
+		hasStartFont  = false
+		endedFontFile = false
+	)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		keyword := fields[0]
+		if inBitmap {
+			if keyword == "ENDCHAR" {
+				inBitmap = false
+				if current.encoding >= first && current.encoding <= last {
+					glyphs[current.encoding] = current
+					if current.encoding > maxRune {
+						maxRune = current.encoding
+					}
+				}
+				current = nil
+				continue
+			}
+			row, err := hex.DecodeString(keyword)
+			if err != nil {
+				return nil, errBDFSyntax
+			}
+			current.rows = append(current.rows, row)
+			continue
+		}
+		ints, err := parseInts(fields[1:])
+		switch keyword {
+		case "STARTFONT":
+			hasStartFont = true
+		case "FONT":
+			if len(fields) > 1 {
+				font.Name = fields[1]
+			}
+		case "FONTBOUNDINGBOX":
+			if err != nil || len(ints) != 4 {
+				return nil, errBDFSyntax
+			}
+			bboxHeight = ints[1]
+			bboxOffset = ints[3]
+		case "FONT_ASCENT":
+			if err != nil || len(ints) != 1 {
+				return nil, errBDFSyntax
+			}
+			ascent = ints[0]
+		case "FONT_DESCENT":
+			if err != nil || len(ints) != 1 {
+				return nil, errBDFSyntax
+			}
+			descent = ints[0]
+		case "STARTCHAR":
+			current = &bdfGlyph{encoding: -1}
+		case "ENCODING":
+			if current == nil || err != nil || len(ints) < 1 {
+				return nil, errBDFSyntax
+			}
+			current.encoding = rune(ints[0])
+		case "DWIDTH":
+			if current == nil || err != nil || len(ints) != 2 {
+				return nil, errBDFSyntax
+			}
+			current.advance = ints[0]
+		case "BBX":
+			if current == nil || err != nil || len(ints) != 4 {
+				return nil, errBDFSyntax
+			}
+			current.width, current.height, current.x, current.y = ints[0], ints[1], ints[2], ints[3]
+		case "BITMAP":
+			if current == nil {
+				return nil, errBDFSyntax
+			}
+			inBitmap = true
+		case "ENDFONT":
+			endedFontFile = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !hasStartFont || !endedFontFile || inBitmap {
+		return nil, errBDFSyntax
+	}
+	if ascent < 0 || descent < 0 {
+		// Not all fonts have these properties, use the bounding box instead.
+		ascent = bboxHeight + bboxOffset
+		descent = -bboxOffset
+	}
+	height := ascent + descent
+	if height > 255 {
+		return nil, errBDFTooHigh
+	}
+	font.Height = uint8(height)
+
+	font.Glyphs = make([]Glyph, maxRune-first+1)
+	for r, g := range glyphs {
+		font.Glyphs[r-first] = g.render(ascent, height)
+	}
+	return font, nil
+}
+
+// A glyph as stored in a BDF file.
+type bdfGlyph struct {
+	encoding      rune
+	advance       int
+	width, height int
+	x, y          int // offset of the bounding box from the origin
+	rows          [][]byte
+}
+
+// Render the glyph into the column based bitmap format of Glyph.
+func (g *bdfGlyph) render(ascent, height int) Glyph {
+	if g.advance <= 0 || g.advance > 255 {
+		return Glyph{}
+	}
+	bytesPerColumn := (height + 7) / 8
+	bitmap := make([]byte, g.advance*bytesPerColumn)
+	top := ascent - g.y - g.height // top row of the bounding box
+	for by, row := range g.rows {
+		if by >= g.height {
+			break
+		}
+		y := top + by
+		if y < 0 || y >= height {
+			continue
+		}
+		for bx := 0; bx < g.width && bx/8 < len(row); bx++ {
+			x := g.x + bx
+			if x < 0 || x >= g.advance {
+				continue
+			}
+			if row[bx/8]&(0x80>>(bx%8)) != 0 {
+				bitmap[x*bytesPerColumn+y/8] |= 1 << (y % 8)
+			}
+		}
+	}
+	return Glyph{
+		Width:  uint8(g.advance),
+		Bitmap: bitmap,
+	}
+}
+
+// Parse all fields as integers.
+func parseInts(fields []string) ([]int, error) {
+	ints := make([]int, len(fields))
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, err
+		}
+		ints[i] = n
+	}
+	return ints, nil
+}
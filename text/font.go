@@ -0,0 +1,251 @@
+// Package text renders text with bitmap fonts on any demos.Displayer, for
+// showing short messages, scores or IP addresses on small LED matrices. It
+// contains a few embedded pixel fonts (Font3x5, Font5x7 and Font8x8) and a
+// loader for BDF fonts, which can be used to embed other fonts.
+package text
+
+//go:generate go run genfonts.go
+
+import (
+	"image/color"
+	"time"
+
+	"github.com/aykevl/ledsgo"
+	"github.com/aykevl/ledsgo/demos"
+)
+
+// Font is a bitmap font with glyphs for a contiguous range of runes.
+type Font struct {
+	Name   string
+	Height uint8 // height of all glyphs in pixels
+	First  rune  // rune of the first glyph
+	Glyphs []Glyph
+}
+
+// Glyph is a single character of a font.
+type Glyph struct {
+	// Width is the number of pixels the glyph advances the cursor. It includes
+	// the space between this glyph and the next. A glyph with a width of zero
+	// is not present in the font.
+	Width uint8
+
+	// Bitmap contains the pixels of the glyph: Width columns from left to
+	// right, each column (Height+7)/8 bytes with the least significant bit of
+	// the first byte at the top.
+	Bitmap []byte
+}
+
+// Glyph returns the glyph for the given rune, or nil if the rune is not
+// present in the font.
+func (f *Font) Glyph(r rune) *Glyph {
+	index := int(r - f.First)
+	if r < f.First || index >= len(f.Glyphs) || f.Glyphs[index].Width == 0 {
+		return nil
+	}
+	return &f.Glyphs[index]
+}
+
+// Return the glyph to draw for the rune, which is a question mark for runes
+// that are not present in the font.
+func (f *Font) lookup(r rune) *Glyph {
+	if g := f.Glyph(r); g != nil {
+		return g
+	}
+	return f.Glyph('?')
+}
+
+// Width returns the width of the string in pixels.
+func (f *Font) Width(s string) int {
+	width := 0
+	for _, r := range s {
+		if g := f.lookup(r); g != nil {
+			width += int(g.Width)
+		}
+	}
+	return width
+}
+
+// Return the width of the string without the empty columns at the end of the
+// last glyph (which are usually the spacing to the next glyph). This is the
+// width used for alignment.
+func (f *Font) visibleWidth(s string) int {
+	width := 0
+	var last *Glyph
+	for _, r := range s {
+		if g := f.lookup(r); g != nil {
+			width += int(g.Width)
+			last = g
+		}
+	}
+	if last == nil {
+		return 0
+	}
+	bytesPerColumn := (int(f.Height) + 7) / 8
+	for col := int(last.Width) - 1; col >= 0; col-- {
+		for _, b := range last.Bitmap[col*bytesPerColumn : (col+1)*bytesPerColumn] {
+			if b != 0 {
+				return width
+			}
+		}
+		width--
+	}
+	return width
+}
+
+// Draw the glyph with the top left corner at (x, y). Pixels outside of the
+// display are skipped.
+func (f *Font) drawGlyph(display demos.Displayer, x, y int16, g *Glyph, c color.RGBA) {
+	width, height := display.Size()
+	bytesPerColumn := (int(f.Height) + 7) / 8
+	for col := 0; col < int(g.Width); col++ {
+		px := x + int16(col)
+		if px < 0 || px >= width {
+			continue
+		}
+		column := g.Bitmap[col*bytesPerColumn : (col+1)*bytesPerColumn]
+		for row := 0; row < int(f.Height); row++ {
+			py := y + int16(row)
+			if py < 0 || py >= height {
+				continue
+			}
+			if column[row/8]>>(row%8)&1 != 0 {
+				display.SetPixel(px, py, c)
+			}
+		}
+	}
+}
+
+// Align is the horizontal alignment of text.
+type Align uint8
+
+// Possible alignments.
+const (
+	AlignLeft Align = iota
+	AlignCenter
+	AlignRight
+)
+
+// Style describes how text is drawn: the font, the colors and the alignment.
+// Only the pixels of the glyphs are drawn, the background is left unmodified.
+type Style struct {
+	Font  *Font
+	Color color.RGBA
+
+	// Palette, when set, is used instead of Color: every glyph gets its own
+	// color, at position PaletteStart + i*PaletteStep in the palette for the
+	// i-th glyph of the string.
+	Palette      *ledsgo.Palette16
+	PaletteStart uint16
+	PaletteStep  uint16
+
+	Align Align // used by DrawAligned
+}
+
+// Width returns the width of the string in pixels.
+func (s *Style) Width(text string) int {
+	return s.Font.Width(text)
+}
+
+// Draw draws the string with the top left corner at (x, y) and returns the x
+// coordinate just after the string. Parts of the text outside of the display
+// are not drawn, so it can be used to draw text that is partially visible.
+func (s *Style) Draw(display demos.Displayer, x, y int16, text string) int16 {
+	width, _ := display.Size()
+	c := s.Color
+	position := s.PaletteStart
+	for _, r := range text {
+		g := s.Font.lookup(r)
+		if g == nil {
+			continue
+		}
+		if x < width && x+int16(g.Width) > 0 {
+			if s.Palette != nil {
+				c = s.Palette.ColorAt(position)
+			}
+			s.Font.drawGlyph(display, x, y, g, c)
+		}
+		position += s.PaletteStep
+		x += int16(g.Width)
+	}
+	return x
+}
+
+// DrawAligned draws the string at the given y coordinate, aligned within the
+// width of the display according to Align. The spacing after the last glyph
+// is not taken into account, so right aligned text touches the right edge.
+func (s *Style) DrawAligned(display demos.Displayer, y int16, text string) {
+	width, _ := display.Size()
+	x := int16(0)
+	switch s.Align {
+	case AlignCenter:
+		x = (width - int16(s.Font.visibleWidth(text))) / 2
+	case AlignRight:
+		x = width - int16(s.Font.visibleWidth(text))
+	}
+	s.Draw(display, x, y, text)
+}
+
+// Maximum scroll speed in pixels per second. Faster text can't be read anyway,
+// and limiting the speed avoids overflows.
+const maxScrollSpeed = 1 << 20
+
+// Scroll draws the string at the given y coordinate, scrolling from right to
+// left at the given speed in pixels per second. The text enters the display at
+// the right edge and starts again when it has left the display at the left
+// edge. The 'now' time determines the scroll position. With a speed of zero
+// or less, the text is drawn with DrawAligned instead. The speed is limited to
+// about a million pixels per second.
+func (s *Style) Scroll(display demos.Displayer, y int16, text string, now time.Time, speed int) {
+	if speed <= 0 {
+		s.DrawAligned(display, y, text)
+		return
+	}
+	if speed > maxScrollSpeed {
+		speed = maxScrollSpeed
+	}
+	width, _ := display.Size()
+	period := int64(s.Width(text)) + int64(width)
+
+	// Calculate the offset in pixels, without overflowing for times far from
+	// 1970: the seconds are reduced modulo the period first.
+	nanos := now.UnixNano()
+	seconds, fraction := nanos/int64(time.Second), nanos%int64(time.Second)
+	if fraction < 0 {
+		seconds--
+		fraction += int64(time.Second)
+	}
+	offset := (seconds%period*int64(speed) + fraction*int64(speed)/int64(time.Second)) % period
+	if offset < 0 {
+		offset += period
+	}
+
+	// Only draw the glyphs that are visible, so that the x coordinate always
+	// fits in an int16, even for very long text.
+	x := int64(width) - offset
+	style := *s
+	start, end := -1, len(text)
+	pos := x
+	for i, r := range text {
+		g := s.Font.lookup(r)
+		if g == nil {
+			continue
+		}
+		if pos >= int64(width) {
+			end = i
+			break
+		}
+		if start < 0 {
+			if pos+int64(g.Width) <= 0 {
+				// This glyph has already left the display.
+				style.PaletteStart += s.PaletteStep
+			} else {
+				start = i
+				x = pos
+			}
+		}
+		pos += int64(g.Width)
+	}
+	if start >= 0 {
+		style.Draw(display, int16(x), y, text[start:end])
+	}
+}
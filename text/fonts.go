@@ -0,0 +1,316 @@
+// Code generated by genfonts.go; DO NOT EDIT.
+
+package text
+
+// Font3x5 is a tiny font of 3x5 pixels per glyph. It only has uppercase
+// letters: lowercase letters are drawn as uppercase letters.
+var Font3x5 = &Font{
+	Name:   "-ledsgo-3x5-medium-r-normal--5-50-75-75-c-40-iso10646-1",
+	Height: 5,
+	First:  ' ',
+	Glyphs: []Glyph{
+		{4, []byte{0x00, 0x00, 0x00, 0x00}}, // ' '
+		{4, []byte{0x00, 0x17, 0x00, 0x00}}, // '!'
+		{4, []byte{0x03, 0x00, 0x03, 0x00}}, // '"'
+		{4, []byte{0x1f, 0x0a, 0x1f, 0x00}}, // '#'
+		{4, []byte{0x12, 0x1f, 0x09, 0x00}}, // '$'
+		{4, []byte{0x19, 0x04, 0x13, 0x00}}, // '%'
+		{4, []byte{0x0a, 0x15, 0x1a, 0x00}}, // '&'
+		{4, []byte{0x00, 0x03, 0x00, 0x00}}, // '\''
+		{4, []byte{0x00, 0x0e, 0x11, 0x00}}, // '('
+		{4, []byte{0x11, 0x0e, 0x00, 0x00}}, // ')'
+		{4, []byte{0x0a, 0x04, 0x0a, 0x00}}, // '*'
+		{4, []byte{0x04, 0x0e, 0x04, 0x00}}, // '+'
+		{4, []byte{0x10, 0x08, 0x00, 0x00}}, // ','
+		{4, []byte{0x04, 0x04, 0x04, 0x00}}, // '-'
+		{4, []byte{0x00, 0x10, 0x00, 0x00}}, // '.'
+		{4, []byte{0x18, 0x04, 0x03, 0x00}}, // '/'
+		{4, []byte{0x1f, 0x11, 0x1f, 0x00}}, // '0'
+		{4, []byte{0x12, 0x1f, 0x10, 0x00}}, // '1'
+		{4, []byte{0x19, 0x15, 0x12, 0x00}}, // '2'
+		{4, []byte{0x11, 0x15, 0x0a, 0x00}}, // '3'
+		{4, []byte{0x07, 0x04, 0x1f, 0x00}}, // '4'
+		{4, []byte{0x17, 0x15, 0x09, 0x00}}, // '5'
+		{4, []byte{0x1e, 0x15, 0x1d, 0x00}}, // '6'
+		{4, []byte{0x01, 0x1d, 0x03, 0x00}}, // '7'
+		{4, []byte{0x1f, 0x15, 0x1f, 0x00}}, // '8'
+		{4, []byte{0x17, 0x15, 0x0f, 0x00}}, // '9'
+		{4, []byte{0x00, 0x0a, 0x00, 0x00}}, // ':'
+		{4, []byte{0x10, 0x0a, 0x00, 0x00}}, // ';'
+		{4, []byte{0x04, 0x0a, 0x11, 0x00}}, // '<'
+		{4, []byte{0x0a, 0x0a, 0x0a, 0x00}}, // '='
+		{4, []byte{0x11, 0x0a, 0x04, 0x00}}, // '>'
+		{4, []byte{0x01, 0x15, 0x02, 0x00}}, // '?'
+		{4, []byte{0x0e, 0x15, 0x16, 0x00}}, // '@'
+		{4, []byte{0x1e, 0x05, 0x1e, 0x00}}, // 'A'
+		{4, []byte{0x1f, 0x15, 0x0a, 0x00}}, // 'B'
+		{4, []byte{0x0e, 0x11, 0x11, 0x00}}, // 'C'
+		{4, []byte{0x1f, 0x11, 0x0e, 0x00}}, // 'D'
+		{4, []byte{0x1f, 0x15, 0x11, 0x00}}, // 'E'
+		{4, []byte{0x1f, 0x05, 0x01, 0x00}}, // 'F'
+		{4, []byte{0x0e, 0x11, 0x1d, 0x00}}, // 'G'
+		{4, []byte{0x1f, 0x04, 0x1f, 0x00}}, // 'H'
+		{4, []byte{0x11, 0x1f, 0x11, 0x00}}, // 'I'
+		{4, []byte{0x08, 0x10, 0x0f, 0x00}}, // 'J'
+		{4, []byte{0x1f, 0x04, 0x1b, 0x00}}, // 'K'
+		{4, []byte{0x1f, 0x10, 0x10, 0x00}}, // 'L'
+		{4, []byte{0x1f, 0x06, 0x1f, 0x00}}, // 'M'
+		{4, []byte{0x1f, 0x01, 0x1e, 0x00}}, // 'N'
+		{4, []byte{0x0e, 0x11, 0x0e, 0x00}}, // 'O'
+		{4, []byte{0x1f, 0x05, 0x02, 0x00}}, // 'P'
+		{4, []byte{0x0e, 0x19, 0x16, 0x00}}, // 'Q'
+		{4, []byte{0x1f, 0x05, 0x1a, 0x00}}, // 'R'
+		{4, []byte{0x12, 0x15, 0x09, 0x00}}, // 'S'
+		{4, []byte{0x01, 0x1f, 0x01, 0x00}}, // 'T'
+		{4, []byte{0x1f, 0x10, 0x1f, 0x00}}, // 'U'
+		{4, []byte{0x0f, 0x10, 0x0f, 0x00}}, // 'V'
+		{4, []byte{0x1f, 0x0c, 0x1f, 0x00}}, // 'W'
+		{4, []byte{0x1b, 0x04, 0x1b, 0x00}}, // 'X'
+		{4, []byte{0x03, 0x1c, 0x03, 0x00}}, // 'Y'
+		{4, []byte{0x19, 0x15, 0x13, 0x00}}, // 'Z'
+		{4, []byte{0x1f, 0x11, 0x00, 0x00}}, // '['
+		{4, []byte{0x03, 0x04, 0x18, 0x00}}, // '\\'
+		{4, []byte{0x00, 0x11, 0x1f, 0x00}}, // ']'
+		{4, []byte{0x02, 0x01, 0x02, 0x00}}, // '^'
+		{4, []byte{0x10, 0x10, 0x10, 0x00}}, // '_'
+		{4, []byte{0x01, 0x02, 0x00, 0x00}}, // '`'
+		{4, []byte{0x1e, 0x05, 0x1e, 0x00}}, // 'a'
+		{4, []byte{0x1f, 0x15, 0x0a, 0x00}}, // 'b'
+		{4, []byte{0x0e, 0x11, 0x11, 0x00}}, // 'c'
+		{4, []byte{0x1f, 0x11, 0x0e, 0x00}}, // 'd'
+		{4, []byte{0x1f, 0x15, 0x11, 0x00}}, // 'e'
+		{4, []byte{0x1f, 0x05, 0x01, 0x00}}, // 'f'
+		{4, []byte{0x0e, 0x11, 0x1d, 0x00}}, // 'g'
+		{4, []byte{0x1f, 0x04, 0x1f, 0x00}}, // 'h'
+		{4, []byte{0x11, 0x1f, 0x11, 0x00}}, // 'i'
+		{4, []byte{0x08, 0x10, 0x0f, 0x00}}, // 'j'
+		{4, []byte{0x1f, 0x04, 0x1b, 0x00}}, // 'k'
+		{4, []byte{0x1f, 0x10, 0x10, 0x00}}, // 'l'
+		{4, []byte{0x1f, 0x06, 0x1f, 0x00}}, // 'm'
+		{4, []byte{0x1f, 0x01, 0x1e, 0x00}}, // 'n'
+		{4, []byte{0x0e, 0x11, 0x0e, 0x00}}, // 'o'
+		{4, []byte{0x1f, 0x05, 0x02, 0x00}}, // 'p'
+		{4, []byte{0x0e, 0x19, 0x16, 0x00}}, // 'q'
+		{4, []byte{0x1f, 0x05, 0x1a, 0x00}}, // 'r'
+		{4, []byte{0x12, 0x15, 0x09, 0x00}}, // 's'
+		{4, []byte{0x01, 0x1f, 0x01, 0x00}}, // 't'
+		{4, []byte{0x1f, 0x10, 0x1f, 0x00}}, // 'u'
+		{4, []byte{0x0f, 0x10, 0x0f, 0x00}}, // 'v'
+		{4, []byte{0x1f, 0x0c, 0x1f, 0x00}}, // 'w'
+		{4, []byte{0x1b, 0x04, 0x1b, 0x00}}, // 'x'
+		{4, []byte{0x03, 0x1c, 0x03, 0x00}}, // 'y'
+		{4, []byte{0x19, 0x15, 0x13, 0x00}}, // 'z'
+		{4, []byte{0x04, 0x1b, 0x11, 0x00}}, // '{'
+		{4, []byte{0x00, 0x1f, 0x00, 0x00}}, // '|'
+		{4, []byte{0x11, 0x1b, 0x04, 0x00}}, // '}'
+		{4, []byte{0x06, 0x04, 0x0c, 0x00}}, // '~'
+	},
+}
+
+// Font5x7 is a classic LCD-style font of 5x7 pixels per glyph.
+var Font5x7 = &Font{
+	Name:   "-ledsgo-5x7-medium-r-normal--7-70-75-75-c-60-iso10646-1",
+	Height: 7,
+	First:  ' ',
+	Glyphs: []Glyph{
+		{6, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00}}, // ' '
+		{6, []byte{0x00, 0x00, 0x5f, 0x00, 0x00, 0x00}}, // '!'
+		{6, []byte{0x00, 0x07, 0x00, 0x07, 0x00, 0x00}}, // '"'
+		{6, []byte{0x14, 0x7f, 0x14, 0x7f, 0x14, 0x00}}, // '#'
+		{6, []byte{0x24, 0x2a, 0x7f, 0x2a, 0x12, 0x00}}, // '$'
+		{6, []byte{0x23, 0x13, 0x08, 0x64, 0x62, 0x00}}, // '%'
+		{6, []byte{0x36, 0x49, 0x55, 0x22, 0x50, 0x00}}, // '&'
+		{6, []byte{0x00, 0x05, 0x03, 0x00, 0x00, 0x00}}, // '\''
+		{6, []byte{0x00, 0x1c, 0x22, 0x41, 0x00, 0x00}}, // '('
+		{6, []byte{0x00, 0x41, 0x22, 0x1c, 0x00, 0x00}}, // ')'
+		{6, []byte{0x14, 0x08, 0x3e, 0x08, 0x14, 0x00}}, // '*'
+		{6, []byte{0x08, 0x08, 0x3e, 0x08, 0x08, 0x00}}, // '+'
+		{6, []byte{0x00, 0x50, 0x30, 0x00, 0x00, 0x00}}, // ','
+		{6, []byte{0x08, 0x08, 0x08, 0x08, 0x08, 0x00}}, // '-'
+		{6, []byte{0x00, 0x60, 0x60, 0x00, 0x00, 0x00}}, // '.'
+		{6, []byte{0x20, 0x10, 0x08, 0x04, 0x02, 0x00}}, // '/'
+		{6, []byte{0x3e, 0x51, 0x49, 0x45, 0x3e, 0x00}}, // '0'
+		{6, []byte{0x00, 0x42, 0x7f, 0x40, 0x00, 0x00}}, // '1'
+		{6, []byte{0x42, 0x61, 0x51, 0x49, 0x46, 0x00}}, // '2'
+		{6, []byte{0x21, 0x41, 0x45, 0x4b, 0x31, 0x00}}, // '3'
+		{6, []byte{0x18, 0x14, 0x12, 0x7f, 0x10, 0x00}}, // '4'
+		{6, []byte{0x27, 0x45, 0x45, 0x45, 0x39, 0x00}}, // '5'
+		{6, []byte{0x3c, 0x4a, 0x49, 0x49, 0x30, 0x00}}, // '6'
+		{6, []byte{0x01, 0x71, 0x09, 0x05, 0x03, 0x00}}, // '7'
+		{6, []byte{0x36, 0x49, 0x49, 0x49, 0x36, 0x00}}, // '8'
+		{6, []byte{0x06, 0x49, 0x49, 0x29, 0x1e, 0x00}}, // '9'
+		{6, []byte{0x00, 0x36, 0x36, 0x00, 0x00, 0x00}}, // ':'
+		{6, []byte{0x00, 0x56, 0x36, 0x00, 0x00, 0x00}}, // ';'
+		{6, []byte{0x08, 0x14, 0x22, 0x41, 0x00, 0x00}}, // '<'
+		{6, []byte{0x14, 0x14, 0x14, 0x14, 0x14, 0x00}}, // '='
+		{6, []byte{0x00, 0x41, 0x22, 0x14, 0x08, 0x00}}, // '>'
+		{6, []byte{0x02, 0x01, 0x51, 0x09, 0x06, 0x00}}, // '?'
+		{6, []byte{0x32, 0x49, 0x79, 0x41, 0x3e, 0x00}}, // '@'
+		{6, []byte{0x7e, 0x11, 0x11, 0x11, 0x7e, 0x00}}, // 'A'
+		{6, []byte{0x7f, 0x49, 0x49, 0x49, 0x36, 0x00}}, // 'B'
+		{6, []byte{0x3e, 0x41, 0x41, 0x41, 0x22, 0x00}}, // 'C'
+		{6, []byte{0x7f, 0x41, 0x41, 0x22, 0x1c, 0x00}}, // 'D'
+		{6, []byte{0x7f, 0x49, 0x49, 0x49, 0x41, 0x00}}, // 'E'
+		{6, []byte{0x7f, 0x09, 0x09, 0x09, 0x01, 0x00}}, // 'F'
+		{6, []byte{0x3e, 0x41, 0x49, 0x49, 0x7a, 0x00}}, // 'G'
+		{6, []byte{0x7f, 0x08, 0x08, 0x08, 0x7f, 0x00}}, // 'H'
+		{6, []byte{0x00, 0x41, 0x7f, 0x41, 0x00, 0x00}}, // 'I'
+		{6, []byte{0x20, 0x40, 0x41, 0x3f, 0x01, 0x00}}, // 'J'
+		{6, []byte{0x7f, 0x08, 0x14, 0x22, 0x41, 0x00}}, // 'K'
+		{6, []byte{0x7f, 0x40, 0x40, 0x40, 0x40, 0x00}}, // 'L'
+		{6, []byte{0x7f, 0x02, 0x0c, 0x02, 0x7f, 0x00}}, // 'M'
+		{6, []byte{0x7f, 0x04, 0x08, 0x10, 0x7f, 0x00}}, // 'N'
+		{6, []byte{0x3e, 0x41, 0x41, 0x41, 0x3e, 0x00}}, // 'O'
+		{6, []byte{0x7f, 0x09, 0x09, 0x09, 0x06, 0x00}}, // 'P'
+		{6, []byte{0x3e, 0x41, 0x51, 0x21, 0x5e, 0x00}}, // 'Q'
+		{6, []byte{0x7f, 0x09, 0x19, 0x29, 0x46, 0x00}}, // 'R'
+		{6, []byte{0x46, 0x49, 0x49, 0x49, 0x31, 0x00}}, // 'S'
+		{6, []byte{0x01, 0x01, 0x7f, 0x01, 0x01, 0x00}}, // 'T'
+		{6, []byte{0x3f, 0x40, 0x40, 0x40, 0x3f, 0x00}}, // 'U'
+		{6, []byte{0x1f, 0x20, 0x40, 0x20, 0x1f, 0x00}}, // 'V'
+		{6, []byte{0x3f, 0x40, 0x38, 0x40, 0x3f, 0x00}}, // 'W'
+		{6, []byte{0x63, 0x14, 0x08, 0x14, 0x63, 0x00}}, // 'X'
+		{6, []byte{0x07, 0x08, 0x70, 0x08, 0x07, 0x00}}, // 'Y'
+		{6, []byte{0x61, 0x51, 0x49, 0x45, 0x43, 0x00}}, // 'Z'
+		{6, []byte{0x00, 0x7f, 0x41, 0x41, 0x00, 0x00}}, // '['
+		{6, []byte{0x02, 0x04, 0x08, 0x10, 0x20, 0x00}}, // '\\'
+		{6, []byte{0x00, 0x41, 0x41, 0x7f, 0x00, 0x00}}, // ']'
+		{6, []byte{0x04, 0x02, 0x01, 0x02, 0x04, 0x00}}, // '^'
+		{6, []byte{0x40, 0x40, 0x40, 0x40, 0x40, 0x00}}, // '_'
+		{6, []byte{0x00, 0x01, 0x02, 0x04, 0x00, 0x00}}, // '`'
+		{6, []byte{0x20, 0x54, 0x54, 0x54, 0x78, 0x00}}, // 'a'
+		{6, []byte{0x7f, 0x48, 0x44, 0x44, 0x38, 0x00}}, // 'b'
+		{6, []byte{0x38, 0x44, 0x44, 0x44, 0x20, 0x00}}, // 'c'
+		{6, []byte{0x38, 0x44, 0x44, 0x48, 0x7f, 0x00}}, // 'd'
+		{6, []byte{0x38, 0x54, 0x54, 0x54, 0x18, 0x00}}, // 'e'
+		{6, []byte{0x08, 0x7e, 0x09, 0x01, 0x02, 0x00}}, // 'f'
+		{6, []byte{0x0c, 0x52, 0x52, 0x52, 0x3e, 0x00}}, // 'g'
+		{6, []byte{0x7f, 0x08, 0x04, 0x04, 0x78, 0x00}}, // 'h'
+		{6, []byte{0x00, 0x44, 0x7d, 0x40, 0x00, 0x00}}, // 'i'
+		{6, []byte{0x20, 0x40, 0x44, 0x3d, 0x00, 0x00}}, // 'j'
+		{6, []byte{0x7f, 0x10, 0x28, 0x44, 0x00, 0x00}}, // 'k'
+		{6, []byte{0x00, 0x41, 0x7f, 0x40, 0x00, 0x00}}, // 'l'
+		{6, []byte{0x7c, 0x04, 0x18, 0x04, 0x78, 0x00}}, // 'm'
+		{6, []byte{0x7c, 0x08, 0x04, 0x04, 0x78, 0x00}}, // 'n'
+		{6, []byte{0x38, 0x44, 0x44, 0x44, 0x38, 0x00}}, // 'o'
+		{6, []byte{0x7c, 0x14, 0x14, 0x14, 0x08, 0x00}}, // 'p'
+		{6, []byte{0x08, 0x14, 0x14, 0x18, 0x7c, 0x00}}, // 'q'
+		{6, []byte{0x7c, 0x08, 0x04, 0x04, 0x08, 0x00}}, // 'r'
+		{6, []byte{0x48, 0x54, 0x54, 0x54, 0x20, 0x00}}, // 's'
+		{6, []byte{0x04, 0x3f, 0x44, 0x40, 0x20, 0x00}}, // 't'
+		{6, []byte{0x3c, 0x40, 0x40, 0x20, 0x7c, 0x00}}, // 'u'
+		{6, []byte{0x1c, 0x20, 0x40, 0x20, 0x1c, 0x00}}, // 'v'
+		{6, []byte{0x3c, 0x40, 0x30, 0x40, 0x3c, 0x00}}, // 'w'
+		{6, []byte{0x44, 0x28, 0x10, 0x28, 0x44, 0x00}}, // 'x'
+		{6, []byte{0x0c, 0x50, 0x50, 0x50, 0x3c, 0x00}}, // 'y'
+		{6, []byte{0x44, 0x64, 0x54, 0x4c, 0x44, 0x00}}, // 'z'
+		{6, []byte{0x00, 0x08, 0x36, 0x41, 0x00, 0x00}}, // '{'
+		{6, []byte{0x00, 0x00, 0x7f, 0x00, 0x00, 0x00}}, // '|'
+		{6, []byte{0x00, 0x41, 0x36, 0x08, 0x00, 0x00}}, // '}'
+		{6, []byte{0x08, 0x04, 0x08, 0x10, 0x08, 0x00}}, // '~'
+	},
+}
+
+// Font8x8 is a bold version of Font5x7, in glyphs of 8x8 pixels.
+var Font8x8 = &Font{
+	Name:   "-ledsgo-8x8-medium-r-normal--8-80-75-75-c-80-iso10646-1",
+	Height: 8,
+	First:  ' ',
+	Glyphs: []Glyph{
+		{8, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}}, // ' '
+		{8, []byte{0x00, 0x00, 0xbe, 0xbe, 0x00, 0x00, 0x00, 0x00}}, // '!'
+		{8, []byte{0x00, 0x0e, 0x0e, 0x0e, 0x0e, 0x00, 0x00, 0x00}}, // '"'
+		{8, []byte{0x28, 0xfe, 0xfe, 0xfe, 0xfe, 0x28, 0x00, 0x00}}, // '#'
+		{8, []byte{0x48, 0x5c, 0xfe, 0xfe, 0x74, 0x24, 0x00, 0x00}}, // '$'
+		{8, []byte{0x46, 0x66, 0x36, 0xd8, 0xcc, 0xc4, 0x00, 0x00}}, // '%'
+		{8, []byte{0x6c, 0xfe, 0xba, 0xee, 0xe4, 0xa0, 0x00, 0x00}}, // '&'
+		{8, []byte{0x00, 0x0a, 0x0e, 0x06, 0x00, 0x00, 0x00, 0x00}}, // '\''
+		{8, []byte{0x00, 0x38, 0x7c, 0xc6, 0x82, 0x00, 0x00, 0x00}}, // '('
+		{8, []byte{0x00, 0x82, 0xc6, 0x7c, 0x38, 0x00, 0x00, 0x00}}, // ')'
+		{8, []byte{0x28, 0x38, 0x7c, 0x7c, 0x38, 0x28, 0x00, 0x00}}, // '*'
+		{8, []byte{0x10, 0x10, 0x7c, 0x7c, 0x10, 0x10, 0x00, 0x00}}, // '+'
+		{8, []byte{0x00, 0xa0, 0xe0, 0x60, 0x00, 0x00, 0x00, 0x00}}, // ','
+		{8, []byte{0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x00, 0x00}}, // '-'
+		{8, []byte{0x00, 0xc0, 0xc0, 0xc0, 0x00, 0x00, 0x00, 0x00}}, // '.'
+		{8, []byte{0x40, 0x60, 0x30, 0x18, 0x0c, 0x04, 0x00, 0x00}}, // '/'
+		{8, []byte{0x7c, 0xfe, 0xb2, 0x9a, 0xfe, 0x7c, 0x00, 0x00}}, // '0'
+		{8, []byte{0x00, 0x84, 0xfe, 0xfe, 0x80, 0x00, 0x00, 0x00}}, // '1'
+		{8, []byte{0x84, 0xc6, 0xe2, 0xb2, 0x9e, 0x8c, 0x00, 0x00}}, // '2'
+		{8, []byte{0x42, 0xc2, 0x8a, 0x9e, 0xf6, 0x62, 0x00, 0x00}}, // '3'
+		{8, []byte{0x30, 0x38, 0x2c, 0xfe, 0xfe, 0x20, 0x00, 0x00}}, // '4'
+		{8, []byte{0x4e, 0xce, 0x8a, 0x8a, 0xfa, 0x72, 0x00, 0x00}}, // '5'
+		{8, []byte{0x78, 0xfc, 0x96, 0x92, 0xf2, 0x60, 0x00, 0x00}}, // '6'
+		{8, []byte{0x02, 0xe2, 0xf2, 0x1a, 0x0e, 0x06, 0x00, 0x00}}, // '7'
+		{8, []byte{0x6c, 0xfe, 0x92, 0x92, 0xfe, 0x6c, 0x00, 0x00}}, // '8'
+		{8, []byte{0x0c, 0x9e, 0x92, 0xd2, 0x7e, 0x3c, 0x00, 0x00}}, // '9'
+		{8, []byte{0x00, 0x6c, 0x6c, 0x6c, 0x00, 0x00, 0x00, 0x00}}, // ':'
+		{8, []byte{0x00, 0xac, 0xec, 0x6c, 0x00, 0x00, 0x00, 0x00}}, // ';'
+		{8, []byte{0x10, 0x38, 0x6c, 0xc6, 0x82, 0x00, 0x00, 0x00}}, // '<'
+		{8, []byte{0x28, 0x28, 0x28, 0x28, 0x28, 0x28, 0x00, 0x00}}, // '='
+		{8, []byte{0x00, 0x82, 0xc6, 0x6c, 0x38, 0x10, 0x00, 0x00}}, // '>'
+		{8, []byte{0x04, 0x06, 0xa2, 0xb2, 0x1e, 0x0c, 0x00, 0x00}}, // '?'
+		{8, []byte{0x64, 0xf6, 0xf2, 0xf2, 0xfe, 0x7c, 0x00, 0x00}}, // '@'
+		{8, []byte{0xfc, 0xfe, 0x22, 0x22, 0xfe, 0xfc, 0x00, 0x00}}, // 'A'
+		{8, []byte{0xfe, 0xfe, 0x92, 0x92, 0xfe, 0x6c, 0x00, 0x00}}, // 'B'
+		{8, []byte{0x7c, 0xfe, 0x82, 0x82, 0xc6, 0x44, 0x00, 0x00}}, // 'C'
+		{8, []byte{0xfe, 0xfe, 0x82, 0xc6, 0x7c, 0x38, 0x00, 0x00}}, // 'D'
+		{8, []byte{0xfe, 0xfe, 0x92, 0x92, 0x92, 0x82, 0x00, 0x00}}, // 'E'
+		{8, []byte{0xfe, 0xfe, 0x12, 0x12, 0x12, 0x02, 0x00, 0x00}}, // 'F'
+		{8, []byte{0x7c, 0xfe, 0x92, 0x92, 0xf6, 0xf4, 0x00, 0x00}}, // 'G'
+		{8, []byte{0xfe, 0xfe, 0x10, 0x10, 0xfe, 0xfe, 0x00, 0x00}}, // 'H'
+		{8, []byte{0x00, 0x82, 0xfe, 0xfe, 0x82, 0x00, 0x00, 0x00}}, // 'I'
+		{8, []byte{0x40, 0xc0, 0x82, 0xfe, 0x7e, 0x02, 0x00, 0x00}}, // 'J'
+		{8, []byte{0xfe, 0xfe, 0x38, 0x6c, 0xc6, 0x82, 0x00, 0x00}}, // 'K'
+		{8, []byte{0xfe, 0xfe, 0x80, 0x80, 0x80, 0x80, 0x00, 0x00}}, // 'L'
+		{8, []byte{0xfe, 0xfe, 0x1c, 0x1c, 0xfe, 0xfe, 0x00, 0x00}}, // 'M'
+		{8, []byte{0xfe, 0xfe, 0x18, 0x30, 0xfe, 0xfe, 0x00, 0x00}}, // 'N'
+		{8, []byte{0x7c, 0xfe, 0x82, 0x82, 0xfe, 0x7c, 0x00, 0x00}}, // 'O'
+		{8, []byte{0xfe, 0xfe, 0x12, 0x12, 0x1e, 0x0c, 0x00, 0x00}}, // 'P'
+		{8, []byte{0x7c, 0xfe, 0xa2, 0xe2, 0xfe, 0xbc, 0x00, 0x00}}, // 'Q'
+		{8, []byte{0xfe, 0xfe, 0x32, 0x72, 0xde, 0x8c, 0x00, 0x00}}, // 'R'
+		{8, []byte{0x8c, 0x9e, 0x92, 0x92, 0xf2, 0x62, 0x00, 0x00}}, // 'S'
+		{8, []byte{0x02, 0x02, 0xfe, 0xfe, 0x02, 0x02, 0x00, 0x00}}, // 'T'
+		{8, []byte{0x7e, 0xfe, 0x80, 0x80, 0xfe, 0x7e, 0x00, 0x00}}, // 'U'
+		{8, []byte{0x3e, 0x7e, 0xc0, 0xc0, 0x7e, 0x3e, 0x00, 0x00}}, // 'V'
+		{8, []byte{0x7e, 0xfe, 0xf0, 0xf0, 0xfe, 0x7e, 0x00, 0x00}}, // 'W'
+		{8, []byte{0xc6, 0xee, 0x38, 0x38, 0xee, 0xc6, 0x00, 0x00}}, // 'X'
+		{8, []byte{0x0e, 0x1e, 0xf0, 0xf0, 0x1e, 0x0e, 0x00, 0x00}}, // 'Y'
+		{8, []byte{0xc2, 0xe2, 0xb2, 0x9a, 0x8e, 0x86, 0x00, 0x00}}, // 'Z'
+		{8, []byte{0x00, 0xfe, 0xfe, 0x82, 0x82, 0x00, 0x00, 0x00}}, // '['
+		{8, []byte{0x04, 0x0c, 0x18, 0x30, 0x60, 0x40, 0x00, 0x00}}, // '\\'
+		{8, []byte{0x00, 0x82, 0x82, 0xfe, 0xfe, 0x00, 0x00, 0x00}}, // ']'
+		{8, []byte{0x08, 0x0c, 0x06, 0x06, 0x0c, 0x08, 0x00, 0x00}}, // '^'
+		{8, []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00, 0x00}}, // '_'
+		{8, []byte{0x00, 0x02, 0x06, 0x0c, 0x08, 0x00, 0x00, 0x00}}, // '`'
+		{8, []byte{0x40, 0xe8, 0xa8, 0xa8, 0xf8, 0xf0, 0x00, 0x00}}, // 'a'
+		{8, []byte{0xfe, 0xfe, 0x98, 0x88, 0xf8, 0x70, 0x00, 0x00}}, // 'b'
+		{8, []byte{0x70, 0xf8, 0x88, 0x88, 0xc8, 0x40, 0x00, 0x00}}, // 'c'
+		{8, []byte{0x70, 0xf8, 0x88, 0x98, 0xfe, 0xfe, 0x00, 0x00}}, // 'd'
+		{8, []byte{0x70, 0xf8, 0xa8, 0xa8, 0xb8, 0x30, 0x00, 0x00}}, // 'e'
+		{8, []byte{0x10, 0xfc, 0xfe, 0x12, 0x06, 0x04, 0x00, 0x00}}, // 'f'
+		{8, []byte{0x18, 0xbc, 0xa4, 0xa4, 0xfc, 0x7c, 0x00, 0x00}}, // 'g'
+		{8, []byte{0xfe, 0xfe, 0x18, 0x08, 0xf8, 0xf0, 0x00, 0x00}}, // 'h'
+		{8, []byte{0x00, 0x88, 0xfa, 0xfa, 0x80, 0x00, 0x00, 0x00}}, // 'i'
+		{8, []byte{0x40, 0xc0, 0x88, 0xfa, 0x7a, 0x00, 0x00, 0x00}}, // 'j'
+		{8, []byte{0xfe, 0xfe, 0x70, 0xd8, 0x88, 0x00, 0x00, 0x00}}, // 'k'
+		{8, []byte{0x00, 0x82, 0xfe, 0xfe, 0x80, 0x00, 0x00, 0x00}}, // 'l'
+		{8, []byte{0xf8, 0xf8, 0x38, 0x38, 0xf8, 0xf0, 0x00, 0x00}}, // 'm'
+		{8, []byte{0xf8, 0xf8, 0x18, 0x08, 0xf8, 0xf0, 0x00, 0x00}}, // 'n'
+		{8, []byte{0x70, 0xf8, 0x88, 0x88, 0xf8, 0x70, 0x00, 0x00}}, // 'o'
+		{8, []byte{0xf8, 0xf8, 0x28, 0x28, 0x38, 0x10, 0x00, 0x00}}, // 'p'
+		{8, []byte{0x10, 0x38, 0x28, 0x38, 0xf8, 0xf8, 0x00, 0x00}}, // 'q'
+		{8, []byte{0xf8, 0xf8, 0x18, 0x08, 0x18, 0x10, 0x00, 0x00}}, // 'r'
+		{8, []byte{0x90, 0xb8, 0xa8, 0xa8, 0xe8, 0x40, 0x00, 0x00}}, // 's'
+		{8, []byte{0x08, 0x7e, 0xfe, 0x88, 0xc0, 0x40, 0x00, 0x00}}, // 't'
+		{8, []byte{0x78, 0xf8, 0x80, 0xc0, 0xf8, 0xf8, 0x00, 0x00}}, // 'u'
+		{8, []byte{0x38, 0x78, 0xc0, 0xc0, 0x78, 0x38, 0x00, 0x00}}, // 'v'
+		{8, []byte{0x78, 0xf8, 0xe0, 0xe0, 0xf8, 0x78, 0x00, 0x00}}, // 'w'
+		{8, []byte{0x88, 0xd8, 0x70, 0x70, 0xd8, 0x88, 0x00, 0x00}}, // 'x'
+		{8, []byte{0x18, 0xb8, 0xa0, 0xa0, 0xf8, 0x78, 0x00, 0x00}}, // 'y'
+		{8, []byte{0x88, 0xc8, 0xe8, 0xb8, 0x98, 0x88, 0x00, 0x00}}, // 'z'
+		{8, []byte{0x00, 0x10, 0x7c, 0xee, 0x82, 0x00, 0x00, 0x00}}, // '{'
+		{8, []byte{0x00, 0x00, 0xfe, 0xfe, 0x00, 0x00, 0x00, 0x00}}, // '|'
+		{8, []byte{0x00, 0x82, 0xee, 0x7c, 0x10, 0x00, 0x00, 0x00}}, // '}'
+		{8, []byte{0x10, 0x18, 0x18, 0x30, 0x30, 0x10, 0x00, 0x00}}, // '~'
+	},
+}
@@ -0,0 +1,77 @@
+// +build none
+
+// This file is used in `go generate` to update fonts.go. It converts the BDF
+// fonts in the fonts directory to Go source code, so that they can be used
+// without a filesystem (for example on a microcontroller).
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	"github.com/aykevl/ledsgo/text"
+)
+
+var fonts = []struct {
+	name string
+	path string
+	doc  string
+}{
+	{"Font3x5", "fonts/3x5.bdf", "Font3x5 is a tiny font of 3x5 pixels per glyph. It only has uppercase\n// letters: lowercase letters are drawn as uppercase letters."},
+	{"Font5x7", "fonts/5x7.bdf", "Font5x7 is a classic LCD-style font of 5x7 pixels per glyph."},
+	{"Font8x8", "fonts/8x8.bdf", "Font8x8 is a bold version of Font5x7, in glyphs of 8x8 pixels."},
+}
+
+func main() {
+	buf := &bytes.Buffer{}
+	buf.WriteString("// Code generated by genfonts.go; DO NOT EDIT.\n\npackage text\n\n")
+	for _, f := range fonts {
+		if err := writeFont(buf, f.name, f.path, f.doc); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to convert %s: %v\n", f.path, err)
+			os.Exit(1)
+		}
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to format source:", err)
+		os.Exit(1)
+	}
+	err = ioutil.WriteFile("fonts.go", src, 0666)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to write fonts.go:", err)
+		os.Exit(1)
+	}
+}
+
+// writeFont loads the printable ASCII characters of the BDF font and writes
+// them to buf as a Go variable.
+func writeFont(buf *bytes.Buffer, name, path, doc string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	font, err := text.ParseBDF(f, ' ', '~')
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(buf, "// %s\nvar %s = &Font{\n", doc, name)
+	fmt.Fprintf(buf, "Name: %q,\nHeight: %d,\nFirst: %q,\nGlyphs: []Glyph{\n", font.Name, font.Height, font.First)
+	for i, g := range font.Glyphs {
+		fmt.Fprintf(buf, "{%d, []byte{", g.Width)
+		for j, b := range g.Bitmap {
+			if j != 0 {
+				buf.WriteString(", ")
+			}
+			fmt.Fprintf(buf, "0x%02x", b)
+		}
+		fmt.Fprintf(buf, "}}, // %s\n", strconv.QuoteRune(font.First+rune(i)))
+	}
+	buf.WriteString("},\n}\n\n")
+	return nil
+}
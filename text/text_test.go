@@ -0,0 +1,246 @@
+package text
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aykevl/ledsgo"
+	"github.com/aykevl/ledsgo/demos"
+)
+
+var _ demos.Displayer = (*testDisplay)(nil)
+
+// testDisplay is a displayer that stores pixels in memory. It panics on pixels
+// outside of the display, to check that all drawing is clipped.
+type testDisplay struct {
+	width, height int16
+	pixels        []color.RGBA
+}
+
+func newTestDisplay(width, height int16) *testDisplay {
+	return &testDisplay{width, height, make([]color.RGBA, int(width)*int(height))}
+}
+
+func (d *testDisplay) Size() (int16, int16) {
+	return d.width, d.height
+}
+
+func (d *testDisplay) SetPixel(x, y int16, c color.RGBA) {
+	if x < 0 || y < 0 || x >= d.width || y >= d.height {
+		panic("pixel out of bounds")
+	}
+	d.pixels[int(y)*int(d.width)+int(x)] = c
+}
+
+// String returns the display as ASCII art: '#' for set pixels and '.' for
+// black pixels.
+func (d *testDisplay) String() string {
+	var rows []string
+	for y := int16(0); y < d.height; y++ {
+		row := make([]byte, d.width)
+		for x := int16(0); x < d.width; x++ {
+			row[x] = '.'
+			if d.pixels[int(y)*int(d.width)+int(x)] != (color.RGBA{}) {
+				row[x] = '#'
+			}
+		}
+		rows = append(rows, string(row))
+	}
+	return strings.Join(rows, "\n")
+}
+
+var white = color.RGBA{255, 255, 255, 255}
+
+func TestEmbeddedFonts(t *testing.T) {
+	for _, font := range []*Font{Font3x5, Font5x7, Font8x8} {
+		if font.First != ' ' || len(font.Glyphs) != '~'-' '+1 {
+			t.Errorf("%s: unexpected glyph range", font.Name)
+		}
+		for i, g := range font.Glyphs {
+			if g.Width == 0 || len(g.Bitmap) != int(g.Width)*((int(font.Height)+7)/8) {
+				t.Errorf("%s: invalid glyph %q", font.Name, font.First+rune(i))
+			}
+		}
+	}
+	if Font5x7.Width("Hello") != 30 || Font3x5.Width("10.0.0.1") != 32 || Font8x8.Width("ab") != 16 {
+		t.Error("unexpected text width")
+	}
+}
+
+func TestDraw(t *testing.T) {
+	d := newTestDisplay(12, 7)
+	style := &Style{Font: Font5x7, Color: white}
+	x := style.Draw(d, 0, 0, "Hi")
+	if x != 12 {
+		t.Errorf("expected x=12 after string, got %d", x)
+	}
+	expected := strings.Join([]string{
+		"#...#...#...",
+		"#...#.......",
+		"#...#..##...",
+		"#####...#...",
+		"#...#...#...",
+		"#...#...#...",
+		"#...#..###..",
+	}, "\n")
+	if d.String() != expected {
+		t.Errorf("unexpected output:\n%s\nexpected:\n%s", d, expected)
+	}
+
+	// Missing runes are drawn as a question mark.
+	d = newTestDisplay(4, 5)
+	(&Style{Font: Font3x5, Color: white}).Draw(d, 0, 0, "é")
+	expected = strings.Join([]string{
+		"##..",
+		"..#.",
+		".#..",
+		"....",
+		".#..",
+	}, "\n")
+	if d.String() != expected {
+		t.Errorf("unexpected output for missing rune:\n%s", d)
+	}
+
+	// Clipping at all edges.
+	d = newTestDisplay(5, 5)
+	style.Draw(d, -3, -2, "WWW")
+	style.Draw(d, 3, 3, "WWW")
+}
+
+func TestAlign(t *testing.T) {
+	tests := []struct {
+		align    Align
+		expected string
+	}{
+		{AlignLeft, "###.###...."},
+		{AlignCenter, "..###.###.."},
+		{AlignRight, "....###.###"},
+	}
+	for _, tc := range tests {
+		d := newTestDisplay(11, 5)
+		style := &Style{Font: Font3x5, Color: white, Align: tc.align}
+		style.DrawAligned(d, 0, "TT")
+		if row := strings.Split(d.String(), "\n")[0]; row != tc.expected {
+			t.Errorf("align %d: expected %s, got %s", tc.align, tc.expected, row)
+		}
+	}
+}
+
+func TestPalette(t *testing.T) {
+	d := newTestDisplay(8, 5)
+	style := &Style{Font: Font3x5, Palette: &ledsgo.RainbowColors, PaletteStart: 0x1000, PaletteStep: 0x2000}
+	style.Draw(d, 0, 0, "II")
+	if c := d.pixels[0]; c != ledsgo.RainbowColors[1] {
+		t.Errorf("unexpected color of first glyph: %v", c)
+	}
+	if c := d.pixels[4]; c != ledsgo.RainbowColors[3] {
+		t.Errorf("unexpected color of second glyph: %v", c)
+	}
+}
+
+func TestScroll(t *testing.T) {
+	style := &Style{Font: Font3x5, Color: white}
+	start := time.Unix(0, 0)
+	// The text is 8 pixels wide and the display 10, so the scroll period is 18
+	// pixels. At 10 pixels per second, the text starts at the right edge.
+	tests := []struct {
+		t        time.Duration
+		expected string
+	}{
+		{0, ".........."},
+		{300 * time.Millisecond, ".......###"},
+		{1 * time.Second, "###.###..."},
+		{1500 * time.Millisecond, "##........"},
+		{1800 * time.Millisecond, ".........."},
+		{2100 * time.Millisecond, ".......###"},
+	}
+	for _, tc := range tests {
+		d := newTestDisplay(10, 5)
+		style.Scroll(d, 0, "TT", start.Add(tc.t), 10)
+		if row := strings.Split(d.String(), "\n")[0]; row != tc.expected {
+			t.Errorf("t=%v: expected %s, got %s", tc.t, tc.expected, row)
+		}
+	}
+
+	// Very high speeds, dates far from 1970 and text that is wider than an
+	// int16 must not panic or overflow. The text is 40000 pixels wide, so
+	// after 4000.1 seconds the last T starts at x=1.
+	long := strings.Repeat("T.", 5000)
+	d := newTestDisplay(10, 5)
+	style.Scroll(d, 0, long, start.Add(4000*time.Second+100*time.Millisecond), 10)
+	if row := strings.Split(d.String(), "\n")[0]; row != ".###......" {
+		t.Errorf("long text: got %s", row)
+	}
+	style.Scroll(newTestDisplay(10, 5), 0, "TT", time.Date(2200, 1, 1, 0, 0, 0, 0, time.UTC), 1e12)
+	style.Scroll(newTestDisplay(10, 5), 0, long, time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC), 1e9+1)
+}
+
+func TestParseBDF(t *testing.T) {
+	// A proportional font with a descender and a glyph that is not in the
+	// requested range.
+	const bdf = `STARTFONT 2.1
+FONT test
+FONTBOUNDINGBOX 3 4 0 -1
+STARTPROPERTIES 2
+FONT_ASCENT 3
+FONT_DESCENT 1
+ENDPROPERTIES
+CHARS 3
+STARTCHAR i
+ENCODING 105
+DWIDTH 2 0
+BBX 1 3 0 0
+BITMAP
+80
+00
+80
+ENDCHAR
+STARTCHAR j
+ENCODING 106
+DWIDTH 3 0
+BBX 2 4 0 -1
+BITMAP
+40
+00
+40
+80
+ENDCHAR
+STARTCHAR A
+ENCODING 65
+DWIDTH 4 0
+BBX 3 3 0 0
+BITMAP
+E0
+A0
+E0
+ENDCHAR
+ENDFONT
+`
+	font, err := ParseBDF(strings.NewReader(bdf), 'a', 'z')
+	if err != nil {
+		t.Fatal("could not parse font:", err)
+	}
+	if font.Name != "test" || font.Height != 4 || font.First != 'a' || len(font.Glyphs) != 'j'-'a'+1 {
+		t.Fatalf("unexpected font: %+v", font)
+	}
+	if font.Glyph('A') != nil || font.Glyph('a') != nil {
+		t.Error("expected glyphs outside of the range or not in the font to be missing")
+	}
+	d := newTestDisplay(5, 4)
+	(&Style{Font: font, Color: white}).Draw(d, 0, 0, "ij")
+	expected := strings.Join([]string{
+		"#..#.",
+		".....",
+		"#..#.",
+		"..#..",
+	}, "\n")
+	if d.String() != expected {
+		t.Errorf("unexpected output:\n%s\nexpected:\n%s", d, expected)
+	}
+
+	if _, err := ParseBDF(strings.NewReader("STARTFONT 2.1\nBBX 1 2 3 4\n"), 'a', 'z'); err == nil {
+		t.Error("expected error for invalid font")
+	}
+}
@@ -0,0 +1,96 @@
+package ledsgo
+
+// This file implements value noise: every lattice point gets a pseudo-random
+// value and the values in between are interpolated with a smoothstep curve.
+// Value noise looks blockier than simplex noise, but it is much cheaper to
+// calculate which makes it a good fit for slow 8-bit microcontrollers.
+//
+// All inputs are 20.12 fixed-point values, just like the simplex noise
+// functions. The lattice repeats every 256 units.
+
+// Return the pseudo-random 16-bit value for a given hash.
+func valueAt(hash uint8) int32 {
+	return int32(perm[hash])<<8 | int32(perm[hash^0x55])
+}
+
+// Calculate the smoothstep function 3t²-2t³ for a .12 input.
+func smoothstep12(t int32) int32 {
+	return ((t * t) >> 12) * (3<<12 - 2*t) >> 12 // .12
+}
+
+// Linear interpolation between a and b, where t is a .12 fixed-point value.
+func lerp12(a, b, t int32) int32 {
+	return a + ((b-a)*t)>>12
+}
+
+// 1D value noise.
+//
+// The x input is a 20.12 fixed-point value. The result covers the full range of
+// a uint16, averaging around 32768.
+func ValueNoise1(x uint32) uint16 {
+	i := x >> 12
+	s := smoothstep12(int32(x & 0xfff)) // .12
+
+	v0 := valueAt(perm[i&0xff])
+	v1 := valueAt(perm[(i+1)&0xff])
+	return uint16(lerp12(v0, v1, s))
+}
+
+// 2D value noise.
+//
+// The x and y inputs are 20.12 fixed-point values. The result covers the full
+// range of a uint16, averaging around 32768.
+func ValueNoise2(x, y uint32) uint16 {
+	i := x >> 12
+	j := y >> 12
+	sx := smoothstep12(int32(x & 0xfff)) // .12
+	sy := smoothstep12(int32(y & 0xfff)) // .12
+
+	h0 := uint32(perm[j&0xff])
+	h1 := uint32(perm[(j+1)&0xff])
+	v00 := valueAt(perm[(i+h0)&0xff])
+	v10 := valueAt(perm[(i+1+h0)&0xff])
+	v01 := valueAt(perm[(i+h1)&0xff])
+	v11 := valueAt(perm[(i+1+h1)&0xff])
+
+	v0 := lerp12(v00, v10, sx)
+	v1 := lerp12(v01, v11, sx)
+	return uint16(lerp12(v0, v1, sy))
+}
+
+// 3D value noise.
+//
+// The x, y and z inputs are 20.12 fixed-point values. The result covers the
+// full range of a uint16, averaging around 32768.
+func ValueNoise3(x, y, z uint32) uint16 {
+	i := x >> 12
+	j := y >> 12
+	k := z >> 12
+	sx := smoothstep12(int32(x & 0xfff)) // .12
+	sy := smoothstep12(int32(y & 0xfff)) // .12
+	sz := smoothstep12(int32(z & 0xfff)) // .12
+
+	k0 := uint32(perm[k&0xff])
+	k1 := uint32(perm[(k+1)&0xff])
+	h00 := uint32(perm[(j+k0)&0xff])
+	h10 := uint32(perm[(j+1+k0)&0xff])
+	h01 := uint32(perm[(j+k1)&0xff])
+	h11 := uint32(perm[(j+1+k1)&0xff])
+
+	v000 := valueAt(perm[(i+h00)&0xff])
+	v100 := valueAt(perm[(i+1+h00)&0xff])
+	v010 := valueAt(perm[(i+h10)&0xff])
+	v110 := valueAt(perm[(i+1+h10)&0xff])
+	v001 := valueAt(perm[(i+h01)&0xff])
+	v101 := valueAt(perm[(i+1+h01)&0xff])
+	v011 := valueAt(perm[(i+h11)&0xff])
+	v111 := valueAt(perm[(i+1+h11)&0xff])
+
+	v00 := lerp12(v000, v100, sx)
+	v10 := lerp12(v010, v110, sx)
+	v01 := lerp12(v001, v101, sx)
+	v11 := lerp12(v011, v111, sx)
+	v0 := lerp12(v00, v10, sy)
+	v1 := lerp12(v01, v11, sy)
+	return uint16(lerp12(v0, v1, sz))
+}
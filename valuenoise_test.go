@@ -0,0 +1,105 @@
+package ledsgo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Floating point reference implementation of value noise, using the same
+// lattice values as the fixed-point implementation. The hash function returns
+// the lattice value at the given integer coordinate.
+func valueNoiseFloat(pos []float64, hash func(cell []uint32) float64) float64 {
+	cell := make([]uint32, len(pos))
+	var interpolate func(dim int) float64
+	interpolate = func(dim int) float64 {
+		if dim < 0 {
+			return hash(cell)
+		}
+		i := math.Floor(pos[dim])
+		f := pos[dim] - i
+		s := f * f * (3 - 2*f)
+		cell[dim] = uint32(int64(i))
+		v0 := interpolate(dim - 1)
+		cell[dim] = uint32(int64(i)) + 1
+		v1 := interpolate(dim - 1)
+		return v0 + (v1-v0)*s
+	}
+	return interpolate(len(pos) - 1)
+}
+
+func testValueNoise(t *testing.T, dims, numTests int, hash func(cell []uint32) float64, noise func(pos []uint32) uint16) {
+	r := rand.NewSource(0)
+	pos := make([]uint32, dims)
+	posf := make([]float64, dims)
+	rangesum := 0.0
+	diffsum := 0.0
+	diffmax := 0.0
+	diffmin := 0.0
+	for n := 0; n < numTests; n++ {
+		for i := range pos {
+			pos[i] = uint32(r.Int63())
+			posf[i] = float64(pos[i]) / 0x1000
+		}
+		n1 := valueNoiseFloat(posf, hash)
+		n2 := float64(noise(pos))
+		rangesum += n2
+		diff := (n1 - n2) / 0x10000
+		diffsum += math.Abs(diff)
+		if diff > diffmax {
+			diffmax = diff
+		}
+		if diff < diffmin {
+			diffmin = diff
+		}
+	}
+	rangeavg := rangesum / float64(numTests) / 0x10000
+	diffavg := diffsum / float64(numTests)
+	t.Logf("number of tests: %d", numTests)
+	t.Logf("range: avg %2.6f", rangeavg)
+	t.Logf("diff:  avg %+2.6f max %+2.6f min %+2.6f", diffavg, diffmax, diffmin)
+	if diffavg >= 0.0003 {
+		t.Errorf("diff avg between float and fixed-point is too big: %f", diffavg)
+	}
+	if diffmax > 0.003 {
+		t.Errorf("diff max is too high: %f", diffmax)
+	}
+	if diffmin < -0.003 {
+		t.Errorf("diff min is too low: %f", diffmin)
+	}
+	if rangeavg < 0.45 || rangeavg > 0.55 {
+		t.Errorf("average is not close to the middle: %f", rangeavg)
+	}
+}
+
+func TestValueNoise1(t *testing.T) {
+	testValueNoise(t, 1, 1000000, func(cell []uint32) float64 {
+		return float64(valueAt(perm[cell[0]&0xff]))
+	}, func(pos []uint32) uint16 {
+		return ValueNoise1(pos[0])
+	})
+}
+
+func TestValueNoise2(t *testing.T) {
+	testValueNoise(t, 2, 1000000, func(cell []uint32) float64 {
+		return float64(valueAt(perm[(cell[0]+uint32(perm[cell[1]&0xff]))&0xff]))
+	}, func(pos []uint32) uint16 {
+		return ValueNoise2(pos[0], pos[1])
+	})
+}
+
+func TestValueNoise3(t *testing.T) {
+	testValueNoise(t, 3, 1000000, func(cell []uint32) float64 {
+		return float64(valueAt(perm[(cell[0]+uint32(perm[(cell[1]+uint32(perm[cell[2]&0xff]))&0xff]))&0xff]))
+	}, func(pos []uint32) uint16 {
+		return ValueNoise3(pos[0], pos[1], pos[2])
+	})
+}
+
+func BenchmarkValueNoise2(b *testing.B) {
+	var r uint16
+	for n := 0; n < b.N; n++ {
+		r = ValueNoise2(uint32(n), uint32(n))
+	}
+	resultUint16 = r
+}
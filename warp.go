@@ -0,0 +1,109 @@
+package ledsgo
+
+// This file implements domain warping: the input coordinates of a noise
+// function are displaced by the output of other noise functions before the
+// noise is calculated. This results in organic, swirling patterns such as
+// marble or the blobs in a lava lamp.
+//
+// The implementation follows the approach described by Inigo Quilez:
+// https://iquilezles.org/articles/warp/
+// Every noise value used here is fractal noise (fBm): a sum of a number of
+// octaves of simplex noise, each with double the frequency and half the
+// amplitude of the previous one.
+//
+// All inputs are 20.12 fixed-point values, just like the simplex noise
+// functions.
+
+// Offsets (in 20.12 format) added to the input coordinates to get independent
+// noise values for each warp direction.
+const (
+	warpOffset1 = 0x1000 * 52 / 10  // .12: 5.2
+	warpOffset2 = 0x1000 * 13 / 10  // .12: 1.3
+	warpOffset3 = 0x1000 * 83 / 10  // .12: 8.3
+	warpOffset4 = 0x1000 * 28 / 10  // .12: 2.8
+	warpOffset5 = 0x1000 * 117 / 10 // .12: 11.7
+	warpOffset6 = 0x1000 * 46 / 10  // .12: 4.6
+)
+
+// Maximum number of octaves. Higher octaves would not add any detail anyway,
+// and limiting it avoids integer overflows.
+const warpMaxOctaves = 8
+
+// Return the 2D fractal noise at the given position as a signed .15 value.
+func fbm2(x, y uint32, octaves uint8) int32 {
+	if octaves == 0 {
+		octaves = 1
+	} else if octaves > warpMaxOctaves {
+		octaves = warpMaxOctaves
+	}
+	var sum int32 // .15
+	for o := uint8(0); o < octaves; o++ {
+		n := int32(Noise2(x<<o, y<<o)) - 0x8000 // .15
+		sum += n << (octaves - 1 - o)
+	}
+	return sum / (1<<octaves - 1) // divide by the sum of all amplitudes
+}
+
+// Return the 3D fractal noise at the given position as a signed .15 value.
+func fbm3(x, y, z uint32, octaves uint8) int32 {
+	if octaves == 0 {
+		octaves = 1
+	} else if octaves > warpMaxOctaves {
+		octaves = warpMaxOctaves
+	}
+	var sum int32 // .15
+	for o := uint8(0); o < octaves; o++ {
+		n := int32(Noise3(x<<o, y<<o, z<<o)) - 0x8000 // .15
+		sum += n << (octaves - 1 - o)
+	}
+	return sum / (1<<octaves - 1) // divide by the sum of all amplitudes
+}
+
+// Convert the signed .15 noise value n to a .12 displacement, by multiplying it
+// with the 8.8 strength.
+func warpOffset(n int32, strength uint16) uint32 {
+	return uint32(((n >> 3) * int32(strength)) >> 8) // .12 * .8 = .20 >> 8 = .12
+}
+
+// WarpedNoise2 returns 2D domain-warped noise. The input position is first
+// displaced by a 2D fractal noise value and then used to calculate another
+// fractal noise value.
+//
+// The x and y inputs are 20.12 fixed-point values. The strength is an 8.8
+// fixed-point value that indicates the maximum displacement in lattice units:
+// 0 means no warping at all, 1.0 (0x100) results in clearly visible swirls and
+// larger values quickly become chaotic unless the input coordinates are spaced
+// closely together. The number of octaves determines the amount of detail, and
+// also the time needed to calculate it: each octave needs three calls to
+// Noise2. It should usually be in the range 1-5, 0 is treated as 1 and values
+// above 8 are treated as 8.
+//
+// The result covers the full range of a uint16, averaging around 32768. Note
+// that fractal noise with multiple octaves tends to stay closer to the center
+// than plain simplex noise.
+func WarpedNoise2(x, y uint32, strength uint16, octaves uint8) uint16 {
+	qx := fbm2(x, y, octaves)
+	qy := fbm2(x+warpOffset1, y+warpOffset2, octaves)
+	x += warpOffset(qx, strength)
+	y += warpOffset(qy, strength)
+	return uint16(fbm2(x, y, octaves) + 0x8000)
+}
+
+// WarpedNoise3 returns 3D domain-warped noise. See WarpedNoise2 for details.
+// Each octave needs four calls to Noise3.
+//
+// A common use is to use the z coordinate as time, which results in slowly
+// moving and swirling patterns.
+//
+// The x, y and z inputs are 20.12 fixed-point values. The strength is an 8.8
+// fixed-point value. The result covers the full range of a uint16, averaging
+// around 32768.
+func WarpedNoise3(x, y, z uint32, strength uint16, octaves uint8) uint16 {
+	qx := fbm3(x, y, z, octaves)
+	qy := fbm3(x+warpOffset1, y+warpOffset2, z+warpOffset3, octaves)
+	qz := fbm3(x+warpOffset4, y+warpOffset5, z+warpOffset6, octaves)
+	x += warpOffset(qx, strength)
+	y += warpOffset(qy, strength)
+	z += warpOffset(qz, strength)
+	return uint16(fbm3(x, y, z, octaves) + 0x8000)
+}
@@ -0,0 +1,103 @@
+package ledsgo
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestWarpedNoiseNoWarp(t *testing.T) {
+	// Without warping and with a single octave, warped noise is the same as
+	// plain simplex noise.
+	r := rand.NewSource(0)
+	for n := 0; n < 10000; n++ {
+		x := uint32(r.Int63())
+		y := uint32(r.Int63())
+		z := uint32(r.Int63())
+		if v1, v2 := WarpedNoise2(x, y, 0, 1), Noise2(x, y); v1 != v2 {
+			t.Fatalf("WarpedNoise2(%d, %d, 0, 1) = %d, expected %d", x, y, v1, v2)
+		}
+		if v1, v2 := WarpedNoise3(x, y, z, 0, 1), Noise3(x, y, z); v1 != v2 {
+			t.Fatalf("WarpedNoise3(%d, %d, %d, 0, 1) = %d, expected %d", x, y, z, v1, v2)
+		}
+	}
+}
+
+func TestWarpedNoise2(t *testing.T) {
+	r := rand.NewSource(0)
+	const numTests = 100000
+	var rangemin, rangemax uint16 = 0xffff, 0
+	rangesum := 0
+	changed := 0
+	for n := 0; n < numTests; n++ {
+		x := uint32(r.Int63()) >> 4 // keep the coordinates in a reasonable range
+		y := uint32(r.Int63()) >> 4
+		v := WarpedNoise2(x, y, 0x400, 3)
+		if v != WarpedNoise2(x, y, 0, 3) {
+			changed++
+		}
+		rangesum += int(v)
+		if v < rangemin {
+			rangemin = v
+		}
+		if v > rangemax {
+			rangemax = v
+		}
+	}
+	rangeavg := rangesum / numTests
+	t.Logf("number of tests: %d", numTests)
+	t.Logf("range: avg %d max %d min %d", rangeavg, rangemax, rangemin)
+	if rangemin > 0x4000 || rangemax < 0xc000 {
+		t.Errorf("WarpedNoise2 covers too little of the uint16 range: %d..%d", rangemin, rangemax)
+	}
+	if rangeavg < 0x7000 || rangeavg > 0x9000 {
+		t.Errorf("WarpedNoise2 is not centered around 0x8000: %d", rangeavg)
+	}
+	if changed < numTests*9/10 {
+		t.Errorf("warping did not change the output often enough: %d of %d", changed, numTests)
+	}
+}
+
+func TestWarpedNoise3(t *testing.T) {
+	r := rand.NewSource(0)
+	const numTests = 30000
+	var rangemin, rangemax uint16 = 0xffff, 0
+	rangesum := 0
+	for n := 0; n < numTests; n++ {
+		x := uint32(r.Int63()) >> 4
+		y := uint32(r.Int63()) >> 4
+		z := uint32(r.Int63()) >> 4
+		v := WarpedNoise3(x, y, z, 0x400, 3)
+		rangesum += int(v)
+		if v < rangemin {
+			rangemin = v
+		}
+		if v > rangemax {
+			rangemax = v
+		}
+	}
+	rangeavg := rangesum / numTests
+	t.Logf("number of tests: %d", numTests)
+	t.Logf("range: avg %d max %d min %d", rangeavg, rangemax, rangemin)
+	if rangemin > 0x4000 || rangemax < 0xc000 {
+		t.Errorf("WarpedNoise3 covers too little of the uint16 range: %d..%d", rangemin, rangemax)
+	}
+	if rangeavg < 0x7000 || rangeavg > 0x9000 {
+		t.Errorf("WarpedNoise3 is not centered around 0x8000: %d", rangeavg)
+	}
+}
+
+func BenchmarkWarpedNoise2(b *testing.B) {
+	var r uint16
+	for i := 0; i < b.N; i++ {
+		r = WarpedNoise2(uint32(i)<<8, uint32(i)<<4, 0x400, 3)
+	}
+	resultUint16 = r
+}
+
+func BenchmarkWarpedNoise3(b *testing.B) {
+	var r uint16
+	for i := 0; i < b.N; i++ {
+		r = WarpedNoise3(uint32(i)<<8, uint32(i)<<4, uint32(i)<<2, 0x400, 3)
+	}
+	resultUint16 = r
+}
@@ -0,0 +1,113 @@
+package wled
+
+import (
+	"image/color"
+	"time"
+
+	"github.com/aykevl/ledsgo"
+	"github.com/aykevl/ledsgo/demos"
+)
+
+// EffectParams are the settings of a segment that are passed to an effect.
+type EffectParams struct {
+	Colors    [3]color.RGBA // primary, secondary and tertiary color
+	Palette   *ledsgo.Palette16
+	Speed     uint8 // 128 is the default speed
+	Intensity uint8
+}
+
+// Effect is an animation that can be selected in a segment.
+type Effect struct {
+	Name string
+
+	// Draw draws the effect into the strip, which is the part of the strip
+	// covered by the segment. Brightness is applied afterwards.
+	Draw func(strip ledsgo.Strip, now time.Time, params EffectParams)
+}
+
+// Palette is a named palette that can be selected in a segment.
+type Palette struct {
+	Name   string
+	Colors *ledsgo.Palette16
+}
+
+// DefaultEffects are the effects of a new Controller. The first effect (Solid)
+// is the default.
+var DefaultEffects = []Effect{
+	{"Solid", drawSolid},
+	{"Palette", drawPalette},
+	{"Noise", drawNoise},
+	DemoEffect("Fire", demos.Fire),
+	DemoEffect("Warp", demos.Warp),
+}
+
+// DefaultPalettes are the palettes of a new Controller. The first palette
+// (Rainbow) is the default.
+var DefaultPalettes = []Palette{
+	{"Rainbow", &ledsgo.RainbowColors},
+	{"Rainbow Bands", &ledsgo.RainbowStripeColors},
+	{"Party", &ledsgo.PartyColors},
+	{"Cloud", &ledsgo.CloudColors},
+	{"Lava", &ledsgo.LavaColors},
+	{"Ocean", &ledsgo.OceanColors},
+	{"Forest", &ledsgo.ForestColors},
+	{"Heat", &ledsgo.HeatColors},
+}
+
+// Return the time scaled with the speed setting: 128 is normal speed, 0 is
+// very slow and 255 is twice as fast.
+func scaleTime(now time.Time, speed uint8) int64 {
+	return now.UnixNano() / 128 * (int64(speed) + 1)
+}
+
+// Fill the segment with the primary color.
+func drawSolid(strip ledsgo.Strip, now time.Time, params EffectParams) {
+	strip.FillSolid(params.Colors[0])
+}
+
+// Move the palette along the strip. The intensity determines how many times
+// the palette is repeated: once at 127, twice at 255.
+func drawPalette(strip ledsgo.Strip, now time.Time, params EffectParams) {
+	offset := uint16(scaleTime(now, params.Speed) >> 16)
+	step := uint32(0x10000) / uint32(len(strip)) * (uint32(params.Intensity) + 1) / 128
+	for i := range strip {
+		strip[i] = params.Palette.ColorAt(offset + uint16(uint32(i)*step))
+	}
+}
+
+// Show (equalized) noise mapped to the palette. The intensity determines the
+// amount of detail.
+func drawNoise(strip ledsgo.Strip, now time.Time, params EffectParams) {
+	t := uint32(scaleTime(now, params.Speed) >> 20)
+	spread := uint32(params.Intensity)/16 + 2
+	for i := range strip {
+		n := ledsgo.EqualizeNoise2(ledsgo.Noise2(t, uint32(i)<<spread<<4))
+		strip[i] = params.Palette.ColorAt(n)
+	}
+}
+
+// DemoEffect wraps an animation from the demos package as an effect. The
+// segment is shown to the animation as a display of one pixel high. The speed
+// setting is taken into account, other settings are ignored.
+func DemoEffect(name string, draw func(display demos.Displayer, now time.Time)) Effect {
+	return Effect{
+		Name: name,
+		Draw: func(strip ledsgo.Strip, now time.Time, params EffectParams) {
+			draw(stripDisplayer(strip), time.Unix(0, scaleTime(now, params.Speed)))
+		},
+	}
+}
+
+// stripDisplayer shows a strip as a display of one pixel high.
+type stripDisplayer ledsgo.Strip
+
+func (d stripDisplayer) Size() (x, y int16) {
+	return int16(len(d)), 1
+}
+
+func (d stripDisplayer) SetPixel(x, y int16, c color.RGBA) {
+	if x < 0 || int(x) >= len(d) || y != 0 {
+		return
+	}
+	d[x] = c
+}
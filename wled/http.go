@@ -0,0 +1,462 @@
+package wled
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"image/color"
+	"net/http"
+	"runtime"
+	"time"
+
+	"github.com/aykevl/ledsgo"
+)
+
+// WLED version reported in the info object. Clients use it to decide which
+// parts of the API they can use.
+const apiVersion = "0.14.0"
+
+var (
+	errInvalidColor   = errors.New("wled: invalid color")
+	errInvalidOn      = errors.New("wled: invalid on value")
+	errInvalidSegment = errors.New("wled: invalid segment")
+)
+
+// JSON encoding of the state object.
+type stateJSON struct {
+	On         bool          `json:"on"`
+	Brightness uint8         `json:"bri"`
+	Transition int           `json:"transition"`
+	Preset     int           `json:"ps"`
+	Playlist   int           `json:"pl"`
+	MainSeg    int           `json:"mainseg"`
+	Segments   []segmentJSON `json:"seg"`
+}
+
+// JSON encoding of a segment in the state object.
+type segmentJSON struct {
+	ID         int       `json:"id"`
+	Start      int       `json:"start"`
+	Stop       int       `json:"stop"`
+	Length     int       `json:"len"`
+	Grouping   int       `json:"grp"`
+	Spacing    int       `json:"spc"`
+	On         bool      `json:"on"`
+	Brightness uint8     `json:"bri"`
+	Colors     [3][3]int `json:"col"`
+	Effect     int       `json:"fx"`
+	Speed      uint8     `json:"sx"`
+	Intensity  uint8     `json:"ix"`
+	Palette    int       `json:"pal"`
+	Selected   bool      `json:"sel"`
+	Reverse    bool      `json:"rev"`
+	Mirror     bool      `json:"mi"`
+}
+
+// JSON encoding of the info object.
+type infoJSON struct {
+	Version   string       `json:"ver"`
+	VersionID int          `json:"vid"`
+	LEDs      infoLEDsJSON `json:"leds"`
+	Name      string       `json:"name"`
+	UDPPort   int          `json:"udpport"`
+	Live      bool         `json:"live"`
+	FxCount   int          `json:"fxcount"`
+	PalCount  int          `json:"palcount"`
+	Arch      string       `json:"arch"`
+	Core      string       `json:"core"`
+	Uptime    int          `json:"uptime"`
+	Brand     string       `json:"brand"`
+	Product   string       `json:"product"`
+	MAC       string       `json:"mac"`
+}
+
+type infoLEDsJSON struct {
+	Count  int  `json:"count"`
+	RGBW   bool `json:"rgbw"`
+	FPS    int  `json:"fps"`
+	MaxSeg int  `json:"maxseg"`
+}
+
+// Partial state update, as sent by clients. Fields that are not present are
+// left unmodified.
+type stateUpdate struct {
+	On         json.RawMessage `json:"on"`
+	Brightness *int            `json:"bri"`
+	Transition *int            `json:"transition"`
+	MainSeg    *int            `json:"mainseg"`
+	Segments   json.RawMessage `json:"seg"`
+	Verbose    bool            `json:"v"`
+}
+
+// Partial segment update.
+type segmentUpdate struct {
+	ID         *int              `json:"id"`
+	Start      *int              `json:"start"`
+	Stop       *int              `json:"stop"`
+	On         json.RawMessage   `json:"on"`
+	Brightness *int              `json:"bri"`
+	Colors     []json.RawMessage `json:"col"`
+	Effect     *int              `json:"fx"`
+	Speed      *int              `json:"sx"`
+	Intensity  *int              `json:"ix"`
+	Palette    *int              `json:"pal"`
+	Selected   *bool             `json:"sel"`
+	Reverse    *bool             `json:"rev"`
+}
+
+// ServeHTTP implements the JSON API at /json and its subpaths:
+//
+//	/json        state, info, effects and palettes (GET, POST)
+//	/json/state  state (GET, POST)
+//	/json/si     state and info (GET, POST)
+//	/json/info   info (GET)
+//	/json/eff    list of effect names (GET)
+//	/json/pal    list of palette names (GET)
+//
+// A POST request updates the state and returns {"success":true}, or the new
+// state when the request contains "v":true.
+func (c *Controller) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	if path == "/json/" {
+		path = "/json"
+	}
+	canUpdate := path == "/json" || path == "/json/state" || path == "/json/si"
+	switch path {
+	case "/json", "/json/state", "/json/si", "/json/info", "/json/eff", "/json/pal":
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet:
+	case r.Method == http.MethodPost && canUpdate:
+		var update stateUpdate
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			writeError(w, err)
+			return
+		}
+		if err := c.update(&update); err != nil {
+			writeError(w, err)
+			return
+		}
+		if !update.Verbose {
+			writeJSON(w, map[string]bool{"success": true})
+			return
+		}
+		path = "/json/state"
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	switch path {
+	case "/json":
+		writeJSON(w, map[string]interface{}{
+			"state":    c.stateJSON(),
+			"info":     c.infoJSON(),
+			"effects":  c.effectNames(),
+			"palettes": c.paletteNames(),
+		})
+	case "/json/state":
+		writeJSON(w, c.stateJSON())
+	case "/json/si":
+		writeJSON(w, map[string]interface{}{
+			"state": c.stateJSON(),
+			"info":  c.infoJSON(),
+		})
+	case "/json/info":
+		writeJSON(w, c.infoJSON())
+	case "/json/eff":
+		writeJSON(w, c.effectNames())
+	case "/json/pal":
+		writeJSON(w, c.paletteNames())
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+func (c *Controller) stateJSON() stateJSON {
+	state := stateJSON{
+		On:         c.on,
+		Brightness: c.brightness,
+		Transition: c.transition,
+		Preset:     -1,
+		Playlist:   -1,
+		MainSeg:    c.mainSeg,
+		Segments:   make([]segmentJSON, len(c.segments)),
+	}
+	for i, seg := range c.segments {
+		s := segmentJSON{
+			ID:         seg.id,
+			Start:      seg.start,
+			Stop:       seg.stop,
+			Length:     seg.stop - seg.start,
+			Grouping:   1,
+			On:         seg.on,
+			Brightness: seg.brightness,
+			Effect:     seg.effect,
+			Speed:      seg.speed,
+			Intensity:  seg.intensity,
+			Palette:    seg.palette,
+			Selected:   seg.selected,
+			Reverse:    seg.reverse,
+		}
+		for j, col := range seg.colors {
+			s.Colors[j] = [3]int{int(col.R), int(col.G), int(col.B)}
+		}
+		state.Segments[i] = s
+	}
+	return state
+}
+
+func (c *Controller) infoJSON() infoJSON {
+	return infoJSON{
+		Version:   apiVersion,
+		VersionID: 2310130,
+		LEDs: infoLEDsJSON{
+			Count:  len(c.strip),
+			FPS:    c.fps,
+			MaxSeg: maxSegments,
+		},
+		Name:     c.Name,
+		FxCount:  len(c.Effects),
+		PalCount: len(c.Palettes),
+		Arch:     runtime.GOARCH,
+		Core:     runtime.Version(),
+		Uptime:   int(time.Since(c.started) / time.Second),
+		Brand:    "WLED",
+		Product:  "ledsgo",
+	}
+}
+
+func (c *Controller) effectNames() []string {
+	names := make([]string, len(c.Effects))
+	for i, effect := range c.Effects {
+		names[i] = effect.Name
+	}
+	return names
+}
+
+func (c *Controller) paletteNames() []string {
+	names := make([]string, len(c.Palettes))
+	for i, palette := range c.Palettes {
+		names[i] = palette.Name
+	}
+	return names
+}
+
+// Apply a state update. Values out of range are clamped, like WLED does. The
+// update is validated before it is applied, so that an invalid update doesn't
+// change anything.
+func (c *Controller) update(update *stateUpdate) error {
+	// Parse the segment updates: either a single object or an array.
+	var segments []segmentUpdate
+	if len(update.Segments) != 0 {
+		if update.Segments[0] == '[' {
+			if err := json.Unmarshal(update.Segments, &segments); err != nil {
+				return err
+			}
+			for i := range segments {
+				if segments[i].ID == nil {
+					id := i
+					segments[i].ID = &id
+				}
+			}
+		} else {
+			var seg segmentUpdate
+			if err := json.Unmarshal(update.Segments, &seg); err != nil {
+				return err
+			}
+			if seg.ID == nil {
+				id := c.mainSeg
+				seg.ID = &id
+			}
+			segments = []segmentUpdate{seg}
+		}
+	}
+	on, err := parseOn(update.On, c.on)
+	if err != nil {
+		return err
+	}
+	for i := range segments {
+		if err := c.checkSegment(&segments[i]); err != nil {
+			return err
+		}
+	}
+
+	c.on = on
+	if update.Brightness != nil {
+		c.brightness = clamp8(*update.Brightness)
+		if c.brightness == 0 {
+			c.on = false
+		}
+	}
+	if update.Transition != nil && *update.Transition >= 0 {
+		c.transition = *update.Transition
+	}
+	for i := range segments {
+		c.updateSegment(&segments[i])
+	}
+	if update.MainSeg != nil && c.segment(*update.MainSeg) != nil {
+		c.mainSeg = *update.MainSeg
+	}
+	return nil
+}
+
+// Check whether a segment update is valid, before applying it.
+func (c *Controller) checkSegment(update *segmentUpdate) error {
+	id := *update.ID
+	if id < 0 || id >= maxSegments {
+		return errInvalidSegment
+	}
+	if c.segment(id) == nil && (update.Stop == nil || *update.Stop <= 0) {
+		// A new segment needs at least a stop value.
+		return errInvalidSegment
+	}
+	if _, err := parseOn(update.On, false); err != nil {
+		return err
+	}
+	if len(update.Colors) > 3 {
+		return errInvalidColor
+	}
+	for _, raw := range update.Colors {
+		if _, err := parseColor(raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply a segment update that has been checked by checkSegment.
+func (c *Controller) updateSegment(update *segmentUpdate) {
+	seg := c.segment(*update.ID)
+	if seg == nil {
+		seg = newSegment(*update.ID, 0, 0)
+		c.addSegment(seg)
+	}
+	if update.Start != nil {
+		seg.start = clampInt(*update.Start, 0, len(c.strip))
+	}
+	if update.Stop != nil {
+		seg.stop = clampInt(*update.Stop, 0, len(c.strip))
+	}
+	if seg.stop <= seg.start {
+		// A segment with a stop value at or before the start is deleted.
+		c.removeSegment(seg.id)
+		if seg.stop < seg.start {
+			seg.stop = seg.start
+		}
+		return
+	}
+	seg.on, _ = parseOn(update.On, seg.on)
+	if update.Brightness != nil {
+		seg.brightness = clamp8(*update.Brightness)
+	}
+	for i, raw := range update.Colors {
+		col, _ := parseColor(raw)
+		if col != nil {
+			seg.colors[i] = *col
+		}
+	}
+	if update.Effect != nil {
+		seg.effect = clampInt(*update.Effect, 0, len(c.Effects)-1)
+	}
+	if update.Speed != nil {
+		seg.speed = clamp8(*update.Speed)
+	}
+	if update.Intensity != nil {
+		seg.intensity = clamp8(*update.Intensity)
+	}
+	if update.Palette != nil {
+		seg.palette = clampInt(*update.Palette, 0, len(c.Palettes)-1)
+	}
+	if update.Selected != nil {
+		seg.selected = *update.Selected
+	}
+	if update.Reverse != nil {
+		seg.reverse = *update.Reverse
+	}
+}
+
+// Parse an on value, which is either a boolean or "t" to toggle the current
+// value.
+func parseOn(raw json.RawMessage, current bool) (bool, error) {
+	if len(raw) == 0 {
+		return current, nil
+	}
+	var on bool
+	if err := json.Unmarshal(raw, &on); err == nil {
+		return on, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil && s == "t" {
+		return !current, nil
+	}
+	return false, errInvalidOn
+}
+
+// Parse a color, which is either an array of 3 or 4 (RGBW) values or a hex
+// string like "FF8000". An empty array leaves the color unmodified, in which
+// case nil is returned.
+func parseColor(raw json.RawMessage) (*color.RGBA, error) {
+	var values []int
+	if err := json.Unmarshal(raw, &values); err == nil {
+		switch len(values) {
+		case 0:
+			return nil, nil
+		case 3, 4:
+			col := color.RGBA{clamp8(values[0]), clamp8(values[1]), clamp8(values[2]), 255}
+			if len(values) == 4 {
+				// There is no white channel, add it to the color instead.
+				w := clamp8(values[3])
+				col = ledsgo.RGBW.Get([]byte{col.R, col.G, col.B, w})
+			}
+			return &col, nil
+		}
+		return nil, errInvalidColor
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil && (len(s) == 6 || len(s) == 8) {
+		b, err := hex.DecodeString(s)
+		if err == nil {
+			var col color.RGBA
+			if len(b) == 4 {
+				// WWRRGGBB
+				col = ledsgo.RGBW.Get([]byte{b[1], b[2], b[3], b[0]})
+			} else {
+				col = color.RGBA{b[0], b[1], b[2], 255}
+			}
+			return &col, nil
+		}
+	}
+	return nil, errInvalidColor
+}
+
+func clamp8(n int) uint8 {
+	return uint8(clampInt(n, 0, 255))
+}
+
+func clampInt(n, low, high int) int {
+	if n < low {
+		return low
+	}
+	if n > high {
+		return high
+	}
+	return n
+}
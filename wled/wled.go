@@ -0,0 +1,188 @@
+// Package wled implements the JSON API of WLED, so that existing WLED clients
+// (such as the WLED app and Home Assistant) can control a ledsgo based LED
+// controller. The supported part of the API is the state (on/off, brightness
+// and segments with their colors, effect and palette), the info object and
+// the lists of effects and palettes.
+//
+// Effects and palettes are ledsgo animations and Palette16 palettes, see
+// DefaultEffects and DefaultPalettes.
+//
+// See https://kno.wled.ge/interfaces/json-api/ for the API.
+package wled
+
+import (
+	"image/color"
+	"sync"
+	"time"
+
+	"github.com/aykevl/ledsgo"
+)
+
+// Maximum number of segments.
+const maxSegments = 16
+
+// Controller keeps the WLED state of a strip and renders it. It implements
+// http.Handler for the JSON API.
+type Controller struct {
+	// Name is the name of the controller, as shown in WLED clients.
+	Name string
+
+	// Effects and Palettes that can be selected. They may be changed before
+	// the controller is used. Segments that use an effect or palette beyond
+	// the end of a shorter list are drawn with the last one instead.
+	Effects  []Effect
+	Palettes []Palette
+
+	lock       sync.Mutex
+	strip      ledsgo.Strip
+	on         bool
+	brightness uint8
+	transition int
+	mainSeg    int
+	segments   []*segment // sorted by ID
+	started    time.Time
+	frames     int
+	lastFPS    time.Time
+	fps        int
+}
+
+// A single segment: a part of the strip with its own effect.
+type segment struct {
+	id         int
+	start      int
+	stop       int // exclusive
+	on         bool
+	brightness uint8
+	colors     [3]color.RGBA
+	effect     int
+	speed      uint8
+	intensity  uint8
+	palette    int
+	reverse    bool
+	selected   bool
+}
+
+// NewController creates a new controller for the given strip, with a single
+// segment covering the whole strip. The controller is switched on, with the
+// default effect (a solid color).
+func NewController(strip ledsgo.Strip) *Controller {
+	c := &Controller{
+		Name:       "ledsgo",
+		Effects:    DefaultEffects,
+		Palettes:   DefaultPalettes,
+		strip:      strip,
+		on:         true,
+		brightness: 128,
+		transition: 7,
+		started:    time.Now(),
+	}
+	c.segments = []*segment{newSegment(0, 0, len(strip))}
+	return c
+}
+
+// Create a segment with default settings.
+func newSegment(id, start, stop int) *segment {
+	return &segment{
+		id:         id,
+		start:      start,
+		stop:       stop,
+		on:         true,
+		brightness: 255,
+		colors:     [3]color.RGBA{{255, 160, 0, 255}, {0, 0, 0, 255}, {0, 0, 0, 255}},
+		speed:      128,
+		intensity:  128,
+		selected:   true,
+	}
+}
+
+// Render draws all segments into the strip at the given time, applying the
+// brightness of the segments and the global brightness. LEDs that are not
+// part of a segment are black, as are all LEDs when the controller is off.
+func (c *Controller) Render(now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.frames++
+	if now.Sub(c.lastFPS) >= time.Second {
+		c.fps = c.frames
+		c.frames = 0
+		c.lastFPS = now
+	}
+
+	c.strip.FillSolid(color.RGBA{0, 0, 0, 255})
+	if !c.on || c.brightness == 0 {
+		return
+	}
+	for _, seg := range c.segments {
+		if !seg.on {
+			continue
+		}
+		part := c.strip[seg.start:seg.stop]
+		if len(part) == 0 || len(c.Effects) == 0 {
+			continue
+		}
+		// Don't modify the selected effect and palette, so that they are
+		// used again when the lists are restored.
+		effect := c.Effects[clampInt(seg.effect, 0, len(c.Effects)-1)]
+		palette := DefaultPalettes[0].Colors
+		if len(c.Palettes) != 0 {
+			palette = c.Palettes[clampInt(seg.palette, 0, len(c.Palettes)-1)].Colors
+		}
+		effect.Draw(part, now, EffectParams{
+			Colors:    seg.colors,
+			Palette:   palette,
+			Speed:     seg.speed,
+			Intensity: seg.intensity,
+		})
+		if seg.reverse {
+			for i, j := 0, len(part)-1; i < j; i, j = i+1, j-1 {
+				part[i], part[j] = part[j], part[i]
+			}
+		}
+		brightness := uint8(uint32(seg.brightness) * uint32(c.brightness) / 255)
+		if brightness != 255 {
+			for i, col := range part {
+				col = ledsgo.ApplyAlpha(col, brightness)
+				col.A = 255
+				part[i] = col
+			}
+		}
+	}
+}
+
+// Return the segment with the given ID, or nil if it doesn't exist.
+func (c *Controller) segment(id int) *segment {
+	for _, seg := range c.segments {
+		if seg.id == id {
+			return seg
+		}
+	}
+	return nil
+}
+
+// Add a new segment, keeping the segments sorted by ID.
+func (c *Controller) addSegment(seg *segment) {
+	i := 0
+	for i < len(c.segments) && c.segments[i].id < seg.id {
+		i++
+	}
+	c.segments = append(c.segments, nil)
+	copy(c.segments[i+1:], c.segments[i:])
+	c.segments[i] = seg
+}
+
+// Remove the segment with the given ID. The last segment is never removed.
+func (c *Controller) removeSegment(id int) {
+	if len(c.segments) <= 1 {
+		return
+	}
+	for i, seg := range c.segments {
+		if seg.id == id {
+			c.segments = append(c.segments[:i], c.segments[i+1:]...)
+			break
+		}
+	}
+	if c.segment(c.mainSeg) == nil {
+		c.mainSeg = c.segments[0].id
+	}
+}
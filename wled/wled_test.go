@@ -0,0 +1,224 @@
+package wled
+
+import (
+	"encoding/json"
+	"image/color"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aykevl/ledsgo"
+)
+
+// Send a request to the controller and decode the JSON response into v.
+func request(t *testing.T, server *httptest.Server, method, path, body string, v interface{}) int {
+	t.Helper()
+	req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatal("request failed:", err)
+	}
+	defer resp.Body.Close()
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("%s %s: could not decode response: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestGet(t *testing.T) {
+	c := NewController(make(ledsgo.Strip, 30))
+	c.Name = "test"
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	var state stateJSON
+	request(t, server, "GET", "/json/state", "", &state)
+	if !state.On || state.Brightness != 128 || len(state.Segments) != 1 {
+		t.Errorf("unexpected state: %+v", state)
+	}
+	if seg := state.Segments[0]; seg.Start != 0 || seg.Stop != 30 || seg.Length != 30 || seg.Colors[0] != [3]int{255, 160, 0} {
+		t.Errorf("unexpected segment: %+v", seg)
+	}
+
+	var info infoJSON
+	request(t, server, "GET", "/json/info", "", &info)
+	if info.Name != "test" || info.LEDs.Count != 30 || info.FxCount != len(DefaultEffects) || info.PalCount != len(DefaultPalettes) || info.Brand != "WLED" {
+		t.Errorf("unexpected info: %+v", info)
+	}
+
+	var names []string
+	request(t, server, "GET", "/json/eff", "", &names)
+	if len(names) != len(DefaultEffects) || names[0] != "Solid" {
+		t.Errorf("unexpected effects: %v", names)
+	}
+	request(t, server, "GET", "/json/pal", "", &names)
+	if len(names) != len(DefaultPalettes) || names[0] != "Rainbow" {
+		t.Errorf("unexpected palettes: %v", names)
+	}
+
+	var all map[string]json.RawMessage
+	request(t, server, "GET", "/json", "", &all)
+	for _, key := range []string{"state", "info", "effects", "palettes"} {
+		if _, ok := all[key]; !ok {
+			t.Errorf("missing %q in /json", key)
+		}
+	}
+
+	if status := request(t, server, "GET", "/json/foo", "", nil); status != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", status)
+	}
+	if status := request(t, server, "POST", "/json/info", "{}", nil); status != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", status)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	strip := make(ledsgo.Strip, 10)
+	c := NewController(strip)
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	var result map[string]bool
+	request(t, server, "POST", "/json/state", `{"on":true,"bri":255,"seg":{"col":[[0,0,255]]}}`, &result)
+	if !result["success"] {
+		t.Errorf("unexpected result: %v", result)
+	}
+	c.Render(time.Now())
+	if strip[0] != (color.RGBA{0, 0, 255, 255}) || strip[9] != (color.RGBA{0, 0, 255, 255}) {
+		t.Errorf("unexpected strip: %v", strip)
+	}
+
+	// Split in two segments, with the verbose flag to get the new state back.
+	var state stateJSON
+	request(t, server, "POST", "/json/state", `{"seg":[{"stop":5,"bri":128},{"id":1,"start":5,"stop":10,"col":["FF0000"],"fx":1,"pal":2,"sx":200}],"v":true}`, &state)
+	if len(state.Segments) != 2 {
+		t.Fatalf("expected 2 segments, got %+v", state.Segments)
+	}
+	if seg := state.Segments[1]; seg.ID != 1 || seg.Start != 5 || seg.Stop != 10 || seg.Effect != 1 || seg.Palette != 2 || seg.Speed != 200 || seg.Colors[0] != [3]int{255, 0, 0} {
+		t.Errorf("unexpected new segment: %+v", seg)
+	}
+	c.Render(time.Now())
+	if strip[0] != (color.RGBA{0, 0, 128, 255}) {
+		t.Errorf("segment brightness not applied: %v", strip[0])
+	}
+	if strip[5] == strip[9] {
+		t.Errorf("palette effect is not drawn: %v", strip[5:])
+	}
+
+	// Toggle off: everything is black.
+	request(t, server, "POST", "/json/state", `{"on":"t"}`, nil)
+	c.Render(time.Now())
+	for i, col := range strip {
+		if col != (color.RGBA{0, 0, 0, 255}) {
+			t.Errorf("LED %d is not black after switching off: %v", i, col)
+		}
+	}
+	request(t, server, "POST", "/json/state", `{"on":"t"}`, &result)
+
+	// Remove the second segment.
+	request(t, server, "POST", "/json", `{"seg":{"id":1,"stop":0},"v":true}`, &state)
+	if len(state.Segments) != 1 || !state.On {
+		t.Errorf("unexpected state after removing segment: %+v", state)
+	}
+
+	// Out of range values are clamped.
+	request(t, server, "POST", "/json/state", `{"bri":1000,"seg":{"id":0,"fx":100,"pal":-1,"stop":50},"v":true}`, &state)
+	if seg := state.Segments[0]; state.Brightness != 255 || seg.Effect != len(DefaultEffects)-1 || seg.Palette != 0 || seg.Stop != 10 {
+		t.Errorf("values are not clamped: %+v", state)
+	}
+
+	// Invalid updates are rejected and don't change anything.
+	for _, body := range []string{
+		`{"on":"x","bri":10}`,
+		`{"bri":10,"seg":{"col":[[1,2]]}}`,
+		`{"bri":10,"seg":{"id":20,"stop":5}}`,
+		`{"bri":10,"seg":{"id":3,"start":5}}`,
+		`not json`,
+	} {
+		if status := request(t, server, "POST", "/json/state", body, nil); status != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", body, status)
+		}
+	}
+	request(t, server, "GET", "/json/state", "", &state)
+	if state.Brightness != 255 {
+		t.Errorf("invalid update changed the state: %+v", state)
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		json  string
+		color color.RGBA
+	}{
+		{`[1,2,3]`, color.RGBA{1, 2, 3, 255}},
+		{`[1,2,3,10]`, color.RGBA{11, 12, 13, 255}},
+		{`[300,-5,3]`, color.RGBA{255, 0, 3, 255}},
+		{`"ff8000"`, color.RGBA{255, 128, 0, 255}},
+		{`"10ff8000"`, color.RGBA{255, 144, 16, 255}},
+	}
+	for _, tc := range tests {
+		col, err := parseColor(json.RawMessage(tc.json))
+		if err != nil || col == nil || *col != tc.color {
+			t.Errorf("parseColor(%s): expected %v, got %v (%v)", tc.json, tc.color, col, err)
+		}
+	}
+	if col, err := parseColor(json.RawMessage(`[]`)); col != nil || err != nil {
+		t.Errorf("expected no color for empty array, got %v %v", col, err)
+	}
+}
+
+func TestEffects(t *testing.T) {
+	// All effects must draw something for various strip sizes, without
+	// panicking.
+	now := time.Now()
+	for _, effect := range DefaultEffects {
+		for _, size := range []int{1, 7, 100} {
+			strip := make(ledsgo.Strip, size)
+			effect.Draw(strip, now, EffectParams{
+				Colors:    [3]color.RGBA{{255, 0, 0, 255}},
+				Palette:   &ledsgo.RainbowColors,
+				Speed:     128,
+				Intensity: 128,
+			})
+		}
+	}
+}
+
+func TestShorterEffects(t *testing.T) {
+	strip := make(ledsgo.Strip, 10)
+	c := NewController(strip)
+	server := httptest.NewServer(c)
+	defer server.Close()
+	request(t, server, "POST", "/json/state", `{"bri":255,"seg":{"col":[[0,0,255]],"fx":3,"pal":4}}`, nil)
+
+	// Replacing the lists with shorter ones must not panic, but use the last
+	// effect and palette.
+	c.Effects = DefaultEffects[:1]
+	c.Palettes = DefaultPalettes[:1]
+	c.Render(time.Now())
+	if strip[0] != (color.RGBA{0, 0, 255, 255}) {
+		t.Errorf("expected the solid effect, got %v", strip[0])
+	}
+	c.Effects = DefaultEffects
+	c.Palettes = DefaultPalettes
+	var state stateJSON
+	request(t, server, "GET", "/json/state", "", &state)
+	if seg := state.Segments[0]; seg.Effect != 3 || seg.Palette != 4 {
+		t.Errorf("expected effect 3 and palette 4 after restoring the lists, got %d and %d", seg.Effect, seg.Palette)
+	}
+
+	// Without any effects, segments stay black.
+	c.Effects = nil
+	c.Palettes = nil
+	c.Render(time.Now())
+	if strip[0] != (color.RGBA{0, 0, 0, 255}) {
+		t.Errorf("expected a black strip, got %v", strip[0])
+	}
+}
@@ -0,0 +1,168 @@
+package ledsgo
+
+// This file implements Worley noise, also known as cellular noise. Every cell
+// in the integer lattice contains exactly one feature point at a
+// pseudo-random position (derived from the same permutation table as the
+// simplex noise). The noise value at a given point is derived from the
+// distance to the closest feature point (F1) and the second closest feature
+// point (F2). Only the 3x3 (or 3x3x3) neighborhood of cells is searched, which
+// is enough for virtually all inputs.
+//
+// Like the simplex noise functions, all inputs are 20.12 fixed-point values
+// and the lattice repeats every 256 cells.
+
+// Maximum distances that are mapped to 0xffff. Distances that are further away
+// are clamped. These values have been chosen to use nearly the full uint16
+// range while rarely clamping.
+const (
+	worley2MaxF1   = 0x1000 * 9 / 10 // .12: 0.9
+	worley2MaxF2   = 0x1000 * 5 / 4  // .12: 1.25
+	worley2MaxEdge = 0x1000 * 1      // .12: 1.0
+	worley3MaxF1   = 0x1000 * 1      // .12: 1.0
+	worley3MaxF2   = 0x1000 * 5 / 4  // .12: 1.25
+	worley3MaxEdge = 0x1000 * 7 / 8  // .12: 0.875
+)
+
+// Return the offset of the feature point within the given 2D cell, as a .12
+// fixed-point value.
+func worleyPoint2(i, j uint32) (fx, fy int32) {
+	h := perm[(i+uint32(perm[j&0xff]))&0xff]
+	return int32(perm[h]) << 4, int32(perm[h^0x55]) << 4 // .8 << 4 = .12
+}
+
+// Return the offset of the feature point within the given 3D cell, as a .12
+// fixed-point value.
+func worleyPoint3(i, j, k uint32) (fx, fy, fz int32) {
+	h := perm[(i+uint32(perm[(j+uint32(perm[k&0xff]))&0xff]))&0xff]
+	return int32(perm[h]) << 4, int32(perm[h^0x55]) << 4, int32(perm[h^0xaa]) << 4 // .8 << 4 = .12
+}
+
+// worley2 returns the distance to the closest and second closest feature
+// point, as .12 fixed-point values.
+func worley2(x, y uint32) (f1, f2 int32) {
+	i := x >> 12
+	j := y >> 12
+	xf := int32(x & 0xfff) // .12
+	yf := int32(y & 0xfff) // .12
+
+	d1 := int32(1<<31 - 1) // .24: squared distance to closest point
+	d2 := int32(1<<31 - 1) // .24: squared distance to second closest point
+	for dj := int32(-1); dj <= 1; dj++ {
+		for di := int32(-1); di <= 1; di++ {
+			fx, fy := worleyPoint2(i+uint32(di), j+uint32(dj))
+			dx := di<<12 + fx - xf // .12
+			dy := dj<<12 + fy - yf // .12
+			d := dx*dx + dy*dy     // .24
+			if d < d1 {
+				d2 = d1
+				d1 = d
+			} else if d < d2 {
+				d2 = d
+			}
+		}
+	}
+	return int32(Sqrt(int(d1))), int32(Sqrt(int(d2))) // .12
+}
+
+// worley3 returns the distance to the closest and second closest feature
+// point, as .12 fixed-point values.
+func worley3(x, y, z uint32) (f1, f2 int32) {
+	i := x >> 12
+	j := y >> 12
+	k := z >> 12
+	xf := int32(x & 0xfff) // .12
+	yf := int32(y & 0xfff) // .12
+	zf := int32(z & 0xfff) // .12
+
+	d1 := int32(1<<31 - 1) // .24: squared distance to closest point
+	d2 := int32(1<<31 - 1) // .24: squared distance to second closest point
+	for dk := int32(-1); dk <= 1; dk++ {
+		for dj := int32(-1); dj <= 1; dj++ {
+			for di := int32(-1); di <= 1; di++ {
+				fx, fy, fz := worleyPoint3(i+uint32(di), j+uint32(dj), k+uint32(dk))
+				dx := di<<12 + fx - xf     // .12
+				dy := dj<<12 + fy - yf     // .12
+				dz := dk<<12 + fz - zf     // .12
+				d := dx*dx + dy*dy + dz*dz // .24
+				if d < d1 {
+					d2 = d1
+					d1 = d
+				} else if d < d2 {
+					d2 = d
+				}
+			}
+		}
+	}
+	return int32(Sqrt(int(d1))), int32(Sqrt(int(d2))) // .12
+}
+
+// Scale the .12 distance d so that max maps to 0xffff, clamping larger values.
+func worleyScale(d, max int32) uint16 {
+	if d >= max {
+		return 0xffff
+	}
+	return uint16(d * 0x10000 / max)
+}
+
+// 2D Worley noise, returning the distance to the closest feature point (F1).
+// This results in a pattern of bright cells with dark centers.
+//
+// The x and y inputs are 20.12 fixed-point values. The result covers the full
+// range of a uint16, but unlike simplex noise it is biased towards lower
+// values.
+func Worley2F1(x, y uint32) uint16 {
+	f1, _ := worley2(x, y)
+	return worleyScale(f1, worley2MaxF1)
+}
+
+// 2D Worley noise, returning the distance to the second closest feature point
+// (F2). This results in a somewhat crystal-like pattern.
+//
+// The x and y inputs are 20.12 fixed-point values. The result covers the full
+// range of a uint16.
+func Worley2F2(x, y uint32) uint16 {
+	_, f2 := worley2(x, y)
+	return worleyScale(f2, worley2MaxF2)
+}
+
+// 2D Worley noise, returning the difference between the distance to the
+// second closest and the closest feature point (F2-F1). The result is zero on
+// cell edges, which makes it useful for stained glass and crackle effects.
+//
+// The x and y inputs are 20.12 fixed-point values. The result covers the full
+// range of a uint16, but is biased towards lower values.
+func Worley2Edge(x, y uint32) uint16 {
+	f1, f2 := worley2(x, y)
+	return worleyScale(f2-f1, worley2MaxEdge)
+}
+
+// 3D Worley noise, returning the distance to the closest feature point (F1).
+// See Worley2F1 for details.
+//
+// The x, y and z inputs are 20.12 fixed-point values. The result covers the
+// full range of a uint16.
+func Worley3F1(x, y, z uint32) uint16 {
+	f1, _ := worley3(x, y, z)
+	return worleyScale(f1, worley3MaxF1)
+}
+
+// 3D Worley noise, returning the distance to the second closest feature point
+// (F2). See Worley2F2 for details.
+//
+// The x, y and z inputs are 20.12 fixed-point values. The result covers the
+// full range of a uint16.
+func Worley3F2(x, y, z uint32) uint16 {
+	_, f2 := worley3(x, y, z)
+	return worleyScale(f2, worley3MaxF2)
+}
+
+// 3D Worley noise, returning the difference between the distance to the
+// second closest and the closest feature point (F2-F1). See Worley2Edge for
+// details.
+//
+// The x, y and z inputs are 20.12 fixed-point values. The result covers the
+// full range of a uint16.
+func Worley3Edge(x, y, z uint32) uint16 {
+	f1, f2 := worley3(x, y, z)
+	return worleyScale(f2-f1, worley3MaxEdge)
+}
@@ -0,0 +1,156 @@
+package ledsgo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Floating point reference implementation of 2D Worley noise, using the same
+// feature points as the fixed-point implementation.
+func worley2Float(x, y float64) (f1, f2 float64) {
+	i := math.Floor(x)
+	j := math.Floor(y)
+	f1 = math.Inf(1)
+	f2 = math.Inf(1)
+	for dj := -1.0; dj <= 1; dj++ {
+		for di := -1.0; di <= 1; di++ {
+			fx, fy := worleyPoint2(uint32(int64(i+di)), uint32(int64(j+dj)))
+			dx := i + di + float64(fx)/0x1000 - x
+			dy := j + dj + float64(fy)/0x1000 - y
+			d := math.Sqrt(dx*dx + dy*dy)
+			if d < f1 {
+				f2 = f1
+				f1 = d
+			} else if d < f2 {
+				f2 = d
+			}
+		}
+	}
+	return
+}
+
+// Floating point reference implementation of 3D Worley noise, using the same
+// feature points as the fixed-point implementation.
+func worley3Float(x, y, z float64) (f1, f2 float64) {
+	i := math.Floor(x)
+	j := math.Floor(y)
+	k := math.Floor(z)
+	f1 = math.Inf(1)
+	f2 = math.Inf(1)
+	for dk := -1.0; dk <= 1; dk++ {
+		for dj := -1.0; dj <= 1; dj++ {
+			for di := -1.0; di <= 1; di++ {
+				fx, fy, fz := worleyPoint3(uint32(int64(i+di)), uint32(int64(j+dj)), uint32(int64(k+dk)))
+				dx := i + di + float64(fx)/0x1000 - x
+				dy := j + dj + float64(fy)/0x1000 - y
+				dz := k + dk + float64(fz)/0x1000 - z
+				d := math.Sqrt(dx*dx + dy*dy + dz*dz)
+				if d < f1 {
+					f2 = f1
+					f1 = d
+				} else if d < f2 {
+					f2 = d
+				}
+			}
+		}
+	}
+	return
+}
+
+func TestWorley2(t *testing.T) {
+	r := rand.NewSource(0)
+	const numTests = 1000000
+	diffsum := 0.0
+	diffmax := 0.0
+	var rangemin, rangemax uint16 = 0xffff, 0
+	for n := 0; n < numTests; n++ {
+		x := uint32(r.Int63())
+		y := uint32(r.Int63())
+		f1, f2 := worley2Float(float64(x)/0x1000, float64(y)/0x1000)
+		n1, n2 := worley2(x, y)
+		for _, diff := range []float64{f1 - float64(n1)/0x1000, f2 - float64(n2)/0x1000} {
+			diffsum += math.Abs(diff)
+			if math.Abs(diff) > diffmax {
+				diffmax = math.Abs(diff)
+			}
+		}
+		v := Worley2F1(x, y)
+		if v < rangemin {
+			rangemin = v
+		}
+		if v > rangemax {
+			rangemax = v
+		}
+	}
+	diffavg := diffsum / numTests / 2
+	t.Logf("number of tests: %d", numTests)
+	t.Logf("range: max %d min %d", rangemax, rangemin)
+	t.Logf("diff:  avg %2.6f max %2.6f", diffavg, diffmax)
+	if diffavg >= 0.0003 {
+		t.Errorf("diff avg between float and fixed-point is too big: %f", diffavg)
+	}
+	if diffmax > 0.001 {
+		t.Errorf("diff max is too high: %f", diffmax)
+	}
+	if rangemin > 0x100 || rangemax < 0xff00 {
+		t.Errorf("Worley2F1 does not cover the uint16 range: %d..%d", rangemin, rangemax)
+	}
+}
+
+func TestWorley3(t *testing.T) {
+	r := rand.NewSource(0)
+	const numTests = 300000
+	diffsum := 0.0
+	diffmax := 0.0
+	var rangemin, rangemax uint16 = 0xffff, 0
+	for n := 0; n < numTests; n++ {
+		x := uint32(r.Int63())
+		y := uint32(r.Int63())
+		z := uint32(r.Int63())
+		f1, f2 := worley3Float(float64(x)/0x1000, float64(y)/0x1000, float64(z)/0x1000)
+		n1, n2 := worley3(x, y, z)
+		for _, diff := range []float64{f1 - float64(n1)/0x1000, f2 - float64(n2)/0x1000} {
+			diffsum += math.Abs(diff)
+			if math.Abs(diff) > diffmax {
+				diffmax = math.Abs(diff)
+			}
+		}
+		v := Worley3F2(x, y, z)
+		if v < rangemin {
+			rangemin = v
+		}
+		if v > rangemax {
+			rangemax = v
+		}
+	}
+	diffavg := diffsum / numTests / 2
+	t.Logf("number of tests: %d", numTests)
+	t.Logf("range: max %d min %d", rangemax, rangemin)
+	t.Logf("diff:  avg %2.6f max %2.6f", diffavg, diffmax)
+	if diffavg >= 0.0003 {
+		t.Errorf("diff avg between float and fixed-point is too big: %f", diffavg)
+	}
+	if diffmax > 0.001 {
+		t.Errorf("diff max is too high: %f", diffmax)
+	}
+	if rangemin > 0x2000 || rangemax < 0xf000 {
+		t.Errorf("Worley3F2 does not cover the uint16 range: %d..%d", rangemin, rangemax)
+	}
+}
+
+func BenchmarkWorley2F1(b *testing.B) {
+	var r uint16
+	for n := 0; n < b.N; n++ {
+		r = Worley2F1(uint32(n), uint32(n))
+	}
+	resultUint16 = r
+}
+
+func BenchmarkWorley3F1(b *testing.B) {
+	var r uint16
+	for n := 0; n < b.N; n++ {
+		r = Worley3F1(uint32(n), uint32(n), uint32(n))
+	}
+	resultUint16 = r
+}